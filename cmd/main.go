@@ -3,23 +3,69 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	urfavecli "github.com/urfave/cli"
 
 	"go-notes/internal/cli"
+	"go-notes/internal/storage/memory"
 	"go-notes/internal/storage/sqlite"
 )
 
 const storageName = "storage.db" // Name of the SQLite database file
 
+// defaultLockTimeout is how long a command which may write notes waits for another run holding the database,
+// unless --lock-timeout is given
+var defaultLockTimeout = 10 * time.Second
+
+// dbEnv names the environment variable with the database path, used when --db is not given
+const dbEnv = "GO_NOTES_DB"
+
+// storage backends selectable with --backend
+const (
+	backendSQLite = "sqlite"
+	backendMemory = "memory"
+)
+
+// closableStorage is a storage holding resources which must be released on exit
+type closableStorage interface {
+	cli.Storage
+	Close() error
+}
+
 func main() {
-	// initialize the sqlite storage using the specified database file name
-	storage, err := sqlite.New(storageName)
+	os.Exit(run())
+}
+
+// run runs the application and returns its exit code, so deferred cleanup happens before exiting
+func run() int {
+	// the backend must be known before the application is built, as commands depend on storage capabilities
+	backend := globalOption(os.Args[1:], "backend", backendSQLite)
+	journalMode := globalOption(os.Args[1:], "journal-mode", "")
+	tablePrefix := globalOption(os.Args[1:], "table-prefix", "")
+	lockTimeout := globalOption(os.Args[1:], "lock-timeout", "")
+	if lockTimeout == "" && !cli.ReadOnlyCommand(commandArg(os.Args[1:])) {
+		// commands which may write notes are serialized with other runs by default
+		lockTimeout = defaultLockTimeout.String()
+	}
+	controlChars := globalOption(os.Args[1:], "control-chars", "")
+	path := databasePath(os.Args[1:], os.Getenv)
+
+	// initialize the storage of the chosen backend
+	storage, err := openStorage(backend, path, sqliteOptions{
+		journalMode:  journalMode,
+		tablePrefix:  tablePrefix,
+		lockTimeout:  lockTimeout,
+		controlChars: controlChars,
+	})
 	if err != nil {
 		fmt.Printf("Error initializing storage: %v\n", err)
-		os.Exit(1)
+		return 1
 	}
 
 	defer func() {
-		// close the storage when the main function exits
+		// close the storage when run returns
 		if err = storage.Close(); err != nil {
 			fmt.Printf("Error closing storage: %v\n", err)
 		}
@@ -27,10 +73,154 @@ func main() {
 
 	// create a new CLI application with the initialized storage
 	app := cli.NewCLI(storage)
+	app.Flags = append(app.Flags, urfavecli.StringFlag{
+		Name:  "db",
+		Value: storageName,
+		Usage: "path of the sqlite database, defaults to $" + dbEnv + " if set",
+	}, urfavecli.StringFlag{
+		Name:  "backend",
+		Value: backendSQLite,
+		Usage: "storage backend: sqlite or memory (notes are lost on exit)",
+	}, urfavecli.StringFlag{
+		Name:  "journal-mode",
+		Usage: "journal mode of the sqlite database, e.g. wal",
+	}, urfavecli.StringFlag{
+		Name:  "table-prefix",
+		Usage: "prefix of sqlite tables, so several notebooks share the database, e.g. work_",
+	}, urfavecli.StringFlag{
+		Name:  "lock-timeout",
+		Usage: fmt.Sprintf("time to wait for another run holding the sqlite database, e.g. 5s; commands which may write notes wait %s by default", defaultLockTimeout),
+	}, urfavecli.StringFlag{
+		Name:  "control-chars",
+		Value: controlCharsReject,
+		Usage: "handling of control characters other than tabs and line breaks in content: reject or strip",
+	})
 
 	// run the CLI application with the command-line arguments passed to the program
 	err = app.Run(os.Args)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// databasePath resolves the path of the sqlite database: the --db option comes first,
+// then the GO_NOTES_DB environment variable looked up with getenv, then the default file name
+func databasePath(args []string, getenv func(key string) string) string {
+	if path := globalOption(args, "db", ""); path != "" {
+		return path
 	}
+	if path := getenv(dbEnv); path != "" {
+		return path
+	}
+
+	return storageName
+}
+
+// sqliteOptions holds global options of the sqlite backend as given, empty options are not applied
+type sqliteOptions struct {
+	journalMode string
+	tablePrefix string
+	lockTimeout string
+
+	// controlChars is either controlCharsReject or controlCharsStrip
+	controlChars string
+}
+
+const (
+	// controlCharsReject rejects content with control characters, the default
+	controlCharsReject = "reject"
+	// controlCharsStrip removes control characters from content
+	controlCharsStrip = "strip"
+)
+
+// openStorage creates storage of the named backend, path and options apply only to sqlite
+func openStorage(backend, path string, options sqliteOptions) (closableStorage, error) {
+	switch backend {
+	case backendSQLite:
+		// initialize the sqlite storage using the specified database file
+		var opts []sqlite.Option
+		if options.journalMode != "" {
+			opts = append(opts, sqlite.WithJournalMode(options.journalMode))
+		}
+		if options.tablePrefix != "" {
+			opts = append(opts, sqlite.WithTablePrefix(options.tablePrefix))
+		}
+		if options.lockTimeout != "" {
+			timeout, err := time.ParseDuration(options.lockTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid lock timeout: %w", err)
+			}
+			opts = append(opts, sqlite.WithFileLock(timeout))
+		}
+		switch options.controlChars {
+		case "", controlCharsReject:
+		case controlCharsStrip:
+			opts = append(opts, sqlite.WithStripControlChars(true))
+		default:
+			return nil, fmt.Errorf("invalid control-chars option: %s", options.controlChars)
+		}
+
+		return sqlite.New(path, opts...)
+	case backendMemory:
+		return memory.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown backend: %s", backend)
+	}
+}
+
+// boolOptions lists global options which take no value, they are defined by the cli package
+var boolOptions = func() map[string]bool {
+	options := make(map[string]bool)
+	for _, name := range cli.GlobalBoolFlags() {
+		options[name] = true
+	}
+
+	return options
+}()
+
+// commandArg returns the command name, the first argument after global options, or "" if there's none
+func commandArg(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+
+		// global options other than bool ones take a value, which is skipped if it's a separate argument
+		if flag := strings.TrimLeft(arg, "-"); !strings.Contains(flag, "=") && !boolOptions[flag] {
+			i++
+		}
+	}
+
+	return ""
+}
+
+// globalOption returns value of a global option given before the command name as --name value or --name=value
+func globalOption(args []string, name, defaultValue string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// global options end at the first argument which is not an option, i.e. the command name
+		if !strings.HasPrefix(arg, "-") {
+			break
+		}
+
+		flag := strings.TrimLeft(arg, "-")
+		if value, ok := strings.CutPrefix(flag, name+"="); ok {
+			return value
+		}
+		if flag == name && i+1 < len(args) {
+			return args[i+1]
+		}
+
+		// other global options take a value, so a value given as a separate argument is skipped
+		if !strings.Contains(flag, "=") && !boolOptions[flag] {
+			i++
+		}
+	}
+
+	return defaultValue
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/storage/sqlite"
+)
+
+func TestGlobalOption(t *testing.T) {
+	args := []string{"--strict", "--backend", "memory", "--journal-mode=wal", "list", "--backend", "other"}
+
+	if backend := globalOption(args, "backend", backendSQLite); backend != backendMemory {
+		t.Errorf("Expected backend %q, got %q", backendMemory, backend)
+	}
+	if mode := globalOption(args, "journal-mode", ""); mode != "wal" {
+		t.Errorf("Expected journal mode wal, got %q", mode)
+	}
+	if missing := globalOption([]string{"list"}, "backend", backendSQLite); missing != backendSQLite {
+		t.Errorf("Expected default backend, got %q", missing)
+	}
+}
+
+func TestGlobalOptionAfterBoolFlags(t *testing.T) {
+	// every bool flag of the cli package is known not to take a value
+	for _, flag := range []string{"--strict", "--quiet", "--no-reminders", "--include-expired"} {
+		args := []string{flag, "--db", "notes.db", "--backend", "memory", "new", "Title", "Content"}
+
+		if path := globalOption(args, "db", ""); path != "notes.db" {
+			t.Errorf("Expected path notes.db after %s, got %q", flag, path)
+		}
+		if backend := globalOption(args, "backend", backendSQLite); backend != backendMemory {
+			t.Errorf("Expected backend %q after %s, got %q", backendMemory, flag, backend)
+		}
+	}
+}
+
+func TestDatabasePath(t *testing.T) {
+	env := func(value string) func(string) string {
+		return func(key string) string {
+			if key == dbEnv {
+				return value
+			}
+			return ""
+		}
+	}
+
+	tests := []struct {
+		args     []string
+		env      string
+		expected string
+	}{
+		{[]string{"list"}, "", storageName},
+		{[]string{"list"}, "/data/notes.db", "/data/notes.db"},
+		{[]string{"--db", "flag.db", "list"}, "/data/notes.db", "flag.db"},
+		{[]string{"--db=flag.db", "list"}, "", "flag.db"},
+	}
+
+	for _, tt := range tests {
+		if path := databasePath(tt.args, env(tt.env)); path != tt.expected {
+			t.Errorf("Expected path %q for %v with %q in %s, got %q", tt.expected, tt.args, tt.env, dbEnv, path)
+		}
+	}
+}
+
+func TestRunStrictExitCode(t *testing.T) {
+	args := os.Args
+	defer func() {
+		os.Args = args
+	}()
+
+	// the memory backend leaves no database file behind
+	os.Args = []string{"go-notes", "--backend", "memory", "--strict", "new"}
+	if code := run(); code == 0 {
+		t.Errorf("Expected non-zero exit code for new without a title under --strict")
+	}
+
+	os.Args = []string{"go-notes", "--backend", "memory", "new"}
+	if code := run(); code != 0 {
+		t.Errorf("Expected zero exit code for new without a title, got %d", code)
+	}
+}
+
+func TestCommandArg(t *testing.T) {
+	tests := map[string][]string{
+		"new":  {"--quiet", "--db", "notes.db", "new", "Title"},
+		"list": {"--db=notes.db", "list"},
+		"":     {"--strict"},
+	}
+	for expected, args := range tests {
+		if command := commandArg(args); command != expected {
+			t.Errorf("Expected command %q for %v, got %q", expected, args, command)
+		}
+	}
+}
+
+func TestRunLocksByDefault(t *testing.T) {
+	args, timeout := os.Args, defaultLockTimeout
+	defer func() {
+		os.Args, defaultLockTimeout = args, timeout
+	}()
+	defaultLockTimeout = 100 * time.Millisecond
+
+	// another run holds the lock of the database
+	path := filepath.Join(t.TempDir(), "notes.db")
+	other, err := sqlite.New(path, sqlite.WithFileLock(0))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer other.Close()
+
+	// a writing command waits for it without --lock-timeout and gives up
+	os.Args = []string{"go-notes", "--quiet", "--db", path, "new", "Title", "Content"}
+	if code := run(); code == 0 {
+		t.Errorf("Expected non-zero exit code for new while another run holds the database")
+	}
+
+	// a reading command doesn't need the lock
+	os.Args = []string{"go-notes", "--quiet", "--db", path, "list"}
+	if code := run(); code != 0 {
+		t.Errorf("Expected zero exit code for list while another run holds the database, got %d", code)
+	}
+
+	// once the other run is done, writing succeeds
+	_ = other.Close()
+	os.Args = []string{"go-notes", "--quiet", "--db", path, "new", "Title", "Content"}
+	if code := run(); code != 0 {
+		t.Errorf("Expected zero exit code for new after the lock was released, got %d", code)
+	}
+}
@@ -3,11 +3,16 @@ module go-notes
 go 1.21.0
 
 require (
+	github.com/atotto/clipboard v0.1.4
 	github.com/mattn/go-sqlite3 v1.14.17
+	github.com/russross/blackfriday/v2 v2.1.0
 	github.com/urfave/cli v1.22.14
+	golang.org/x/term v0.20.0
+	golang.org/x/text v0.15.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
-	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
 )
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommandAliases(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+	_, _ = storage.NewNote("Meeting", "Discuss the release.")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	// an alias prints the same as the canonical command
+	run := func(args ...string) string {
+		out.Reset()
+		if err := app.Run(append([]string{"go-notes"}, args...)); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", args, err)
+		}
+
+		return out.String()
+	}
+	if list, ls := run("list"), run("ls"); ls != list {
+		t.Errorf("Expected ls to print %q, got %q", list, ls)
+	}
+	if search, find := run("search", "Milk"), run("find", "Milk"); find != search {
+		t.Errorf("Expected find to print %q, got %q", search, find)
+	}
+
+	run("rm", "2")
+	if len(storage.notes) != 1 || storage.notes[0].Title != "Groceries" {
+		t.Errorf("Expected rm to delete the note, got %+v", storage.notes)
+	}
+
+	// aliases are listed in help
+	help := run("help")
+	for _, alias := range []string{"list, ls", "delete, rm, del", "new, add", "search, find"} {
+		if !strings.Contains(help, alias) {
+			t.Errorf("Expected help to list %q, got %q", alias, help)
+		}
+	}
+}
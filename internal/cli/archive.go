@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// ArchiveStorage is implemented by storages able to move old notes into the database of another profile
+type ArchiveStorage interface {
+	// ArchiveToProfile moves notes last edited before the time into the named profile and returns their number
+	ArchiveToProfile(profile string, before time.Time) (int, error)
+}
+
+// archiveOldCommand creates new CLI command for moving old notes into another profile
+func archiveOldCommand(archiveStorage ArchiveStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "archive-old"
+		commandUsage = "Move notes created more than --older-than-days ago into the database of --to-profile"
+	)
+
+	// create a new CLI command configuration
+	archiveOld := cli.Command{
+		Name:  commandName,  // name of command (e.g., "archive-old")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.IntFlag{Name: "older-than-days", Usage: "move notes created more than the number of days ago"},
+			cli.StringFlag{Name: "to-profile", Usage: "profile to move notes to, kept as <profile>.db next to the database"},
+		},
+		Action: func(c *cli.Context) error {
+			days, profile := c.Int("older-than-days"), c.String("to-profile")
+			if days <= 0 || profile == "" {
+				return missingArg(c, "Please provide --older-than-days and --to-profile.")
+			}
+
+			moved, err := archiveStorage.ArchiveToProfile(profile, time.Now().AddDate(0, 0, -days))
+			if err != nil {
+				return fmt.Errorf("archiving notes: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Moved %d note(s) to profile '%s'\n", moved, profile)
+
+			return nil
+		},
+	}
+
+	return archiveOld
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// defaultAroundWindow is the width of the time window of list --around
+const defaultAroundWindow = 2 * time.Hour
+
+// parseAroundTime parses the time of list --around in local time, a date without time means its start
+func parseAroundTime(s string) (time.Time, error) {
+	for _, layout := range dueDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD or YYYY-MM-DD HH:MM", s)
+}
+
+// notesAround returns notes created within the window centered on center, i.e. at most half of it before
+// or after, ordered from the closest to center. Notes equally close keep their order
+func notesAround(notes []entities.Note, center time.Time, window time.Duration) []entities.Note {
+	distance := func(note entities.Note) time.Duration {
+		d := note.CreatedAt.Sub(center)
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+
+	var around []entities.Note
+	for _, note := range notes {
+		if distance(note) <= window/2 {
+			around = append(around, note)
+		}
+	}
+
+	sort.SliceStable(around, func(i, j int) bool {
+		return distance(around[i]) < distance(around[j])
+	})
+
+	return around
+}
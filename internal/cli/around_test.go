@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestParseAroundTime(t *testing.T) {
+	if got, err := parseAroundTime("2024-01-09 12:00"); err != nil || !got.Equal(time.Date(2024, 1, 9, 12, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected noon of 2024-01-09, got %s (%v)", got, err)
+	}
+	if got, err := parseAroundTime("2024-01-09"); err != nil || !got.Equal(time.Date(2024, 1, 9, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected start of 2024-01-09, got %s (%v)", got, err)
+	}
+	if _, err := parseAroundTime("last tuesday"); err == nil {
+		t.Error("Expected an error for an invalid time")
+	}
+}
+
+func TestNotesAround(t *testing.T) {
+	center := time.Date(2024, 1, 9, 12, 0, 0, 0, time.Local)
+	notes := []entities.Note{
+		{ID: 1, CreatedAt: center.Add(-2 * time.Hour)},
+		{ID: 2, CreatedAt: center.Add(time.Hour)},
+		{ID: 3, CreatedAt: center.Add(-10 * time.Minute)},
+		{ID: 4, CreatedAt: center.Add(90 * time.Minute)},
+		{ID: 5, CreatedAt: center.AddDate(0, 0, 1)},
+	}
+
+	var ids []int
+	for _, note := range notesAround(notes, center, 3*time.Hour) {
+		ids = append(ids, note.ID)
+	}
+	if expected := []int{3, 2, 4}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected notes %v, got %v", expected, ids)
+	}
+}
+
+func TestListAround(t *testing.T) {
+	center := time.Date(2024, 1, 9, 12, 0, 0, 0, time.Local)
+	storage := &fakeStorage{notes: []entities.Note{
+		{ID: 1, Title: "Early", CreatedAt: center.Add(-5 * time.Hour)},
+		{ID: 2, Title: "Lunch", CreatedAt: center.Add(30 * time.Minute)},
+	}}
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "list", "--ids-only", "--around", "2024-01-09 12:00", "--window", "3h"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out.String() != "2\n" {
+		t.Errorf("Expected only note 2, got %q", out.String())
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// defaultAuditLimit is the default number of the latest audit log entries printed by audit
+const defaultAuditLimit = 20
+
+// AuditStorage is implemented by storages recording changes of notes in an audit log
+type AuditStorage interface {
+	// GetAuditEntriesSinceID retrieves audit log entries with ID greater than the specified one ordered by ID
+	GetAuditEntriesSinceID(id int) ([]entities.AuditEntry, error)
+
+	// GetLatestAuditEntries retrieves up to n newest audit log entries ordered by ID
+	GetLatestAuditEntries(n int) ([]entities.AuditEntry, error)
+}
+
+// fetchAuditEntries retrieves audit log entries written after the entry with lastID and returns them
+// with the ID of the newest one. The ID is unchanged if there are no new entries
+func fetchAuditEntries(storage AuditStorage, lastID int) ([]entities.AuditEntry, int, error) {
+	entries, err := storage.GetAuditEntriesSinceID(lastID)
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	// entries are ordered by ID, so the last one is the newest
+	if len(entries) > 0 {
+		lastID = entries[len(entries)-1].ID
+	}
+
+	return entries, lastID, nil
+}
+
+// auditCommand creates new CLI command for printing the audit log
+func auditCommand(storage AuditStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "audit"
+		commandUsage = "Print the latest changes of notes from the audit log, --follow keeps printing new ones"
+	)
+
+	// create a new CLI command configuration
+	audit := cli.Command{
+		Name:  commandName,  // name of command (e.g., "audit")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.IntFlag{Name: "limit", Value: defaultAuditLimit, Usage: "number of the latest entries to print first"},
+			cli.BoolFlag{Name: "follow", Usage: "keep printing entries as they are written until interrupted"},
+			cli.DurationFlag{Name: "interval", Value: defaultTailInterval, Usage: "time between checks for new entries with --follow"},
+		},
+		Action: func(c *cli.Context) error {
+			limit := c.Int("limit")
+			if limit < 0 {
+				return errors.New("--limit must not be negative")
+			}
+
+			var (
+				entries []entities.AuditEntry
+				err     error
+			)
+			if limit > 0 {
+				if entries, err = storage.GetLatestAuditEntries(limit); err != nil {
+					return fmt.Errorf("retrieving audit log: %w", err)
+				}
+			}
+			printAuditEntries(c.App.Writer, noteIDFormat(c), entries)
+
+			if !c.Bool("follow") {
+				return nil
+			}
+
+			interval := c.Duration("interval")
+			if interval <= 0 {
+				return fmt.Errorf("invalid interval: %s", interval)
+			}
+
+			// follow from the newest existing entry, even if none of them was printed
+			_, lastID, err := fetchAuditEntries(storage, 0)
+			if err != nil {
+				return fmt.Errorf("retrieving audit log: %w", err)
+			}
+
+			return pollUntilInterrupted(interval, func() error {
+				entries, lastID, err = fetchAuditEntries(storage, lastID)
+				if err != nil {
+					return fmt.Errorf("retrieving audit log: %w", err)
+				}
+
+				printAuditEntries(c.App.Writer, noteIDFormat(c), entries)
+
+				return nil
+			})
+		},
+	}
+
+	return audit
+}
+
+// printAuditEntries prints audit log entries one per line with note IDs in the format
+func printAuditEntries(w io.Writer, ids idFormat, entries []entities.AuditEntry) {
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s %s note %s\n", entry.At.Local().Format(time.DateTime), entry.Action, ids.Format(entry.NoteID))
+	}
+}
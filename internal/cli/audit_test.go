@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// auditStorage keeps an audit log of simulated changes
+type auditStorage struct {
+	fakeStorage
+	entries []entities.AuditEntry
+}
+
+// log appends an entry for the action on the note
+func (s *auditStorage) log(noteID int, action string) {
+	s.entries = append(s.entries, entities.AuditEntry{ID: len(s.entries) + 1, NoteID: noteID, Action: action,
+		At: time.Date(2024, 1, 9, 12, len(s.entries), 0, 0, time.Local)})
+}
+
+func (s *auditStorage) GetAuditEntriesSinceID(id int) ([]entities.AuditEntry, error) {
+	var entries []entities.AuditEntry
+	for _, entry := range s.entries {
+		if entry.ID > id {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+func (s *auditStorage) GetLatestAuditEntries(n int) ([]entities.AuditEntry, error) {
+	return s.entries[max(len(s.entries)-n, 0):], nil
+}
+
+func TestFetchAuditEntries(t *testing.T) {
+	storage := &auditStorage{}
+	storage.log(1, "created")
+
+	_, lastID, err := fetchAuditEntries(storage, 0)
+	if err != nil || lastID != 1 {
+		t.Fatalf("Expected last ID 1, got %d (%v)", lastID, err)
+	}
+
+	// nothing new yet
+	entries, lastID, _ := fetchAuditEntries(storage, lastID)
+	if len(entries) != 0 || lastID != 1 {
+		t.Errorf("Expected no new entries and last ID 1, got %+v and %d", entries, lastID)
+	}
+
+	// entries written in between are returned once
+	storage.log(1, "edited")
+	storage.log(2, "created")
+
+	entries, lastID, _ = fetchAuditEntries(storage, lastID)
+	if len(entries) != 2 || entries[0].Action != "edited" || entries[1].NoteID != 2 || lastID != 3 {
+		t.Errorf("Expected the edit and the new note with last ID 3, got %+v and %d", entries, lastID)
+	}
+
+	storage.log(1, "deleted")
+
+	entries, lastID, _ = fetchAuditEntries(storage, lastID)
+	if len(entries) != 1 || entries[0].Action != "deleted" || lastID != 4 {
+		t.Errorf("Expected only the deletion with last ID 4, got %+v and %d", entries, lastID)
+	}
+}
+
+func TestAuditLimit(t *testing.T) {
+	storage := &auditStorage{}
+	storage.log(1, "created")
+	storage.log(1, "edited")
+	storage.log(1, "trashed")
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "audit", "--limit", "2"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "2024-01-09 12:01:00 edited note 1\n2024-01-09 12:02:00 trashed note 1\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// BackupStorage is implemented by storages able to copy themselves to a file
+type BackupStorage interface {
+	// Backup writes a copy of the storage to path and records the time of the backup
+	Backup(path string) error
+
+	// LastBackup retrieves the time of the last successful backup, ok is false if there was none
+	LastBackup() (at time.Time, ok bool, err error)
+}
+
+// parseWarnDays parses a value of backup.warn-days, empty value and 0 disable the warning
+func parseWarnDays(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid number of days %q, expected 0 or more", value)
+	}
+
+	return days, nil
+}
+
+// backupStale reports whether a warning is due, because the last backup is older than days or there was none.
+// Days of 0 never warn
+func backupStale(last time.Time, ok bool, days int, now time.Time) bool {
+	if days <= 0 {
+		return false
+	}
+
+	return !ok || now.Sub(last) > time.Duration(days)*24*time.Hour
+}
+
+// warnStaleBackup prints a reminder before any command but backup if backup.warn-days is set
+// and the last backup is older. Failures are ignored, as a reminder must not break commands
+func warnStaleBackup(c *cli.Context, storage Storage) {
+	backups, ok := storage.(BackupStorage)
+	if !ok || c.Args().First() == "backup" {
+		return
+	}
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return
+	}
+
+	value, _, err := settings.GetSetting(configBackupWarnDays)
+	if err != nil {
+		return
+	}
+	days, err := parseWarnDays(value)
+	if err != nil || days == 0 {
+		return
+	}
+
+	last, ok, err := backups.LastBackup()
+	if err != nil || !backupStale(last, ok, days, time.Now()) {
+		return
+	}
+
+	if !ok {
+		fmt.Fprintln(errorWriter(c), "Warning: notes were never backed up, run 'backup PATH'")
+		return
+	}
+	fmt.Fprintf(errorWriter(c), "Warning: last backup is %d day(s) old, run 'backup PATH'\n", int(time.Since(last).Hours()/24))
+}
+
+// backupCommand creates new CLI command for backing up notes and checking when it was last done
+func backupCommand(storage BackupStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "backup"
+		commandUsage = "Copy all notes to a new database file"
+	)
+
+	// create a new CLI command configuration
+	backup := cli.Command{
+		Name:      commandName,  // name of command (e.g., "backup")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "path",
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "status", Usage: "print when the last backup was made instead"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("status") {
+				last, ok, err := storage.LastBackup()
+				if err != nil {
+					return fmt.Errorf("retrieving last backup: %w", err)
+				}
+
+				if !ok {
+					fmt.Fprintln(c.App.Writer, "No backup was made yet")
+					return nil
+				}
+				fmt.Fprintf(c.App.Writer, "Last backup: %s\n", last.Local().Format(time.DateTime))
+
+				return nil
+			}
+
+			path := c.Args().First()
+			if path == "" {
+				return missingArg(c, "Please provide a path of the backup.")
+			}
+
+			if err := storage.Backup(path); err != nil {
+				return fmt.Errorf("backing up notes: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Backed up notes to %s\n", path)
+
+			return nil
+		},
+	}
+
+	return backup
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type backupStorage struct {
+	settingsStorage
+	lastBackup time.Time
+}
+
+func (s *backupStorage) Backup(string) error {
+	s.lastBackup = time.Now()
+	return nil
+}
+
+func (s *backupStorage) LastBackup() (time.Time, bool, error) {
+	return s.lastBackup, !s.lastBackup.IsZero(), nil
+}
+
+func TestBackupStale(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		last     time.Time
+		ok       bool
+		days     int
+		expected bool
+	}{
+		{now.Add(-time.Hour), true, 7, false},
+		{now.AddDate(0, 0, -8), true, 7, true},
+		{time.Time{}, false, 7, true},
+		{time.Time{}, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := backupStale(tt.last, tt.ok, tt.days, now); got != tt.expected {
+			t.Errorf("Expected %v for backup at %s (%v) within %d days, got %v", tt.expected, tt.last, tt.ok, tt.days, got)
+		}
+	}
+}
+
+func TestBackupReminder(t *testing.T) {
+	storage := &backupStorage{}
+	_, _ = storage.NewNote("Note", "Content")
+
+	app := NewCLI(storage)
+	var out, errOut bytes.Buffer
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	// the reminder is off by default
+	if err := app.Run([]string{"go-notes", "list"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("Expected no reminder, got %q", errOut.String())
+	}
+
+	_ = storage.SetSetting(configBackupWarnDays, "7")
+	_ = app.Run([]string{"go-notes", "list"})
+	if !strings.Contains(errOut.String(), "never backed up") {
+		t.Errorf("Expected a reminder, got %q", errOut.String())
+	}
+
+	// a recent backup silences the reminder and is reported by --status
+	errOut.Reset()
+	if err := app.Run([]string{"go-notes", "backup", "copy.db"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	out.Reset()
+	_ = app.Run([]string{"go-notes", "backup", "--status"})
+	if !strings.Contains(out.String(), "Last backup: "+storage.lastBackup.Format(time.DateTime)) {
+		t.Errorf("Expected the backup time, got %q", out.String())
+	}
+	_ = app.Run([]string{"go-notes", "list"})
+	if errOut.Len() != 0 {
+		t.Errorf("Expected no reminder after a backup, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// BreakdownStorage is implemented by storages able to sum up notes and their content length per label
+type BreakdownStorage interface {
+	// GetTagBreakdown retrieves every tag with the number of notes having it and their total content length
+	GetTagBreakdown() ([]entities.LabelSize, error)
+
+	// GetMetaBreakdown retrieves every value of the metadata key with the number of notes having it
+	// and their total content length
+	GetMetaBreakdown(key string) ([]entities.LabelSize, error)
+}
+
+// breakdownCommand creates new CLI command reporting the number of notes and their size per tag or category
+func breakdownCommand(storage BreakdownStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "breakdown"
+		commandUsage = "Show the number of notes and their content size in bytes per tag or category"
+	)
+
+	// create a new CLI command configuration
+	breakdown := cli.Command{
+		Name:  commandName,  // name of command (e.g., "breakdown")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "by", Value: groupByTag, Usage: "label to break notes down by: tag or category (the 'category' field)"},
+			cli.BoolFlag{Name: "json", Usage: "print the breakdown as JSON"},
+		},
+		Action: func(c *cli.Context) error {
+			var (
+				labels []entities.LabelSize
+				err    error
+			)
+			switch by := c.String("by"); by {
+			case groupByTag:
+				labels, err = storage.GetTagBreakdown()
+			case groupByCategory:
+				labels, err = storage.GetMetaBreakdown(categoryKey)
+			default:
+				return fmt.Errorf("unknown breakdown: %s", by)
+			}
+			if err != nil {
+				return fmt.Errorf("retrieving breakdown: %w", err)
+			}
+
+			if c.Bool("json") {
+				// an empty breakdown is an empty list rather than null
+				if labels == nil {
+					labels = []entities.LabelSize{}
+				}
+
+				return writeStructured(c.App.Writer, outputJSON, labels)
+			}
+
+			if len(labels) == 0 {
+				fmt.Fprintf(c.App.Writer, "No notes with a %s found.\n", c.String("by"))
+				return nil
+			}
+
+			rows := make([][]string, len(labels))
+			for i, label := range labels {
+				rows[i] = []string{label.Label, strconv.Itoa(label.Notes), strconv.Itoa(label.Bytes)}
+			}
+			renderTable(c.App.Writer, []string{strings.ToUpper(c.String("by")), "NOTES", "BYTES"}, rows)
+
+			return nil
+		},
+	}
+
+	return breakdown
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// CaptureStorage is implemented by storages able to create, tag and pin a note in a single transaction
+type CaptureStorage interface {
+	// CaptureNote creates a note with tags, optionally pinned, storing nothing if any step fails
+	CaptureNote(title, content string, tags []string, pin bool) (int, error)
+}
+
+// captureCommand creates new CLI command for quickly capturing a tagged and optionally pinned note
+func captureCommand(captures CaptureStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "capture"
+		commandUsage = "Create a note with tags and optionally pin it in one step"
+	)
+
+	// create a new CLI command configuration
+	captureNote := cli.Command{
+		Name:      commandName,  // name of command (e.g., "capture")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "title [content]",
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{Name: "tag", Usage: "tag to attach to the note, can be repeated"},
+			cli.BoolFlag{Name: "pin", Usage: "pin the note"},
+		},
+		Action: func(c *cli.Context) error {
+			title := c.Args().Get(0)
+			if title == "" {
+				return missingArg(c, "Please provide a title for the note.")
+			}
+
+			// missing content is read from standard input or written in the editor
+			content := c.Args().Get(1)
+			if content == "" {
+				var err error
+				if content, err = readPipedContent(); err != nil {
+					return err
+				}
+			}
+			if content == "" {
+				var err error
+				if content, _, err = editContent(""); err != nil {
+					return err
+				}
+			}
+
+			noteID, err := captures.CaptureNote(title, content, c.StringSlice("tag"), c.Bool("pin"))
+			if err != nil {
+				return fmt.Errorf("capturing note: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Captured a new note %q with ID %s\n", title, noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return captureNote
+}
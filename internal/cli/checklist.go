@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// checkboxPattern matches a markdown task list item, e.g. "- [ ] task" or "  1. [x] task",
+// the second group is the mark inside the brackets
+var checkboxPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])\]`)
+
+// toggleCheckbox toggles the checkbox on the 1-based line of content, checking an unchecked box and unchecking
+// a checked one. It returns the new content and whether the box is checked now, ok is false for a line
+// without a checkbox, in which case content is returned unchanged
+func toggleCheckbox(content string, line int) (newContent string, checked, ok bool, err error) {
+	// a trailing newline doesn't start a new line, the same way lines are numbered
+	lineCount := len(strings.Split(strings.TrimSuffix(content, "\n"), "\n"))
+	if content == "" || line < 1 || line > lineCount {
+		return content, false, false, fmt.Errorf("line %d is out of range, the note has %d line(s)", line, lineCount)
+	}
+
+	lines := strings.Split(content, "\n")
+	match := checkboxPattern.FindStringSubmatchIndex(lines[line-1])
+	if match == nil {
+		return content, false, false, nil
+	}
+
+	// the mark is a single byte at the start of the second group
+	mark, checked := "x", true
+	if lines[line-1][match[4]] != ' ' {
+		mark, checked = " ", false
+	}
+	lines[line-1] = lines[line-1][:match[4]] + mark + lines[line-1][match[5]:]
+
+	return strings.Join(lines, "\n"), checked, true, nil
+}
+
+// countCheckboxes counts checklist items in content and how many of them are checked
+func countCheckboxes(content string) (done, total int) {
+	for _, line := range strings.Split(content, "\n") {
+		match := checkboxPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		total++
+		if match[2] != " " {
+			done++
+		}
+	}
+
+	return done, total
+}
+
+// checkCommand creates new CLI command for toggling a checkbox of a markdown checklist in note content
+func checkCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "check"
+		commandUsage = "Toggle the checkbox of a checklist item (- [ ] or - [x]) on a line of note content"
+	)
+
+	// create a new CLI command configuration
+	check := cli.Command{
+		Name:      commandName,  // name of command (e.g., "check")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Flags: []cli.Flag{
+			cli.IntFlag{Name: "line", Usage: "1-based number of the content line with the checkbox"},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note.")
+			if !ok || err != nil {
+				return err
+			}
+
+			line := c.Int("line")
+			if line < 1 {
+				return missingArg(c, "Please provide the line to toggle with --line N.")
+			}
+
+			note, err := storage.GetNoteByID(noteID)
+			if err != nil {
+				return fmt.Errorf("retrieving note: %w", err)
+			}
+
+			content, checked, ok, err := toggleCheckbox(note.Content, line)
+			if err != nil {
+				return fmt.Errorf("toggling checkbox: %w", err)
+			}
+			if !ok {
+				fmt.Fprintf(errorWriter(c), "Warning: line %d of note with ID %s has no checkbox, the note is unchanged\n",
+					line, noteIDFormat(c).Format(noteID))
+				return nil
+			}
+
+			if err = storage.SetNoteContent(noteID, content); err != nil {
+				return fmt.Errorf("updating content: %w", err)
+			}
+
+			state := "Unchecked"
+			if checked {
+				state = "Checked"
+			}
+			fmt.Fprintf(c.App.Writer, "%s line %d of note with ID %s\n", state, line, noteIDFormat(c).Format(noteID))
+
+			runPostSaveHook(c, storage, noteID)
+
+			return nil
+		},
+	}
+
+	return check
+}
+
+// progressCommand creates new CLI command reporting how many checklist items of notes are done
+func progressCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "progress"
+		commandUsage = "Show how many checklist items (- [ ] or - [x]) are done per note with a checklist"
+	)
+
+	// create a new CLI command configuration
+	progress := cli.Command{
+		Name:  commandName,  // name of command (e.g., "progress")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "overall", Usage: "print only the total over all notes"},
+		},
+		Action: func(c *cli.Context) error {
+			notes, err := storage.GetAllNotes()
+			if err != nil {
+				return fmt.Errorf("retrieving notes: %w", err)
+			}
+
+			var allDone, allTotal int
+			for _, note := range notes {
+				done, total := countCheckboxes(note.Content)
+				if total == 0 {
+					continue
+				}
+
+				allDone += done
+				allTotal += total
+				if !c.Bool("overall") {
+					fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, Done: %d/%d\n",
+						noteIDFormat(c).Format(note.ID), note.Title, done, total)
+				}
+			}
+
+			if allTotal == 0 {
+				fmt.Fprintln(c.App.Writer, "No checklist items found.")
+				return nil
+			}
+
+			fmt.Fprintf(c.App.Writer, "Overall: %d/%d done, %d outstanding\n", allDone, allTotal, allTotal-allDone)
+
+			return nil
+		},
+	}
+
+	return progress
+}
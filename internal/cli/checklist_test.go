@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestToggleCheckbox(t *testing.T) {
+	const list = "Groceries\n- [ ] milk\n  * [x] bread\n1. [X] eggs\n"
+
+	tests := []struct {
+		line     int
+		expected string
+		checked  bool
+		ok       bool
+	}{
+		{2, "Groceries\n- [x] milk\n  * [x] bread\n1. [X] eggs\n", true, true},
+		{3, "Groceries\n- [ ] milk\n  * [ ] bread\n1. [X] eggs\n", false, true},
+		{4, "Groceries\n- [ ] milk\n  * [x] bread\n1. [ ] eggs\n", false, true},
+		// a line without a checkbox is left as is
+		{1, list, false, false},
+	}
+
+	for _, tt := range tests {
+		content, checked, ok, err := toggleCheckbox(list, tt.line)
+		if err != nil {
+			t.Fatalf("Expected no error for line %d, got %v", tt.line, err)
+		}
+		if content != tt.expected || checked != tt.checked || ok != tt.ok {
+			t.Errorf("Expected %q (checked %v, ok %v) for line %d, got %q (checked %v, ok %v)",
+				tt.expected, tt.checked, tt.ok, tt.line, content, checked, ok)
+		}
+	}
+
+	// toggling twice restores the content
+	content, _, _, _ := toggleCheckbox(list, 2)
+	if content, _, _, _ = toggleCheckbox(content, 2); content != list {
+		t.Errorf("Expected content %q after toggling back, got %q", list, content)
+	}
+
+	for _, line := range []int{0, 5} {
+		if _, _, _, err := toggleCheckbox(list, line); err == nil {
+			t.Errorf("Expected error for line %d out of range", line)
+		}
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("TODO", "Today\n- [ ] write tests")
+
+	var out, errOut bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	if err := app.Run([]string{"go-notes", "check", "--line", "2", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if storage.notes[0].Content != "Today\n- [x] write tests" || !strings.Contains(out.String(), "Checked line 2") {
+		t.Errorf("Expected line 2 to be checked, got %q with output %q", storage.notes[0].Content, out.String())
+	}
+
+	// a line without a checkbox only warns
+	if err := app.Run([]string{"go-notes", "check", "--line", "1", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if storage.notes[0].Content != "Today\n- [x] write tests" || !strings.Contains(errOut.String(), "has no checkbox") {
+		t.Errorf("Expected unchanged note and a warning, got %q with warning %q", storage.notes[0].Content, errOut.String())
+	}
+}
+
+func TestCountCheckboxes(t *testing.T) {
+	tests := []struct {
+		content     string
+		done, total int
+	}{
+		{"- [ ] milk\n- [x] bread\n* [X] eggs\nnot an item [x]", 2, 3},
+		{"1. [ ] first\n  - [ ] nested\n", 0, 2},
+		{"no checklist", 0, 0},
+		{"", 0, 0},
+	}
+
+	for _, tt := range tests {
+		if done, total := countCheckboxes(tt.content); done != tt.done || total != tt.total {
+			t.Errorf("Expected %d/%d for %q, got %d/%d", tt.done, tt.total, tt.content, done, total)
+		}
+	}
+}
+
+func TestProgressCommand(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "- [x] milk\n- [ ] bread")
+	_, _ = storage.NewNote("Plain", "no checklist")
+	_, _ = storage.NewNote("Chores", "- [x] dishes\n- [x] laundry\n- [ ] windows")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "progress"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "ID: 1, Title: Groceries, Done: 1/2\n" +
+		"ID: 3, Title: Chores, Done: 2/3\n" +
+		"Overall: 3/5 done, 2 outstanding\n"
+	if out.String() != expected {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
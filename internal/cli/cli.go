@@ -1,12 +1,19 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli"
+	"golang.org/x/term"
 
 	"go-notes/internal/entities"
+	"go-notes/internal/server"
+	"go-notes/internal/syncer"
 )
 
 type Storage interface {
@@ -34,23 +41,235 @@ const (
 	appUsage = "Manage your notes using CLI" // description of application's purpose
 )
 
+// strictFlag names the global flag turning missing required arguments into errors
+const strictFlag = "strict"
+
+// errMissingArgument is returned for missing required arguments with the global --strict flag
+var errMissingArgument = errors.New("missing argument")
+
+// globalFlags returns options accepted before the command name
+func globalFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  strictFlag,
+			Usage: "fail instead of printing a hint when a required argument is missing",
+		},
+		idFormatFlag,
+		cli.BoolFlag{Name: quietFlag, Usage: "don't print reminders and other notices before commands"},
+		cli.BoolFlag{Name: noRemindersFlag, Usage: "don't print overdue and due today notes before commands"},
+		includeExpiredFlag,
+	}
+}
+
+// GlobalBoolFlags returns names of global options which take no value,
+// so options can be told apart from their values before the application is built
+func GlobalBoolFlags() []string {
+	var names []string
+	for _, flag := range globalFlags() {
+		if boolFlag, ok := flag.(cli.BoolFlag); ok {
+			names = append(names, boolFlag.Name)
+		}
+	}
+
+	return names
+}
+
+// readOnlyCommands names commands which only read notes, including help shown without a command
+var readOnlyCommands = map[string]bool{
+	"": true, "help": true, "h": true, "audit": true, "breakdown": true, "commands": true, "conflicts": true,
+	"dashboard": true, "diff": true, "digest": true, "export": true, "export-html": true, "get": true, "graph": true,
+	"heatmap": true, "info": true, "linkcheck": true, "list": true, "mirror": true, "note-of-the-day": true,
+	"nth": true, "orphans": true, "pragmas": true, "progress": true, "schema": true, "search": true, "share": true,
+	"size": true, "spec": true, "stubs": true, "tags": true, "unseen": true,
+}
+
+// ReadOnlyCommand reports whether the named command only reads notes, so it doesn't need to lock the storage
+// against other runs. Other commands, including unknown ones, may write
+func ReadOnlyCommand(name string) bool {
+	return readOnlyCommands[name]
+}
+
 // NewCLI creates new CLI application with provided storage object
 func NewCLI(storage Storage) *cli.App {
 	// create a new CLI application
 	app := cli.NewApp()
 	app.Name = appName   // set application's name
 	app.Usage = appUsage // set application's usage description
+	app.Flags = globalFlags()
+	app.Before = func(c *cli.Context) error {
+		if err := noteIDFormat(c).validate(); err != nil {
+			return err
+		}
+		if filter, ok := storage.(ExpiredFilterStorage); ok {
+			filter.SetIncludeExpired(includeExpired(c))
+		}
+
+		if !c.Bool(quietFlag) {
+			warnStaleBackup(c, storage)
+			printReminders(c, storage)
+		}
+
+		return nil
+	}
 
 	// define available commands for CLI application
 	app.Commands = []cli.Command{
 		newNoteCommand(storage),           // create a new note
 		deleteNoteCommand(storage),        // delete a note by ID
 		getNoteByIDCommand(storage),       // get a note by ID
+		infoCommand(storage),              // show age and edit recency of a note
 		listNotesCommand(storage),         // list all notes
 		updateNoteContentCommand(storage), // update content of a note
+		checkCommand(storage),             // toggle a checklist item of a note
+		progressCommand(storage),          // count done checklist items
 		searchNotesCommand(storage),       // search notes by keyword in title or content
+		diffNotesCommand(storage),         // diff contents of two notes
+		exportCommand(storage),            // export all notes
+		exportHTMLCommand(storage),        // render a note as HTML
+		mirrorCommand(storage),            // keep a markdown file in sync with a note
+		digestCommand(storage),            // print a daily summary of notes
+		graphCommand(storage),             // print links between notes as a graph
+		linkcheckCommand(storage),         // list links to missing notes
+	}
+
+	// register commands of optional storage capabilities
+	if tags, ok := storage.(TagStorage); ok {
+		app.Commands = append(app.Commands,
+			tagCommand(tags),    // manage tags of a note
+			tagAllCommand(tags), // tag notes matching a keyword
+			tagsCommand(tags),   // list all tags with counts
+		)
+	}
+	if meta, ok := storage.(MetaStorage); ok {
+		app.Commands = append(app.Commands, metaCommand(meta)) // manage custom fields of a note
+	}
+	if size, ok := storage.(SizeStorage); ok {
+		app.Commands = append(app.Commands, sizeCommand(size)) // list notes by content length
+	}
+	if breakdown, ok := storage.(BreakdownStorage); ok {
+		app.Commands = append(app.Commands, breakdownCommand(breakdown)) // sum up notes and their size per tag
+	}
+	if scrub, ok := storage.(ScrubStorage); ok {
+		app.Commands = append(app.Commands, scrubCommand(scrub)) // wipe content or titles of all notes
+	}
+	if stubs, ok := storage.(StubStorage); ok {
+		app.Commands = append(app.Commands, stubsCommand(stubs)) // list notes with no content
+	}
+	if orphans, ok := storage.(OrphanStorage); ok {
+		app.Commands = append(app.Commands, orphansCommand(orphans)) // list notes not connected to anything
+	}
+	if pins, ok := storage.(PinStorage); ok {
+		app.Commands = append(app.Commands,
+			pinNoteCommand(pins),   // pin a note
+			unpinNoteCommand(pins), // unpin a note
+			dashboardCommand(pins), // list pinned and recent notes
+		)
+	}
+	if due, ok := storage.(DueStorage); ok {
+		app.Commands = append(app.Commands, dueCommand(due)) // set a due date of a note
+	}
+	if ids, ok := storage.(IDStorage); ok {
+		app.Commands = append(app.Commands, changeIDCommand(ids)) // move a note to a new ID
+	}
+	if swaps, ok := storage.(SwapStorage); ok {
+		app.Commands = append(app.Commands, swapCommand(swaps)) // exchange title and content of a note
+	}
+	if timestamps, ok := storage.(TimestampStorage); ok {
+		app.Commands = append(app.Commands, fixTimestampsCommand(timestamps)) // backfill broken timestamps
+	}
+	if settings, ok := storage.(SettingsStorage); ok {
+		app.Commands = append(app.Commands, configCommand(settings)) // change defaults of commands
+	}
+	if pragmas, ok := storage.(PragmaStorage); ok {
+		app.Commands = append(app.Commands, pragmasCommand(pragmas)) // print database settings
+	}
+	if schemas, ok := storage.(SchemaStorage); ok {
+		app.Commands = append(app.Commands, schemaCommand(schemas)) // print the database schema
+	}
+	if compression, ok := storage.(CompressionStorage); ok {
+		app.Commands = append(app.Commands, initCommand(compression)) // change storage settings
+	}
+	if expiry, ok := storage.(ExpiryStorage); ok {
+		app.Commands = append(app.Commands,
+			expireCommand(expiry),    // make a note expire after a duration
+			expireNowCommand(expiry), // delete expired notes
+		)
+	}
+	if audit, ok := storage.(AuditStorage); ok {
+		app.Commands = append(app.Commands, auditCommand(audit)) // print changes of notes from the audit log
+	}
+	if patches, ok := storage.(PatchStorage); ok {
+		app.Commands = append(app.Commands, patchCommand(patches)) // update contents of many notes at once
+	}
+	if retitles, ok := storage.(RetitleStorage); ok {
+		app.Commands = append(app.Commands, retitleCommand(retitles)) // change titles of many notes at once
+	}
+	if seen, ok := storage.(SeenStorage); ok {
+		app.Commands = append(app.Commands,
+			markSeenCommand(seen), // mark all notes as seen
+			unseenCommand(seen),   // list notes changed since they were marked as seen
+		)
+	}
+	if nth, ok := storage.(NthStorage); ok {
+		app.Commands = append(app.Commands, nthCommand(nth)) // print the note at a position in sorted notes
+	}
+	if daily, ok := storage.(NoteOfTheDayStorage); ok {
+		app.Commands = append(app.Commands, noteOfTheDayCommand(daily)) // print the note picked for today
+	}
+	if conflicts, ok := storage.(ConflictStorage); ok {
+		app.Commands = append(app.Commands, conflictsCommand(conflicts)) // list notes with the same title
+	}
+	if backups, ok := storage.(BackupStorage); ok {
+		app.Commands = append(app.Commands, backupCommand(backups)) // copy notes to a new database file
+	}
+	if tidies, ok := storage.(TidyStorage); ok {
+		app.Commands = append(app.Commands, tidyCommand(tidies)) // normalize whitespace of all notes
+	}
+	if rebuilds, ok := storage.(RebuildStorage); ok {
+		app.Commands = append(app.Commands, rebuildCommand(rebuilds)) // regenerate content hashes and indexes
+	}
+	if daily, ok := storage.(DailyCountStorage); ok {
+		app.Commands = append(app.Commands, heatmapCommand(daily)) // print notes created per day as a calendar
+	}
+	if trash, ok := storage.(TrashStorage); ok {
+		app.Commands = append(app.Commands,
+			trashCommand(trash),   // move a note to the trash
+			restoreCommand(trash), // move a note out of the trash
+		)
+	}
+	if archives, ok := storage.(ArchiveStorage); ok {
+		app.Commands = append(app.Commands, archiveOldCommand(archives)) // move old notes into another profile
+	}
+	if trashMatching, ok := storage.(TrashMatchingStorage); ok {
+		app.Commands = append(app.Commands, trashMatchingCommand(trashMatching)) // trash all notes matching a filter
+	}
+	if captures, ok := storage.(CaptureStorage); ok {
+		app.Commands = append(app.Commands, captureCommand(captures)) // create a tagged and pinned note at once
+	}
+	if imports, ok := storage.(ImportStorage); ok {
+		app.Commands = append(app.Commands, importCommand(imports)) // import notes from markdown files
+	}
+	if remote, ok := storage.(server.Storage); ok {
+		app.Commands = append(app.Commands,
+			serveCommand(remote), // serve notes over HTTP
+			specCommand(),        // print OpenAPI document of the server
+		)
+		if settings, ok := storage.(SettingsStorage); ok {
+			app.Commands = append(app.Commands, shareCommand(storage, settings)) // share a note read-only
+		}
+	}
+	if local, ok := storage.(syncer.Local); ok {
+		app.Commands = append(app.Commands, syncCommand(local)) // sync notes with a remote server
 	}
 
+	// commands accepting --include-expired show expired notes the storage hides
+	if filter, ok := storage.(ExpiredFilterStorage); ok {
+		includeExpiredBefore(app.Commands, filter)
+	}
+
+	// describe all commands registered above for tools wrapping the CLI
+	app.Commands = append(app.Commands, commandsCommand())
+
 	return app
 }
 
@@ -70,12 +289,11 @@ func updateNoteContentCommand(storage Storage) cli.Command {
 			// retrieve first argument as note ID
 			noteIDStr := c.Args().First()
 			if noteIDStr == "" {
-				fmt.Println("Please provide ID of note to update.")
-				return nil
+				return missingArg(c, "Please provide ID of note to update.")
 			}
 
 			// convert note ID string to an integer
-			noteID, err := strconv.Atoi(noteIDStr)
+			noteID, err := noteIDFormat(c).Parse(noteIDStr)
 			if err != nil {
 				return fmt.Errorf("invalid note ID: %w", err)
 			}
@@ -83,8 +301,7 @@ func updateNoteContentCommand(storage Storage) cli.Command {
 			// retrieve second argument as new content for note
 			content := c.Args().Get(1)
 			if content == "" {
-				fmt.Println("Please provide content to update note.")
-				return nil
+				return missingArg(c, "Please provide content to update note.")
 			}
 
 			// call a function from 'storage' object to update note's content
@@ -93,7 +310,8 @@ func updateNoteContentCommand(storage Storage) cli.Command {
 				return fmt.Errorf("updating note: %w", err)
 			}
 
-			fmt.Printf("Updated note with ID %d\n", noteID)
+			fmt.Fprintf(c.App.Writer, "Updated note with ID %s\n", noteIDFormat(c).Format(noteID))
+			runPostSaveHook(c, storage, noteID)
 
 			return nil
 		},
@@ -102,6 +320,12 @@ func updateNoteContentCommand(storage Storage) cli.Command {
 	return updateNoteContent
 }
 
+// TitleSearchStorage is implemented by storages able to search titles of notes only
+type TitleSearchStorage interface {
+	// SearchNotesByTitle searches for notes containing the keyword in titles
+	SearchNotesByTitle(keyword string) ([]entities.Note, error)
+}
+
 // searchNotesCommand creates a new CLI command for searching notes by keyword.
 func searchNotesCommand(storage Storage) cli.Command {
 	// constants for command name and usage description.
@@ -112,35 +336,150 @@ func searchNotesCommand(storage Storage) cli.Command {
 
 	// create a new CLI command configuration
 	searchNotes := cli.Command{
-		Name:  commandName,  // name of command (e.g., "update")
-		Usage: commandUsage, // description of command
+		Name:    commandName,      // name of command (e.g., "update")
+		Usage:   commandUsage,     // description of command
+		Aliases: []string{"find"}, // alternative names of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "interactive, i", Usage: "filter notes live while typing the keyword"},
+			cli.BoolFlag{Name: "ids-only", Usage: "print only IDs of matching notes, one per line"},
+			cli.IntFlag{Name: "preview", Value: defaultPreviewLength, Usage: "number of characters of content to show, 0 shows full content"},
+			noContentFlag,
+			clipboardFlag,
+			includeExpiredFlag,
+			outputFlag,
+			cli.BoolFlag{Name: "include-tags", Usage: "also find notes with a tag containing the keyword"},
+			cli.BoolFlag{Name: "title-only", Usage: "find notes by their titles only, which is faster for many notes"},
+			cli.IntFlag{Name: "recent-days", Usage: "find only notes created or edited within the last N days"},
+			cli.StringFlag{Name: "sort", Usage: "order of results: matches lists notes with the most keyword occurrences first"},
+		},
 		Action: func(c *cli.Context) error {
 			// extract the command-line argument as the keyword to search for
 			keyword := c.Args().First()
+
+			output, err := outputFormat(c)
+			if err != nil {
+				return err
+			}
+
+			// searching tags requires a storage supporting them
+			searchNotes := storage.SearchNotesByKeyword
+			if c.Bool("include-tags") {
+				tagStorage, ok := storage.(TagStorage)
+				if !ok {
+					return errors.New("storage doesn't support tags")
+				}
+
+				searchNotes = tagStorage.SearchNotesIncludingTags
+			}
+			if c.Bool("title-only") {
+				if c.Bool("include-tags") {
+					return errors.New("--title-only can't be combined with --include-tags")
+				}
+
+				titleStorage, ok := storage.(TitleSearchStorage)
+				if !ok {
+					return errors.New("storage doesn't support searching titles")
+				}
+
+				searchNotes = titleStorage.SearchNotesByTitle
+			}
+
+			// the recency window is applied by storage within the same scope
+			if c.Int("recent-days") != 0 {
+				if searchNotes, err = recentSearch(c, storage); err != nil {
+					return err
+				}
+			}
+
+			// search the storage hiding expired notes unless requested otherwise
+			search := func(keyword string) ([]entities.Note, error) {
+				notes, err := searchNotes(keyword)
+
+				return hideExpired(c, notes), err
+			}
+
+			// content is hidden in every mode, including interactive search
+			hidden, err := hideContent(c, storage)
+			if err != nil {
+				return err
+			}
+
+			// in interactive mode the keyword is only the initial query
+			if c.Bool("interactive") {
+				return runInteractiveSearch(search, os.Stdin, c.App.Writer, keyword, noteIDFormat(c), hidden)
+			}
+
 			if keyword == "" {
-				fmt.Println("Please provide a keyword to search for notes.")
-				return nil
+				return missingArg(c, "Please provide a keyword to search for notes.")
+			}
+
+			previewLength := c.Int("preview")
+			if previewLength < 0 {
+				return fmt.Errorf("invalid preview length: %d", previewLength)
+			}
+
+			// a negative length hides content, it can't be requested with --preview
+			if hidden {
+				previewLength = -1
+			}
+
+			// print results while searching if nothing needs all of them at once
+			if streamer, ok := storage.(StreamSearchStorage); ok && canStreamSearch(c, output) {
+				return streamSearch(c, streamer, keyword, previewLength)
 			}
 
 			// call method from the 'storage' object to search for notes
-			notes, err := storage.SearchNotesByKeyword(keyword)
+			notes, err := search(keyword)
 			if err != nil {
-				fmt.Printf("Error searching notes: %v\n", err)
+				fmt.Fprintf(c.App.Writer, "Error searching notes: %v\n", err)
+				return err
+			}
+			if err = sortSearchResults(notes, keyword, c.String("sort")); err != nil {
 				return err
 			}
 
-			// display search results
-			if len(notes) == 0 {
-				fmt.Printf("No notes found for keyword: %s\n", keyword)
-			} else {
-				fmt.Printf("Notes found for keyword '%s':\n", keyword)
+			// print nothing but IDs, so output can be piped into other commands
+			if c.Bool("ids-only") {
+				printNoteIDs(c.App.Writer, noteIDFormat(c), notes)
+				return nil
+			}
+
+			// display search results, structured output is written even if nothing was found
+			if len(notes) == 0 && !isStructured(output) {
+				fmt.Fprintf(c.App.Writer, "No notes found for keyword: %s\n", keyword)
+				return nil
+			}
+
+			return renderWithClipboard(c, func(w io.Writer) error {
+				if isStructured(output) {
+					if notes == nil {
+						notes = []entities.Note{}
+					}
+
+					if hidden {
+						notes = withoutContent(notes)
+					}
+
+					return writeStructured(w, output, notes)
+				}
+				if output == outputTable {
+					columns := []string{columnID, columnTitle, columnContent, columnCreated, columnEdited}
+					if hidden {
+						columns = withoutColumn(columns, columnContent)
+					}
+
+					return printNoteTable(w, storage, noteIDFormat(c), notes, columns, keyword, previewLength)
+				}
+
+				fmt.Fprintf(w, "Notes found for keyword '%s':\n", keyword)
 				for _, note := range notes {
-					fmt.Printf("ID: %d, Title: %s, Content: %s, CreatedAt: %s, LastEditedAt: %s\n",
-						note.ID, note.Title, note.Content, note.CreatedAt, note.LastEditedAt)
+					if err := printSearchResult(w, noteIDFormat(c), note, keyword, previewLength); err != nil {
+						return err
+					}
 				}
-			}
 
-			return nil
+				return nil
+			})
 		},
 	}
 
@@ -159,31 +498,98 @@ func getNoteByIDCommand(storage Storage) cli.Command {
 	getNoteByID := cli.Command{
 		Name:  commandName,  // name of command (e.g., "get")
 		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			clipboardFlag,
+			widthFlag,
+			includeExpiredFlag,
+			cli.BoolFlag{Name: "numbered", Usage: "print content with line numbers"},
+			cli.BoolFlag{Name: "full", Usage: "print the whole content of large notes, see the get.max-lines setting"},
+			noContentFlag,
+			outputFlag,
+		},
 		Action: func(c *cli.Context) error {
+			output, err := outputFormat(c)
+			if err != nil {
+				return err
+			}
+			if output == outputTable {
+				return errors.New("table output is not supported by get")
+			}
+
 			// retrieve first argument as note ID
 			noteIDStr := c.Args().First()
 			if noteIDStr == "" {
-				fmt.Println("Please provide ID of note to retrieve.")
-				return nil
+				return missingArg(c, "Please provide ID of note to retrieve.")
 			}
 
 			// convert note ID string to an integer
-			noteID, err := strconv.Atoi(noteIDStr)
+			noteID, err := noteIDFormat(c).Parse(noteIDStr)
 			if err != nil {
 				return fmt.Errorf("invalid note ID: %w", err)
 			}
 
 			// call a function from 'storage' object to retrieve note by its ID
+			// storages hiding expired notes don't find them, they are looked up again for the hint
 			note, err := storage.GetNoteByID(noteID)
-			if err != nil {
+			if err != nil && (includeExpired(c) || !hiddenAsExpired(storage, noteID)) {
 				return fmt.Errorf("retrieving note: %w", err)
 			}
+			if err != nil || (note.Expired(time.Now()) && !includeExpired(c)) {
+				return fmt.Errorf("note with ID %s has expired, use --%s to show it", noteIDFormat(c).Format(noteID), includeExpiredFlag.Name)
+			}
+			hidden, err := hideContent(c, storage)
+			if err != nil {
+				return err
+			}
 
-			// print details of retrieved note
-			fmt.Printf("Note ID: %d\nTitle: %s\nContent: %s\nCreatedAt: %s\nLastEditedAt: %s\n",
-				note.ID, note.Title, note.Content, note.CreatedAt, note.LastEditedAt)
+			// print details of retrieved note, wrapping long lines to fit the terminal
+			return renderWithClipboard(c, func(w io.Writer) error {
+				if isStructured(output) {
+					if hidden {
+						note.Content = ""
+					}
 
-			return nil
+					return writeStructured(w, output, note)
+				}
+				if hidden {
+					details := fmt.Sprintf("Note ID: %s\nTitle: %s\nCreatedAt: %s\nLastEditedAt: %s\n",
+						noteIDFormat(c).Format(note.ID), note.Title, note.CreatedAt, note.LastEditedAt)
+					_, err := io.WriteString(w, wrapText(details, outputWidth(c)))
+
+					return err
+				}
+
+				// large notes are cut, so they don't flood the terminal
+				content, lines, maxLines := note.Content, 0, 0
+				if !c.Bool("full") {
+					var err error
+					if maxLines, err = maxLinesOf(storage); err != nil {
+						return err
+					}
+
+					content, lines = truncateLines(content, maxLines)
+				}
+				truncated := content != note.Content
+
+				// numbered content starts on its own line, so all lines are aligned
+				if c.Bool("numbered") {
+					content = "\n" + strings.TrimSuffix(numberLines(content), "\n")
+				}
+
+				details := fmt.Sprintf("Note ID: %s\nTitle: %s\nContent: %s\nCreatedAt: %s\nLastEditedAt: %s\n",
+					noteIDFormat(c).Format(note.ID), note.Title, content, note.CreatedAt, note.LastEditedAt)
+
+				if _, err := io.WriteString(w, wrapText(details, outputWidth(c))); err != nil {
+					return err
+				}
+
+				if truncated {
+					fmt.Fprintf(errorWriter(c), "Warning: note has %d lines, showing the first %d, use --full to show everything\n",
+						lines, maxLines)
+				}
+
+				return nil
+			})
 		},
 	}
 
@@ -200,23 +606,221 @@ func listNotesCommand(storage Storage) cli.Command {
 
 	// create a new CLI command configuration
 	listNotes := cli.Command{
-		Name:  commandName,  // name of command (e.g., "list")
-		Usage: commandUsage, // description of command
+		Name:    commandName,    // name of command (e.g., "list")
+		Usage:   commandUsage,   // description of command
+		Aliases: []string{"ls"}, // alternative names of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "meta", Usage: "list only notes with a field set to a value (key=value) or set at all (key)"},
+			cli.StringFlag{Name: "group-by", Usage: "group notes by tag, category (the 'category' field) or day of creation"},
+			cli.StringFlag{Name: "weekday", Usage: "list only notes created on a day of the week, e.g. monday or mon"},
+			cli.StringFlag{Name: "around", Usage: "list notes created around a time (YYYY-MM-DD [HH:MM]), closest first"},
+			cli.DurationFlag{Name: "window", Value: defaultAroundWindow, Usage: "width of the time window centered on --around, e.g. 3h"},
+			cli.IntFlag{Name: "limit", Usage: "list at most this many notes"},
+			cli.IntFlag{Name: "offset", Usage: "number of notes to skip with --limit"},
+			cli.BoolFlag{Name: "ids-only", Usage: "print only IDs of notes, one per line"},
+			cli.BoolFlag{Name: "content", Usage: "show a preview of content of every note"},
+			noContentFlag,
+			cli.IntFlag{Name: "preview", Value: defaultPreviewLength, Usage: "number of characters of content to show with --content, 0 shows full content"},
+			cli.StringFlag{Name: "columns", Usage: "comma-separated columns to print separated by tabs: " + strings.Join(listColumns, ",")},
+			outputFlag,
+			cli.BoolFlag{Name: "tail", Usage: "keep printing notes as they are created until interrupted"},
+			cli.DurationFlag{Name: "interval", Value: defaultTailInterval, Usage: "time between checks for new notes with --tail"},
+			includeExpiredFlag,
+		},
 		Action: func(c *cli.Context) error {
-			// call a function from 'storage' object to retrieve all notes
-			notes, err := storage.GetAllNotes()
+			output, err := outputFormat(c)
+			if err != nil {
+				return err
+			}
+			columns, err := listColumnsOf(c, storage)
+			if err != nil {
+				return err
+			}
+
+			// content is shown only if requested and not hidden, hiding it wins over --content
+			hidden, err := hideContent(c, storage)
 			if err != nil {
-				fmt.Printf("Error listing notes: %v\n", err)
 				return err
 			}
+			if hidden {
+				columns = withoutColumn(columns, columnContent)
+			}
+			previewLength := -1
+			if c.Bool("content") && !hidden {
+				previewLength = c.Int("preview")
+				if previewLength < 0 {
+					return fmt.Errorf("invalid preview length: %d", previewLength)
+				}
+			}
+
+			// print selected columns if requested, the default columns otherwise
+			printNotes := func(notes []entities.Note) error {
+				if hidden {
+					notes = withoutContent(notes)
+				}
+
+				switch {
+				case isStructured(output):
+					// always write a list, even when there are no notes
+					if notes == nil {
+						notes = []entities.Note{}
+					}
 
-			// print a header for list of notes
-			fmt.Println("List of notes:")
+					return writeStructured(c.App.Writer, output, notes)
+				case output == outputTable:
+					tableColumns := columns
+					if tableColumns == nil {
+						tableColumns = []string{columnID, columnTitle, columnCreated, columnEdited}
+						if previewLength >= 0 {
+							tableColumns = append(tableColumns, columnContent)
+						}
+					}
 
-			// iterate through retrieved notes and print their details
-			for _, note := range notes {
-				fmt.Printf("ID: %d, Title: %s, CreatedAt: %s, LastEditedAt: %s\n",
-					note.ID, note.Title, note.CreatedAt, note.LastEditedAt)
+					return printNoteTable(c.App.Writer, storage, noteIDFormat(c), notes, tableColumns, "", c.Int("preview"))
+				case columns != nil:
+					return printNoteColumns(c.App.Writer, storage, noteIDFormat(c), notes, columns, c.Int("preview"))
+				default:
+					printNoteList(c.App.Writer, noteIDFormat(c), notes, previewLength)
+					return nil
+				}
+			}
+
+			// follow new notes instead of listing existing ones
+			if c.Bool("tail") {
+				tail, ok := storage.(TailStorage)
+				if !ok {
+					return errors.New("storage doesn't support following new notes")
+				}
+
+				return tailNotes(tail, c.Duration("interval"), printNotes)
+			}
+
+			// notes are selected by at most one filter
+			var filters int
+			for _, name := range []string{"meta", "weekday", "limit", "around"} {
+				if c.IsSet(name) {
+					filters++
+				}
+			}
+			if filters > 1 {
+				return errors.New("only one of --meta, --weekday, --limit and --around can be given")
+			}
+
+			var (
+				notes []entities.Note
+				page  *entities.Page
+			)
+			if c.IsSet("limit") {
+				// paging requires a storage supporting it
+				pageStorage, ok := storage.(PageStorage)
+				if !ok {
+					return errors.New("storage doesn't support paging")
+				}
+
+				var p entities.Page
+				p, err = pageStorage.GetNotesPageMeta(c.Int("limit"), c.Int("offset"))
+				notes, page = p.Notes, &p
+			} else if weekday := c.String("weekday"); weekday != "" {
+				// filtering requires a storage supporting it
+				weekdayStorage, ok := storage.(WeekdayStorage)
+				if !ok {
+					return errors.New("storage doesn't support listing notes by weekday")
+				}
+
+				day, dayErr := parseWeekday(weekday)
+				if dayErr != nil {
+					return dayErr
+				}
+				notes, err = weekdayStorage.GetNotesByWeekday(day)
+			} else if filter := c.String("meta"); filter != "" {
+				// filtering requires a storage supporting metadata
+				metaStorage, ok := storage.(MetaStorage)
+				if !ok {
+					return errors.New("storage doesn't support note metadata")
+				}
+
+				key, value, _ := strings.Cut(filter, "=")
+				notes, err = metaStorage.GetNotesByMeta(key, value)
+			} else if around := c.String("around"); around != "" {
+				center, aroundErr := parseAroundTime(around)
+				if aroundErr != nil {
+					return aroundErr
+				}
+				if c.Duration("window") <= 0 {
+					return fmt.Errorf("invalid window: %s", c.Duration("window"))
+				}
+
+				notes, err = storage.GetAllNotes()
+				err = warnSkippedNotes(c, err)
+				notes = notesAround(notes, center, c.Duration("window"))
+			} else {
+				// call a function from 'storage' object to retrieve all notes, listing readable ones if some are damaged
+				notes, err = storage.GetAllNotes()
+				err = warnSkippedNotes(c, err)
+			}
+			if err != nil {
+				fmt.Fprintf(c.App.Writer, "Error listing notes: %v\n", err)
+				return err
+			}
+			notes = hideExpired(c, notes)
+			if hidden {
+				notes = withoutContent(notes)
+			}
+
+			// print nothing but IDs, so output can be piped into other commands
+			if c.Bool("ids-only") {
+				printNoteIDs(c.App.Writer, noteIDFormat(c), notes)
+				return nil
+			}
+
+			// structured output of a page includes information about all notes
+			if isStructured(output) && page != nil {
+				page.Notes = notes
+				if page.Notes == nil {
+					page.Notes = []entities.Note{}
+				}
+
+				return writeStructured(c.App.Writer, output, page)
+			}
+
+			// print notes under a header per group if grouping is requested
+			if mode := c.String("group-by"); mode != "" {
+				if isStructured(output) {
+					return fmt.Errorf("--group-by is not supported with %s output", output)
+				}
+
+				groups, err := groupNotes(storage, notes, mode)
+				if err != nil {
+					return err
+				}
+
+				for i, group := range groups {
+					if i > 0 {
+						fmt.Fprintln(c.App.Writer)
+					}
+					fmt.Fprintf(c.App.Writer, "%s (%d):\n", group.name, len(group.notes))
+					if err = printNotes(group.notes); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+
+			// tables, columns and structured output are printed without the header
+			if output != outputText || columns != nil {
+				if err = printNotes(notes); err != nil {
+					return err
+				}
+			} else {
+				// print a header for list of notes
+				fmt.Fprintln(c.App.Writer, "List of notes:")
+				printNoteList(c.App.Writer, noteIDFormat(c), notes, previewLength)
+			}
+
+			// tell which part of all notes was printed, except for columns processed by other tools
+			if page != nil && columns == nil && !isStructured(output) {
+				printPageFooter(c.App.Writer, *page, c.Int("offset"))
 			}
 
 			return nil
@@ -226,6 +830,44 @@ func listNotesCommand(storage Storage) cli.Command {
 	return listNotes
 }
 
+// printNoteList prints details of notes one per line with IDs in the format.
+// Content is shown shortened to previewLength runes, 0 shows full content and a negative length hides it
+func printNoteList(w io.Writer, ids idFormat, notes []entities.Note, previewLength int) {
+	// iterate through notes and print their details
+	for _, note := range notes {
+		if previewLength < 0 {
+			fmt.Fprintf(w, "ID: %s, Title: %s, CreatedAt: %s, LastEditedAt: %s\n",
+				ids.Format(note.ID), note.Title, note.CreatedAt, note.LastEditedAt)
+			continue
+		}
+
+		fmt.Fprintf(w, "ID: %s, Title: %s, Content: %s, CreatedAt: %s, LastEditedAt: %s\n",
+			ids.Format(note.ID), note.Title, preview(note.Content, "", previewLength), note.CreatedAt, note.LastEditedAt)
+	}
+}
+
+// printSearchResult prints details of a note found by keyword on a single line, content is shown shortened
+// to previewLength runes around the keyword, 0 shows full content and a negative length hides it
+func printSearchResult(w io.Writer, ids idFormat, note entities.Note, keyword string, previewLength int) error {
+	if previewLength < 0 {
+		_, err := fmt.Fprintf(w, "ID: %s, Title: %s, CreatedAt: %s, LastEditedAt: %s\n",
+			ids.Format(note.ID), note.Title, note.CreatedAt, note.LastEditedAt)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "ID: %s, Title: %s, Content: %s, CreatedAt: %s, LastEditedAt: %s\n",
+		ids.Format(note.ID), note.Title, preview(note.Content, keyword, previewLength), note.CreatedAt, note.LastEditedAt)
+
+	return err
+}
+
+// printNoteIDs prints IDs of notes in the format one per line
+func printNoteIDs(w io.Writer, ids idFormat, notes []entities.Note) {
+	for _, note := range notes {
+		fmt.Fprintln(w, ids.Format(note.ID))
+	}
+}
+
 // deleteNoteCommand creates new CLI command for deleting note from storage with provided storage object
 func deleteNoteCommand(storage Storage) cli.Command {
 	// constants for command name and usage description
@@ -236,8 +878,9 @@ func deleteNoteCommand(storage Storage) cli.Command {
 
 	// create a new CLI command configuration.
 	deleteNote := cli.Command{
-		Name:  commandName,  // name of command (e.g., "delete")
-		Usage: commandUsage, // description of command
+		Name:    commandName,           // name of command (e.g., "delete")
+		Usage:   commandUsage,          // description of command
+		Aliases: []string{"rm", "del"}, // alternative names of command
 		Action: func(c *cli.Context) error {
 			// retrieve first argument as note ID
 			noteIDStr := c.Args().First()
@@ -246,7 +889,7 @@ func deleteNoteCommand(storage Storage) cli.Command {
 			}
 
 			// convert note ID string to an integer
-			noteID, err := strconv.Atoi(noteIDStr)
+			noteID, err := noteIDFormat(c).Parse(noteIDStr)
 			if err != nil {
 				return fmt.Errorf("invalid note ID: %w", err)
 			}
@@ -257,7 +900,7 @@ func deleteNoteCommand(storage Storage) cli.Command {
 				return fmt.Errorf("Error deleting note: %v\n", err)
 			}
 
-			fmt.Printf("Deleted note with ID %d\n", deletedNoteID)
+			fmt.Fprintf(c.App.Writer, "Deleted note with ID %s\n", noteIDFormat(c).Format(deletedNoteID))
 
 			return nil
 		},
@@ -276,30 +919,85 @@ func newNoteCommand(storage Storage) cli.Command {
 
 	// create a new CLI command configuration
 	newNote := cli.Command{
-		Name:  commandName,  // name of command (e.g., "new")
-		Usage: commandUsage, // description of command
+		Name:    commandName,     // name of command (e.g., "new")
+		Usage:   commandUsage,    // description of command
+		Aliases: []string{"add"}, // alternative names of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "auto-title", Usage: "generate title from the first line of content given as the only argument or on standard input"},
+			cli.BoolFlag{Name: "open", Usage: "open the created note in $EDITOR"},
+			cli.StringFlag{Name: "expires", Usage: "make the note expire after a duration (e.g., 30m, 1h, 7d)"},
+			cli.StringFlag{Name: "json", Usage: `create the note from a JSON object with "title" and "content", "-" reads it from standard input`},
+		},
 		Action: func(c *cli.Context) error {
-			// retrieve first argument as title of new note
-			title := c.Args().First()
+			// validate expiry before creating the note
+			var (
+				expiry ExpiryStorage
+				ttl    time.Duration
+			)
+			if ttlStr := c.String("expires"); ttlStr != "" {
+				var (
+					ok  bool
+					err error
+				)
+				if expiry, ok = storage.(ExpiryStorage); !ok {
+					return errors.New("storage doesn't support expiring notes")
+				}
+				if c.Bool("auto-title") {
+					return errors.New("--expires can't be combined with --auto-title")
+				}
+				if ttl, err = parseTTL(ttlStr); err != nil {
+					return err
+				}
+			}
+
+			if c.Bool("auto-title") && c.NArg() < 2 {
+				return newNoteWithAutoTitle(c, storage, c.Args().First(), c.Bool("open"))
+			}
+
+			// retrieve first argument as title and second argument as content of new note
+			title, content := c.Args().First(), c.Args().Get(1)
+
+			// a JSON payload replaces positional arguments
+			if payload := c.String("json"); payload != "" {
+				if c.NArg() > 0 {
+					return errors.New("--json can't be combined with title and content arguments")
+				}
+
+				var err error
+				if title, content, err = readNotePayload(payload, os.Stdin); err != nil {
+					return err
+				}
+			}
+
 			if title == "" {
-				fmt.Println("Please provide a title for new note.")
-				return nil
+				return missingArg(c, "Please provide a title for new note.")
 			}
 
-			// retrieve second argument as content of new note
-			content := c.Args().Get(1)
 			if content == "" {
-				fmt.Println("Please provide content for new note.")
-				return nil
+				return missingArg(c, "Please provide content for new note.")
 			}
 
-			// call a function from 'storage' object to create a new note with provided title
-			noteID, err := storage.NewNote(title, content)
+			// call a function from 'storage' object to create a new note with provided title,
+			// an expiring note is created together with its expiry
+			var (
+				noteID int
+				err    error
+			)
+			if ttl > 0 {
+				noteID, err = expiry.NewNoteWithExpiry(title, content, time.Now().Add(ttl))
+			} else {
+				noteID, err = storage.NewNote(title, content)
+			}
 			if err != nil {
 				return fmt.Errorf("creating new note: %v\n", err)
 			}
 
-			fmt.Printf("Created a new note with ID %d\n", noteID)
+			fmt.Fprintf(c.App.Writer, "Created a new note with ID %s\n", noteIDFormat(c).Format(noteID))
+			runPostSaveHook(c, storage, noteID)
+
+			if c.Bool("open") {
+				return editNote(c.App.Writer, storage, noteIDFormat(c), noteID, content)
+			}
 
 			return nil
 		},
@@ -307,3 +1005,100 @@ func newNoteCommand(storage Storage) cli.Command {
 
 	return newNote
 }
+
+// newNoteWithAutoTitle creates a note titled after its content, which is read from standard input if not given.
+// If open is set, the note is opened in $EDITOR afterwards
+func newNoteWithAutoTitle(c *cli.Context, storage Storage, content string, open bool) error {
+	if content == "" {
+		var err error
+		if content, err = readPipedContent(); err != nil {
+			return err
+		}
+	}
+
+	title := autoTitle(content)
+	if title == "" {
+		return errors.New("can't generate title: content is empty")
+	}
+
+	noteID, err := storage.NewNote(title, content)
+	if err != nil {
+		return fmt.Errorf("creating new note: %w", err)
+	}
+
+	fmt.Fprintf(c.App.Writer, "Created a new note %q with ID %s\n", title, noteIDFormat(c).Format(noteID))
+	runPostSaveHook(c, storage, noteID)
+
+	if open {
+		return editNote(c.App.Writer, storage, noteIDFormat(c), noteID, content)
+	}
+
+	return nil
+}
+
+// editNote opens content of the note in $EDITOR and saves it back if it was changed, reporting it to w
+// with the ID in the format
+func editNote(w io.Writer, storage Storage, ids idFormat, noteID int, content string) error {
+	edited, changed, err := editContent(content)
+	if err != nil {
+		return fmt.Errorf("editing note: %w", err)
+	}
+
+	// leave the note as is if the editor was closed without changes
+	if !changed {
+		return nil
+	}
+
+	if err = storage.SetNoteContent(noteID, edited); err != nil {
+		return fmt.Errorf("saving edited note: %w", err)
+	}
+
+	fmt.Fprintf(w, "Updated content of note with ID %s\n", ids.Format(noteID))
+
+	return nil
+}
+
+// missingArg reports a missing required argument. By default the message is printed as a hint and nil is returned,
+// so interactive use isn't treated as a failure. With the global --strict flag an error with the message is returned instead
+func missingArg(c *cli.Context, message string) error {
+	if c.GlobalBool(strictFlag) {
+		return fmt.Errorf("%w: %s", errMissingArgument, message)
+	}
+
+	fmt.Fprintln(c.App.Writer, message)
+
+	return nil
+}
+
+// noteIDArg parses the first argument as a note ID.
+// If it's missing, ok is false and err is the result of missingArg with the message
+func noteIDArg(c *cli.Context, message string) (noteID int, ok bool, err error) {
+	// retrieve first argument as note ID
+	noteIDStr := c.Args().First()
+	if noteIDStr == "" {
+		return 0, false, missingArg(c, message)
+	}
+
+	// convert note ID string to an integer
+	noteID, err = noteIDFormat(c).Parse(noteIDStr)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid note ID: %w", err)
+	}
+
+	return noteID, true, nil
+}
+
+// readPipedContent reads content piped into standard input without trailing newlines.
+// An interactive terminal is never waited on, an empty string is returned instead
+func readPipedContent() (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("reading content: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/atotto/clipboard"
+	"github.com/urfave/cli"
+)
+
+// copyToClipboard replaces content of the system clipboard, tests replace it with a stub
+var copyToClipboard = clipboard.WriteAll
+
+// clipboardFlag enables copying output of a command to the clipboard
+var clipboardFlag = cli.BoolFlag{Name: "clipboard", Usage: "also copy the output to the system clipboard"}
+
+// renderWithClipboard prints output produced by render and copies it to the clipboard if --clipboard is set.
+// Missing clipboard support is reported without failing the command, as the output was printed anyway
+func renderWithClipboard(c *cli.Context, render func(w io.Writer) error) error {
+	if !c.Bool(clipboardFlag.Name) {
+		return render(c.App.Writer)
+	}
+
+	var buf bytes.Buffer
+	if err := render(io.MultiWriter(c.App.Writer, &buf)); err != nil {
+		return err
+	}
+
+	// messages go to error output, so they don't mix with printed notes
+	errWriter := errorWriter(c)
+
+	if err := copyToClipboard(buf.String()); err != nil {
+		fmt.Fprintf(errWriter, "Clipboard is not available, output was not copied: %v\n", err)
+		return nil
+	}
+
+	fmt.Fprintln(errWriter, "Copied to clipboard")
+
+	return nil
+}
+
+// errorWriter returns the writer of messages which must not mix with printed notes
+func errorWriter(c *cli.Context) io.Writer {
+	if c.App.ErrWriter != nil {
+		return c.App.ErrWriter
+	}
+
+	return cli.ErrWriter
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubClipboard replaces the system clipboard with a function for the duration of the test
+func stubClipboard(t *testing.T, stub func(text string) error) {
+	t.Helper()
+
+	original := copyToClipboard
+	copyToClipboard = stub
+	t.Cleanup(func() { copyToClipboard = original })
+}
+
+func TestSearchClipboard(t *testing.T) {
+	var copied string
+	stubClipboard(t, func(text string) error {
+		copied = text
+		return nil
+	})
+
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+	_, _ = storage.NewNote("Meeting", "Project deadline.")
+
+	var out, errOut bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	if err := app.Run([]string{"go-notes", "search", "--clipboard", "bread"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// the same output is printed and copied
+	if copied == "" || copied != out.String() {
+		t.Errorf("Expected copied text to equal printed output %q, got %q", out.String(), copied)
+	}
+	if !strings.Contains(copied, "Title: Groceries") || strings.Contains(copied, "Meeting") {
+		t.Errorf("Expected only the matching note to be copied, got %q", copied)
+	}
+}
+
+func TestGetClipboardUnavailable(t *testing.T) {
+	stubClipboard(t, func(text string) error {
+		return errors.New("no clipboard utilities available")
+	})
+
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+
+	var out, errOut bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	// a missing clipboard doesn't fail the command
+	if err := app.Run([]string{"go-notes", "get", "--clipboard", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Title: Groceries") {
+		t.Errorf("Expected note to be printed, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "Clipboard is not available") {
+		t.Errorf("Expected a message about missing clipboard, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// columns which list can print with --columns
+const (
+	columnID      = "id"
+	columnTitle   = "title"
+	columnCreated = "created"
+	columnEdited  = "edited"
+	columnContent = "content"
+	columnTags    = "tags"
+)
+
+// listColumns lists all supported columns in their default order
+var listColumns = []string{columnID, columnTitle, columnCreated, columnEdited, columnContent, columnTags}
+
+// parseColumns parses a comma-separated list of column names, an empty list selects the default output
+func parseColumns(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var columns []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(listColumns, name) {
+			return nil, fmt.Errorf("unknown column %q, expected some of %s", name, strings.Join(listColumns, ","))
+		}
+
+		columns = append(columns, name)
+	}
+
+	return columns, nil
+}
+
+// listColumnsOf returns columns given with --columns, falling back to the default configured with
+// config set list.columns. Nil columns select the default output
+func listColumnsOf(c *cli.Context, storage Storage) ([]string, error) {
+	if c.IsSet("columns") {
+		return parseColumns(c.String("columns"))
+	}
+
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return nil, nil
+	}
+
+	value, _, err := settings.GetSetting(configListColumns)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving default columns: %w", err)
+	}
+
+	return parseColumns(value)
+}
+
+// printNoteColumns prints the columns of every note separated by tabs, one note per line.
+// Content is shortened to previewLength characters, 0 shows full content on a single line
+func printNoteColumns(w io.Writer, storage Storage, ids idFormat, notes []entities.Note, columns []string, previewLength int) error {
+	rows, err := noteRows(storage, ids, notes, columns, "", previewLength)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return nil
+}
+
+// noteRows returns values of the columns of every note, each on a single line, with IDs in the format.
+// Content is shortened to previewLength characters around keyword, 0 keeps full content
+func noteRows(storage Storage, ids idFormat, notes []entities.Note, columns []string, keyword string, previewLength int) ([][]string, error) {
+	// tags are retrieved only if they are printed
+	var tagStorage TagStorage
+	if contains(columns, columnTags) {
+		var ok bool
+		if tagStorage, ok = storage.(TagStorage); !ok {
+			return nil, errors.New("storage doesn't support tags")
+		}
+	}
+
+	rows := make([][]string, 0, len(notes))
+	for _, note := range notes {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			switch column {
+			case columnID:
+				row[i] = ids.Format(note.ID)
+			case columnTitle:
+				row[i] = note.Title
+			case columnCreated:
+				row[i] = note.CreatedAt.Format(time.DateTime)
+			case columnEdited:
+				row[i] = note.LastEditedAt.Format(time.DateTime)
+			case columnContent:
+				row[i] = preview(note.Content, keyword, previewLength)
+			case columnTags:
+				tags, err := tagStorage.GetNoteTags(note.ID)
+				if err != nil {
+					return nil, fmt.Errorf("retrieving tags: %w", err)
+				}
+				row[i] = strings.Join(tags, ",")
+			}
+
+			// keep every note on a single line with tabs separating only columns
+			row[i] = strings.Join(strings.Fields(row[i]), " ")
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// contains reports whether values contain s
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+
+	return false
+}
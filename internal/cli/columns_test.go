@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+// settingsStorage adds settings to fakeStorage
+type settingsStorage struct {
+	fakeStorage
+	settings map[string]string
+}
+
+func (s *settingsStorage) GetSetting(key string) (string, bool, error) {
+	value, ok := s.settings[key]
+	return value, ok, nil
+}
+
+func (s *settingsStorage) SetSetting(key, value string) error {
+	if s.settings == nil {
+		s.settings = make(map[string]string)
+	}
+	s.settings[key] = value
+
+	return nil
+}
+
+func TestListColumns(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk\tand\nbread.")
+	_, _ = storage.NewNote("Meeting", "Discuss the release.")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "list", "--columns", "title, ID,content"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := "Groceries\t1\tMilk and bread.\nMeeting\t2\tDiscuss the release.\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
+
+func TestListColumnsDefault(t *testing.T) {
+	storage := &settingsStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "config", "set", "list.columns", "id,title"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// the configured columns are used unless --columns is given
+	out.Reset()
+	_ = app.Run([]string{"go-notes", "list"})
+	if out.String() != "1\tGroceries\n" {
+		t.Errorf("Expected configured columns, got %q", out.String())
+	}
+
+	out.Reset()
+	_ = app.Run([]string{"go-notes", "list", "--columns", "title"})
+	if out.String() != "Groceries\n" {
+		t.Errorf("Expected columns from flag, got %q", out.String())
+	}
+}
+
+func TestListColumnsUnknown(t *testing.T) {
+	storage := &settingsStorage{}
+
+	app := NewCLI(storage)
+	app.Writer = &bytes.Buffer{}
+
+	if err := app.Run([]string{"go-notes", "list", "--columns", "id,author"}); err == nil {
+		t.Errorf("Expected error for an unknown column, got nil")
+	}
+	if err := app.Run([]string{"go-notes", "config", "set", "list.columns", "id,author"}); err == nil {
+		t.Errorf("Expected error configuring an unknown column, got nil")
+	}
+
+	// tags can't be printed without a storage supporting them
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+	if err := app.Run([]string{"go-notes", "list", "--columns", "id,tags"}); err == nil {
+		t.Errorf("Expected error for tags of a storage without tags, got nil")
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// commandInfo describes a command of the application for tools wrapping the CLI
+type commandInfo struct {
+	Name        string        `json:"name"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Usage       string        `json:"usage"`
+	ArgsUsage   string        `json:"args_usage,omitempty"`
+	Flags       []flagInfo    `json:"flags"`
+	Subcommands []commandInfo `json:"subcommands,omitempty"`
+}
+
+// flagInfo describes a flag of a command, its first name is the long one
+type flagInfo struct {
+	Names      []string `json:"names"`
+	Usage      string   `json:"usage"`
+	TakesValue bool     `json:"takes_value"`
+	Default    string   `json:"default,omitempty"`
+}
+
+// appInfo describes global flags and commands of the application
+type appInfo struct {
+	Flags    []flagInfo    `json:"flags"`
+	Commands []commandInfo `json:"commands"`
+}
+
+// describeCommands describes the commands, hidden ones are included as they can be run as well
+func describeCommands(commands []cli.Command) []commandInfo {
+	infos := []commandInfo{}
+	for _, command := range commands {
+		info := commandInfo{
+			Name:      command.Name,
+			Aliases:   command.Aliases,
+			Usage:     command.Usage,
+			ArgsUsage: command.ArgsUsage,
+			Flags:     describeFlags(command.Flags),
+		}
+		if len(command.Subcommands) > 0 {
+			info.Subcommands = describeCommands(command.Subcommands)
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// describeFlags describes the flags, usage and default value are known only for flags of urfave/cli
+func describeFlags(flags []cli.Flag) []flagInfo {
+	infos := []flagInfo{}
+	for _, flag := range flags {
+		// names are given as "name, n"
+		var info flagInfo
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			info.Names = append(info.Names, strings.TrimSpace(name))
+		}
+
+		if doc, ok := flag.(cli.DocGenerationFlag); ok {
+			info.Usage, info.TakesValue, info.Default = doc.GetUsage(), doc.TakesValue(), doc.GetValue()
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// commandsCommand creates a hidden CLI command describing all commands of the application,
+// it reads the live definitions, so the description always matches the application
+func commandsCommand() cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "commands"
+		commandUsage = "List commands of the application, as JSON with --json"
+	)
+
+	// create a new CLI command configuration
+	commands := cli.Command{
+		Name:   commandName,  // name of command (e.g., "commands")
+		Usage:  commandUsage, // description of command
+		Hidden: true,
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "json", Usage: "describe commands with their usage and flags as JSON"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("json") {
+				info := appInfo{Flags: describeFlags(c.App.Flags), Commands: describeCommands(c.App.Commands)}
+				return writeStructured(c.App.Writer, outputJSON, info)
+			}
+
+			for _, command := range c.App.Commands {
+				fmt.Fprintln(c.App.Writer, command.Name)
+			}
+
+			return nil
+		},
+	}
+
+	return commands
+}
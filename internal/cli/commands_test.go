@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCommandsJSON(t *testing.T) {
+	app := NewCLI(&settingsStorage{})
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "commands", "--json"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var info appInfo
+	if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	described := map[string]commandInfo{}
+	for _, command := range info.Commands {
+		described[command.Name] = command
+	}
+	for _, command := range app.Commands {
+		if _, ok := described[command.Name]; !ok {
+			t.Errorf("Expected command %s in the JSON", command.Name)
+		}
+	}
+
+	// flags and subcommands are described as defined
+	search := described["search"]
+	if len(search.Aliases) != 1 || search.Aliases[0] != "find" {
+		t.Errorf("Expected alias find of search, got %v", search.Aliases)
+	}
+	found := false
+	for _, flag := range search.Flags {
+		if flag.Names[0] == "interactive" {
+			found = len(flag.Names) == 2 && flag.Names[1] == "i" && !flag.TakesValue && flag.Usage != ""
+		}
+	}
+	if !found {
+		t.Errorf("Expected the interactive flag of search, got %+v", search.Flags)
+	}
+	if len(described["config"].Subcommands) == 0 {
+		t.Error("Expected subcommands of config")
+	}
+	if len(info.Flags) == 0 {
+		t.Error("Expected global flags")
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+)
+
+// SettingsStorage is implemented by storages able to keep settings between runs
+type SettingsStorage interface {
+	// GetSetting retrieves the value of a setting, ok is false if the setting is not set
+	GetSetting(key string) (value string, ok bool, err error)
+
+	// SetSetting stores the value of a setting, replacing the previous one
+	SetSetting(key, value string) error
+}
+
+// keys of settings which can be changed with config
+const (
+	// configListColumns holds columns printed by list by default, see --columns
+	configListColumns = "list.columns"
+
+	// configGetMaxLines holds the number of lines get prints of large notes without --full, 0 disables the limit
+	configGetMaxLines = "get.max-lines"
+
+	// configShareSecret holds the secret signing links of share, changing it revokes all links
+	configShareSecret = "share.secret"
+
+	// configBackupWarnDays holds the age of the last backup in days after which commands print a reminder, 0 disables it
+	configBackupWarnDays = "backup.warn-days"
+
+	// configRemindersEnabled enables the banner of overdue and due today notes printed before commands
+	configRemindersEnabled = "reminders.enabled"
+
+	// configNoContent hides content of notes printed by search, get and list, like --no-content
+	configNoContent = "output.no-content"
+
+	// configPostSaveHook holds a shell command run after new and update save a note, see runHook
+	configPostSaveHook = "hooks.post-save"
+)
+
+// configKeys maps keys of settings which can be changed with config to functions validating their values
+var configKeys = map[string]func(value string) error{
+	configListColumns: func(value string) error {
+		_, err := parseColumns(value)
+		return err
+	},
+	configGetMaxLines: func(value string) error {
+		_, err := parseMaxLines(value)
+		return err
+	},
+	configShareSecret: parseShareSecret,
+	configBackupWarnDays: func(value string) error {
+		_, err := parseWarnDays(value)
+		return err
+	},
+	configRemindersEnabled: func(value string) error {
+		_, err := parseBoolSetting(value)
+		return err
+	},
+	configNoContent: func(value string) error {
+		_, err := parseBoolSetting(value)
+		return err
+	},
+	configPostSaveHook: func(string) error { return nil },
+}
+
+// configCommand creates new CLI command for reading and changing defaults of other commands
+func configCommand(storage SettingsStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "config"
+		commandUsage = "Read or change defaults of commands, e.g. list.columns"
+	)
+
+	// create a new CLI command configuration with a subcommand per operation
+	config := cli.Command{
+		Name:  commandName,  // name of command (e.g., "config")
+		Usage: commandUsage, // description of command
+		Subcommands: []cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Change a default, an empty value restores the built-in one",
+				ArgsUsage: "key value",
+				Action: func(c *cli.Context) error {
+					key := c.Args().First()
+					if key == "" || c.NArg() < 2 {
+						return missingArg(c, "Please provide a key and a value.")
+					}
+
+					value := c.Args().Get(1)
+					validate, found := configKeys[key]
+					if !found {
+						return fmt.Errorf("unknown config key %q", key)
+					}
+					if err := validate(value); err != nil {
+						return err
+					}
+
+					if err := storage.SetSetting(key, value); err != nil {
+						return fmt.Errorf("changing config: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Set '%s' to %q\n", key, value)
+
+					return nil
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Print all defaults or a single one",
+				ArgsUsage: "[key]",
+				Action: func(c *cli.Context) error {
+					keys := []string{c.Args().First()}
+					if keys[0] == "" {
+						keys = keys[:0]
+						for key := range configKeys {
+							keys = append(keys, key)
+						}
+						sort.Strings(keys)
+					} else if _, found := configKeys[keys[0]]; !found {
+						return fmt.Errorf("unknown config key %q", keys[0])
+					}
+
+					for _, key := range keys {
+						value, _, err := storage.GetSetting(key)
+						if err != nil {
+							return fmt.Errorf("retrieving config: %w", err)
+						}
+
+						fmt.Fprintf(c.App.Writer, "%s: %s\n", key, value)
+					}
+
+					return nil
+				},
+			},
+		},
+	}
+
+	return config
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// ConflictStorage is implemented by storages able to find notes with the same title but different content
+type ConflictStorage interface {
+	// FindTitleConflicts retrieves groups of notes sharing a title whose contents differ
+	FindTitleConflicts() ([][]entities.Note, error)
+}
+
+// conflictsCommand creates new CLI command for listing notes with the same title but different content
+func conflictsCommand(storage ConflictStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "conflicts"
+		commandUsage = "List notes having the same title but different content"
+	)
+
+	// create a new CLI command configuration
+	conflicts := cli.Command{
+		Name:  commandName,  // name of command (e.g., "conflicts")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.IntFlag{Name: "preview", Value: defaultPreviewLength, Usage: "number of characters of content to show, 0 shows full content"},
+		},
+		Action: func(c *cli.Context) error {
+			previewLength := c.Int("preview")
+			if previewLength < 0 {
+				return fmt.Errorf("invalid preview length: %d", previewLength)
+			}
+
+			groups, err := storage.FindTitleConflicts()
+			if err != nil {
+				return fmt.Errorf("finding conflicts: %w", err)
+			}
+
+			if len(groups) == 0 {
+				fmt.Fprintln(c.App.Writer, "No conflicting notes found")
+				return nil
+			}
+
+			for i, group := range groups {
+				if i > 0 {
+					fmt.Fprintln(c.App.Writer)
+				}
+				fmt.Fprintf(c.App.Writer, "%s (%d):\n", group[0].Title, len(group))
+				printNoteList(c.App.Writer, noteIDFormat(c), group, previewLength)
+			}
+
+			return nil
+		},
+	}
+
+	return conflicts
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/diff"
+)
+
+// diffNotesCommand creates new CLI command for printing a unified diff between contents of two notes
+func diffNotesCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "diff"
+		commandUsage = "Show a unified diff between contents of two notes"
+	)
+
+	// create a new CLI command configuration
+	diffNotes := cli.Command{
+		Name:      commandName,  // name of command (e.g., "diff")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "fromID toID",
+		Action: func(c *cli.Context) error {
+			// retrieve both arguments as note IDs
+			fromIDStr, toIDStr := c.Args().Get(0), c.Args().Get(1)
+			if fromIDStr == "" || toIDStr == "" {
+				return missingArg(c, "Please provide IDs of two notes to compare.")
+			}
+
+			// convert note IDs to integers
+			fromID, err := noteIDFormat(c).Parse(fromIDStr)
+			if err != nil {
+				return fmt.Errorf("invalid note ID: %w", err)
+			}
+			toID, err := noteIDFormat(c).Parse(toIDStr)
+			if err != nil {
+				return fmt.Errorf("invalid note ID: %w", err)
+			}
+
+			// retrieve both notes from storage
+			from, err := storage.GetNoteByID(fromID)
+			if err != nil {
+				return fmt.Errorf("retrieving note %s: %w", fromIDStr, err)
+			}
+			to, err := storage.GetNoteByID(toID)
+			if err != nil {
+				return fmt.Errorf("retrieving note %s: %w", toIDStr, err)
+			}
+
+			// compute the diff of note contents
+			unified := diff.Unified(
+				fmt.Sprintf("note %s (%s)", noteIDFormat(c).Format(from.ID), from.Title),
+				fmt.Sprintf("note %s (%s)", noteIDFormat(c).Format(to.ID), to.Title),
+				from.Content, to.Content,
+			)
+			if unified == "" {
+				fmt.Fprintln(c.App.Writer, "Notes have identical content.")
+				return nil
+			}
+
+			fmt.Fprint(c.App.Writer, unified)
+
+			return nil
+		},
+	}
+
+	return diffNotes
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// digest is a daily summary of notes
+type digest struct {
+	Date             string          `json:"date"`
+	CreatedYesterday int             `json:"created_yesterday"`
+	Due              []entities.Note `json:"due"`
+	Pinned           []entities.Note `json:"pinned"`
+	LastEdited       *entities.Note  `json:"last_edited"`
+}
+
+// digestCommand creates new CLI command for printing a daily summary of notes
+func digestCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "digest"
+		commandUsage = "Print a daily summary: notes created yesterday, due notes, pinned notes and the last edited one"
+	)
+
+	// create a new CLI command configuration
+	digestNotes := cli.Command{
+		Name:  commandName,  // name of command (e.g., "digest")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "json", Usage: "print the digest as JSON"},
+		},
+		Action: func(c *cli.Context) error {
+			d, err := buildDigest(storage, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("json") {
+				encoder := json.NewEncoder(c.App.Writer)
+				encoder.SetIndent("", "  ")
+
+				return encoder.Encode(d)
+			}
+
+			printDigest(c.App.Writer, noteIDFormat(c), d)
+
+			return nil
+		},
+	}
+
+	return digestNotes
+}
+
+// buildDigest composes the digest for the day of now, using its location for day boundaries.
+// Sections requiring optional storage capabilities are left empty if storage doesn't support them
+func buildDigest(storage Storage, now time.Time) (digest, error) {
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	startOfYesterday, startOfTomorrow := startOfToday.AddDate(0, 0, -1), startOfToday.AddDate(0, 0, 1)
+
+	d := digest{Date: startOfToday.Format(time.DateOnly)}
+
+	notes, err := storage.GetAllNotes()
+	if err != nil {
+		return digest{}, fmt.Errorf("retrieving notes: %w", err)
+	}
+
+	for i, note := range notes {
+		if !note.CreatedAt.Before(startOfYesterday) && note.CreatedAt.Before(startOfToday) {
+			d.CreatedYesterday++
+		}
+		if d.LastEdited == nil || note.LastEditedAt.After(d.LastEdited.LastEditedAt) {
+			d.LastEdited = &notes[i]
+		}
+	}
+
+	// notes due today include overdue ones
+	if dueStorage, ok := storage.(DueStorage); ok {
+		d.Due, err = dueStorage.GetDueNotes(startOfTomorrow)
+		if err != nil {
+			return digest{}, fmt.Errorf("retrieving due notes: %w", err)
+		}
+	}
+
+	if pinStorage, ok := storage.(PinStorage); ok {
+		dashboard, err := pinStorage.GetNotesForDashboard()
+		if err != nil {
+			return digest{}, fmt.Errorf("retrieving pinned notes: %w", err)
+		}
+
+		for _, note := range dashboard {
+			if note.PinnedAt != nil {
+				d.Pinned = append(d.Pinned, note)
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// printDigest writes the digest as text with note IDs in the format
+func printDigest(w io.Writer, ids idFormat, d digest) {
+	fmt.Fprintf(w, "Digest for %s\n\n", d.Date)
+	fmt.Fprintf(w, "Created yesterday: %d note(s)\n", d.CreatedYesterday)
+
+	fmt.Fprintln(w, "\nDue today or overdue:")
+	if len(d.Due) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, note := range d.Due {
+		fmt.Fprintf(w, "  ID: %s, Title: %s, Due: %s\n", ids.Format(note.ID), note.Title, note.DueAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	fmt.Fprintln(w, "\nPinned:")
+	if len(d.Pinned) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, note := range d.Pinned {
+		fmt.Fprintf(w, "  ID: %s, Title: %s\n", ids.Format(note.ID), note.Title)
+	}
+
+	fmt.Fprintln(w, "\nLast edited:")
+	if d.LastEdited == nil {
+		fmt.Fprintln(w, "  none")
+	} else {
+		fmt.Fprintf(w, "  ID: %s, Title: %s, LastEditedAt: %s\n", ids.Format(d.LastEdited.ID), d.LastEdited.Title, d.LastEdited.LastEditedAt)
+	}
+}
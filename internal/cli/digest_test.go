@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// digestStorage adds due dates and pins to fakeStorage
+type digestStorage struct {
+	fakeStorage
+}
+
+func (s *digestStorage) SetDueDate(noteID int, due time.Time) error {
+	return nil
+}
+
+func (s *digestStorage) GetDueNotes(before time.Time) ([]entities.Note, error) {
+	var due []entities.Note
+	for _, note := range s.notes {
+		if note.DueAt != nil && note.DueAt.Before(before) {
+			due = append(due, note)
+		}
+	}
+
+	return due, nil
+}
+
+func (s *digestStorage) PinNote(noteID int) error {
+	return nil
+}
+
+func (s *digestStorage) UnpinNote(noteID int) error {
+	return nil
+}
+
+func (s *digestStorage) GetNotesForDashboard() ([]entities.Note, error) {
+	return s.notes, nil
+}
+
+func TestBuildDigest(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, loc)
+	at := func(day, hour int) time.Time { return time.Date(2024, 3, day, hour, 0, 0, 0, loc) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	storage := &digestStorage{fakeStorage{notes: []entities.Note{
+		{ID: 1, Title: "Older", CreatedAt: at(1, 0).AddDate(0, 0, -3), LastEditedAt: at(1, 8)},
+		{ID: 2, Title: "Yesterday morning", CreatedAt: at(1, 0), LastEditedAt: at(1, 0), DueAt: ptr(at(1, 12))},
+		{ID: 3, Title: "Yesterday night", CreatedAt: at(1, 23), LastEditedAt: at(2, 8), PinnedAt: ptr(at(1, 23))},
+		{ID: 4, Title: "Today", CreatedAt: at(2, 7), LastEditedAt: at(2, 7), DueAt: ptr(at(2, 18))},
+		{ID: 5, Title: "Tomorrow's task", CreatedAt: at(2, 8), LastEditedAt: at(2, 8).Add(-time.Minute), DueAt: ptr(at(3, 9))},
+	}}}
+
+	d, err := buildDigest(storage, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if d.Date != "2024-03-02" {
+		t.Errorf("Expected digest for 2024-03-02, got %s", d.Date)
+	}
+	if d.CreatedYesterday != 2 {
+		t.Errorf("Expected 2 notes created yesterday, got %d", d.CreatedYesterday)
+	}
+	if len(d.Due) != 2 || d.Due[0].ID != 2 || d.Due[1].ID != 4 {
+		t.Errorf("Expected overdue note 2 and note 4 due today, got %+v", d.Due)
+	}
+	if len(d.Pinned) != 1 || d.Pinned[0].ID != 3 {
+		t.Errorf("Expected pinned note 3, got %+v", d.Pinned)
+	}
+	if d.LastEdited == nil || d.LastEdited.ID != 3 {
+		t.Errorf("Expected note 3 as the last edited, got %+v", d.LastEdited)
+	}
+
+	var buf bytes.Buffer
+	printDigest(&buf, idFormatDecimal, d)
+	for _, want := range []string{"Created yesterday: 2 note(s)", "Title: Today, Due:", "ID: 3, Title: Yesterday night"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected digest to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// dueDateLayouts lists accepted formats of due dates, interpreted in local time
+var dueDateLayouts = []string{"2006-01-02 15:04", time.DateOnly}
+
+// DueStorage is implemented by storages supporting due dates of notes
+type DueStorage interface {
+	// SetDueDate sets the time the note is due at, zero time removes the due date
+	SetDueDate(noteID int, due time.Time) error
+
+	// GetDueNotes retrieves notes due before the specified time
+	GetDueNotes(before time.Time) ([]entities.Note, error)
+}
+
+// dueCommand creates new CLI command for setting or removing a due date of a note
+func dueCommand(storage DueStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "due"
+		commandUsage = "Set a due date of a note (YYYY-MM-DD [HH:MM]) or remove it with --clear"
+	)
+
+	// create a new CLI command configuration
+	due := cli.Command{
+		Name:      commandName,  // name of command (e.g., "due")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID [date]",
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "clear", Usage: "remove the due date"},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note and a due date.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if c.Bool("clear") {
+				if err = storage.SetDueDate(noteID, time.Time{}); err != nil {
+					return fmt.Errorf("removing due date: %w", err)
+				}
+
+				fmt.Fprintf(c.App.Writer, "Removed due date of note with ID %s\n", noteIDFormat(c).Format(noteID))
+
+				return nil
+			}
+
+			dateStr := c.Args().Get(1)
+			if dateStr == "" {
+				return missingArg(c, "Please provide ID of note and a due date.")
+			}
+
+			due, err := parseDueDate(dateStr)
+			if err != nil {
+				return err
+			}
+
+			if err = storage.SetDueDate(noteID, due); err != nil {
+				return fmt.Errorf("setting due date: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Note with ID %s is due at %s\n", noteIDFormat(c).Format(noteID), due.Format(dueDateLayouts[0]))
+
+			return nil
+		},
+	}
+
+	return due
+}
+
+// parseDueDate parses a due date in local time, a date without time is due at its start
+func parseDueDate(s string) (time.Time, error) {
+	for _, layout := range dueDateLayouts {
+		if due, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return due, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid due date %q, expected YYYY-MM-DD or YYYY-MM-DD HH:MM", s)
+}
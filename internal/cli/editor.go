@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor is used when $EDITOR is not set
+const defaultEditor = "vi"
+
+// editContent opens content in $EDITOR and returns the edited text.
+// changed is false if the file was saved without modifications
+func editContent(content string) (edited string, changed bool, err error) {
+	file, err := os.CreateTemp("", "go-notes-*.md")
+	if err != nil {
+		return "", false, fmt.Errorf("creating temporary file: %w", err)
+	}
+	// ensure temporary file is removed when done editing
+	defer os.Remove(file.Name())
+
+	if _, err = file.WriteString(content); err != nil {
+		_ = file.Close()
+		return "", false, fmt.Errorf("writing temporary file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return "", false, fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	// $EDITOR may contain arguments, e.g. "code --wait"
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = cmd.Run(); err != nil {
+		return "", false, fmt.Errorf("running editor: %w", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", false, fmt.Errorf("reading edited file: %w", err)
+	}
+
+	edited = string(data)
+	if edited == "" {
+		return "", false, errors.New("edited content is empty")
+	}
+
+	return edited, edited != content, nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+// fakeStorage keeps notes in memory for testing commands
+type fakeStorage struct {
+	notes []entities.Note
+}
+
+func (s *fakeStorage) NewNote(noteTitle, content string) (int, error) {
+	id := len(s.notes) + 1
+	s.notes = append(s.notes, entities.Note{ID: id, Title: noteTitle, Content: content})
+
+	return id, nil
+}
+
+func (s *fakeStorage) DeleteNote(id int) (int, error) {
+	for i, note := range s.notes {
+		if note.ID == id {
+			s.notes = append(s.notes[:i], s.notes[i+1:]...)
+			return id, nil
+		}
+	}
+
+	return 0, sql.ErrNoRows
+}
+
+func (s *fakeStorage) SetNoteContent(noteID int, content string) error {
+	for i := range s.notes {
+		if s.notes[i].ID == noteID {
+			s.notes[i].Content = content
+			return nil
+		}
+	}
+
+	return sql.ErrNoRows
+}
+
+func (s *fakeStorage) SearchNotesByKeyword(keyword string) ([]entities.Note, error) {
+	var found []entities.Note
+	for _, note := range s.notes {
+		if strings.Contains(note.Title, keyword) || strings.Contains(note.Content, keyword) {
+			found = append(found, note)
+		}
+	}
+
+	return found, nil
+}
+
+func (s *fakeStorage) GetNoteByID(noteID int) (entities.Note, error) {
+	for _, note := range s.notes {
+		if note.ID == noteID {
+			return note, nil
+		}
+	}
+
+	return entities.Note{}, sql.ErrNoRows
+}
+
+func (s *fakeStorage) GetAllNotes() ([]entities.Note, error) {
+	return s.notes, nil
+}
+
+// fakeEditor sets $EDITOR to a script running the shell command on the edited file passed as $1
+func fakeEditor(t *testing.T, command string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor is a shell script")
+	}
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"+command+"\n"), 0o755); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Setenv("EDITOR", script)
+}
+
+func TestNewOpenSavesEditedContent(t *testing.T) {
+	fakeEditor(t, `printf ' and more' >> "$1"`)
+
+	storage := &fakeStorage{}
+	err := NewCLI(storage).Run([]string{"go-notes", "new", "--open", "Draft", "Some text"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(1)
+	if note.Content != "Some text and more" {
+		t.Errorf("Expected edited content, got %q", note.Content)
+	}
+}
+
+func TestNewOpenKeepsUnchangedNote(t *testing.T) {
+	fakeEditor(t, `true`)
+
+	storage := &fakeStorage{}
+	err := NewCLI(storage).Run([]string{"go-notes", "new", "--open", "Draft", "Some text"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(1)
+	if note.Content != "Some text" {
+		t.Errorf("Expected content to stay the same, got %q", note.Content)
+	}
+}
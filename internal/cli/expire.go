@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// ExpiryStorage is implemented by storages supporting notes which expire after some time
+type ExpiryStorage interface {
+	// NewNoteWithExpiry creates a new note which expires at the specified time and returns its ID
+	NewNoteWithExpiry(noteTitle, content string, expires time.Time) (int, error)
+
+	// SetExpiry sets the time the note expires at, zero time makes the note never expire
+	SetExpiry(noteID int, expires time.Time) error
+
+	// DeleteExpiredNotes deletes notes which expired by the specified time and returns their number
+	DeleteExpiredNotes(now time.Time) (int, error)
+}
+
+// ExpiredFilterStorage is implemented by storages hiding expired notes from all their read methods
+type ExpiredFilterStorage interface {
+	// SetIncludeExpired makes read methods return notes which have expired too
+	SetIncludeExpired(include bool)
+}
+
+// includeExpiredFlag makes read commands show notes which have already expired,
+// it's accepted both before the command and by commands showing notes
+var includeExpiredFlag = cli.BoolFlag{Name: "include-expired", Usage: "also show notes which have expired"}
+
+// includeExpired reports whether --include-expired is set for the command or globally
+func includeExpired(c *cli.Context) bool {
+	return c.Bool(includeExpiredFlag.Name) || c.GlobalBool(includeExpiredFlag.Name)
+}
+
+// includeExpiredBefore makes commands accepting --include-expired pass it on to the storage,
+// so expired notes it hides are shown when the flag is given after the command
+func includeExpiredBefore(commands []cli.Command, storage ExpiredFilterStorage) {
+	for i := range commands {
+		for _, flag := range commands[i].Flags {
+			if flag.GetName() != includeExpiredFlag.Name {
+				continue
+			}
+
+			commands[i].Before = func(c *cli.Context) error {
+				storage.SetIncludeExpired(includeExpired(c))
+				return nil
+			}
+		}
+	}
+}
+
+// expireCommand creates new CLI command for setting or removing the time a note expires at
+func expireCommand(storage ExpiryStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "expire"
+		commandUsage = "Make a note expire after a duration (e.g., 30m, 1h, 7d) or never expire with --clear"
+	)
+
+	// create a new CLI command configuration
+	expire := cli.Command{
+		Name:      commandName,  // name of command (e.g., "expire")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID [duration]",
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "clear", Usage: "make the note never expire"},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note and a duration.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if c.Bool("clear") {
+				if err = storage.SetExpiry(noteID, time.Time{}); err != nil {
+					return fmt.Errorf("removing expiry: %w", err)
+				}
+
+				fmt.Fprintf(c.App.Writer, "Note with ID %s never expires\n", noteIDFormat(c).Format(noteID))
+
+				return nil
+			}
+
+			ttlStr := c.Args().Get(1)
+			if ttlStr == "" {
+				return missingArg(c, "Please provide ID of note and a duration.")
+			}
+
+			ttl, err := parseTTL(ttlStr)
+			if err != nil {
+				return err
+			}
+
+			expires := time.Now().Add(ttl)
+			if err = storage.SetExpiry(noteID, expires); err != nil {
+				return fmt.Errorf("setting expiry: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Note with ID %s expires at %s\n", noteIDFormat(c).Format(noteID), expires.Format(dueDateLayouts[0]))
+
+			return nil
+		},
+	}
+
+	return expire
+}
+
+// expireNowCommand creates new CLI command for deleting notes which have expired
+func expireNowCommand(storage ExpiryStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "expire-now"
+		commandUsage = "Delete notes which have expired"
+	)
+
+	// create a new CLI command configuration
+	expireNow := cli.Command{
+		Name:  commandName,  // name of command (e.g., "expire-now")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			deleted, err := storage.DeleteExpiredNotes(time.Now())
+			if err != nil {
+				return fmt.Errorf("deleting expired notes: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Deleted %d expired note(s)\n", deleted)
+
+			return nil
+		},
+	}
+
+	return expireNow
+}
+
+// parseTTL parses a positive duration like time.ParseDuration does, additionally accepting days (e.g., "7d")
+func parseTTL(s string) (time.Duration, error) {
+	var (
+		ttl time.Duration
+		err error
+	)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		ttl = time.Duration(n) * 24 * time.Hour
+	} else {
+		ttl, err = time.ParseDuration(s)
+	}
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected e.g. 30m, 1h or 7d", s)
+	}
+
+	return ttl, nil
+}
+
+// hiddenAsExpired reports whether a note the storage didn't find is hidden by it because the note expired
+func hiddenAsExpired(storage Storage, noteID int) bool {
+	filter, ok := storage.(ExpiredFilterStorage)
+	if !ok {
+		return false
+	}
+
+	filter.SetIncludeExpired(true)
+	defer filter.SetIncludeExpired(false)
+
+	note, err := storage.GetNoteByID(noteID)
+
+	return err == nil && note.Expired(time.Now())
+}
+
+// hideExpired removes notes which have already expired, unless --include-expired is set
+func hideExpired(c *cli.Context, notes []entities.Note) []entities.Note {
+	if includeExpired(c) {
+		return notes
+	}
+
+	now := time.Now()
+	visible := notes[:0:0]
+	for _, note := range notes {
+		if !note.Expired(now) {
+			visible = append(visible, note)
+		}
+	}
+
+	return visible
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// expiryStorage adds expiring notes to fakeStorage
+type expiryStorage struct {
+	fakeStorage
+}
+
+func (s *expiryStorage) SetExpiry(noteID int, expires time.Time) error {
+	for i := range s.notes {
+		if s.notes[i].ID == noteID {
+			s.notes[i].ExpiresAt = &expires
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *expiryStorage) NewNoteWithExpiry(noteTitle, content string, expires time.Time) (int, error) {
+	id, _ := s.NewNote(noteTitle, content)
+	s.notes[len(s.notes)-1].ExpiresAt = &expires
+
+	return id, nil
+}
+
+func (s *expiryStorage) DeleteExpiredNotes(now time.Time) (int, error) {
+	return 0, nil
+}
+
+// filteringExpiryStorage hides expired notes unless they are requested and records the requests
+type filteringExpiryStorage struct {
+	expiryStorage
+	includeExpired []bool
+}
+
+func (s *filteringExpiryStorage) SetIncludeExpired(include bool) {
+	s.includeExpired = append(s.includeExpired, include)
+}
+
+func (s *filteringExpiryStorage) GetNoteByID(noteID int) (entities.Note, error) {
+	note, err := s.expiryStorage.GetNoteByID(noteID)
+	if n := len(s.includeExpired); err == nil && note.Expired(time.Now()) && (n == 0 || !s.includeExpired[n-1]) {
+		return entities.Note{}, sql.ErrNoRows
+	}
+
+	return note, err
+}
+
+func TestExpiredNotesHidden(t *testing.T) {
+	storage := &expiryStorage{}
+	expiredID, _ := storage.NewNote("One-time code", "123456")
+	_, _ = storage.NewNote("Groceries", "Milk and code.")
+	_ = storage.SetExpiry(expiredID, time.Now().Add(-time.Minute))
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	// expired notes are hidden by default
+	for _, args := range [][]string{{"list"}, {"search", "code"}} {
+		out.Reset()
+		if err := app.Run(append([]string{"go-notes"}, args...)); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", args, err)
+		}
+
+		if strings.Contains(out.String(), "One-time code") || !strings.Contains(out.String(), "Groceries") {
+			t.Errorf("Expected only the unexpired note for %v, got %q", args, out.String())
+		}
+	}
+	if err := app.Run([]string{"go-notes", "get", "1"}); err == nil {
+		t.Errorf("Expected error getting an expired note, got nil")
+	}
+
+	// and shown on request
+	for _, args := range [][]string{{"list", "--include-expired"}, {"search", "--include-expired", "code"}, {"get", "--include-expired", "1"}} {
+		out.Reset()
+		if err := app.Run(append([]string{"go-notes"}, args...)); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", args, err)
+		}
+
+		if !strings.Contains(out.String(), "One-time code") {
+			t.Errorf("Expected the expired note for %v, got %q", args, out.String())
+		}
+	}
+}
+
+func TestIncludeExpiredPassedToStorage(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"export"}, false},
+		{[]string{"--include-expired", "export"}, true},
+		{[]string{"list", "--include-expired"}, true},
+	}
+	for _, tt := range tests {
+		storage := &filteringExpiryStorage{}
+
+		app := NewCLI(storage)
+		app.Writer = &bytes.Buffer{}
+		if err := app.Run(append([]string{"go-notes", "--quiet"}, tt.args...)); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", tt.args, err)
+		}
+
+		if n := len(storage.includeExpired); n == 0 || storage.includeExpired[n-1] != tt.want {
+			t.Errorf("Expected storage to include expired notes %v for %v, got %v", tt.want, tt.args, storage.includeExpired)
+		}
+	}
+}
+
+func TestGetExpiredNoteHiddenByStorage(t *testing.T) {
+	storage := &filteringExpiryStorage{}
+	_, _ = storage.NewNoteWithExpiry("One-time code", "123456", time.Now().Add(-time.Minute))
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	// the storage doesn't find the note, but it's reported as expired rather than missing
+	err := app.Run([]string{"go-notes", "--quiet", "get", "1"})
+	if err == nil || !strings.Contains(err.Error(), "has expired, use --include-expired") {
+		t.Errorf("Expected the expired note hint, got %v", err)
+	}
+
+	// a missing note is still reported as missing
+	err = app.Run([]string{"go-notes", "--quiet", "get", "2"})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing note, got %v", err)
+	}
+
+	if err = app.Run([]string{"go-notes", "--quiet", "get", "--include-expired", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "One-time code") {
+		t.Errorf("Expected the expired note, got %q", out.String())
+	}
+}
+
+func TestNewNoteExpires(t *testing.T) {
+	storage := &expiryStorage{}
+
+	app := NewCLI(storage)
+	app.Writer = &bytes.Buffer{}
+
+	before := time.Now()
+	if err := app.Run([]string{"go-notes", "new", "--expires", "1h", "Code", "123456"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// the note is created together with its expiry
+	if len(storage.notes) != 1 {
+		t.Fatalf("Expected 1 note, got %d", len(storage.notes))
+	}
+	note := storage.notes[0]
+	if note.ExpiresAt == nil || note.ExpiresAt.Before(before.Add(time.Hour)) || note.ExpiresAt.After(time.Now().Add(time.Hour)) {
+		t.Errorf("Expected note to expire in an hour, got %v", note.ExpiresAt)
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"1h":  time.Hour,
+		"7d":  7 * 24 * time.Hour,
+	}
+	for s, want := range tests {
+		if got, err := parseTTL(s); err != nil || got != want {
+			t.Errorf("Expected %v for %q, got %v (%v)", want, s, got, err)
+		}
+	}
+
+	for _, s := range []string{"", "soon", "-1h", "0d"} {
+		if _, err := parseTTL(s); err == nil {
+			t.Errorf("Expected error for %q, got nil", s)
+		}
+	}
+}
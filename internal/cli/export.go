@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli"
+	"golang.org/x/term"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/export"
+)
+
+// export formats supported by exportCommand
+const (
+	formatJSON     = "json"
+	formatMarkdown = "md"
+)
+
+// RevisionStorage is implemented by storages keeping earlier contents of notes
+type RevisionStorage interface {
+	// GetRevisions retrieves earlier contents of the note, oldest first
+	GetRevisions(noteID int) ([]entities.Revision, error)
+}
+
+// exportCommand creates new CLI command for exporting all notes
+func exportCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "export"
+		commandUsage = "Export all notes as JSON or markdown"
+	)
+
+	// create a new CLI command configuration
+	exportNotes := cli.Command{
+		Name:  commandName,  // name of command (e.g., "export")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "format", Value: formatJSON, Usage: "export format: json or md"},
+			cli.BoolFlag{Name: "single", Usage: "write all notes into a single markdown document with a table of contents"},
+			cli.StringFlag{Name: "out", Usage: "file to write to, standard output by default"},
+			cli.StringFlag{Name: "dir", Usage: "write every note into its own markdown file with front-matter in this directory"},
+			cli.BoolFlag{Name: "by-tag", Usage: "with --dir, write a markdown file per tag holding all notes having the tag"},
+			cli.BoolFlag{Name: "with-history", Usage: "include earlier contents of every note in JSON export as revisions"},
+		},
+		Action: func(c *cli.Context) error {
+			// revisions are written only by the JSON format
+			if c.Bool("with-history") && (c.String("dir") != "" || c.String("format") != formatJSON) {
+				return fmt.Errorf("history can be exported only in %s format", formatJSON)
+			}
+
+			if c.Bool("by-tag") {
+				if c.String("dir") == "" {
+					return missingArg(c, "Please provide a directory to export notes by tag to with --dir.")
+				}
+
+				return exportByTag(c.App.Writer, storage, c.String("dir"))
+			}
+
+			// directory export always writes markdown files
+			if dir := c.String("dir"); dir != "" {
+				if c.IsSet("format") && c.String("format") != formatMarkdown {
+					return fmt.Errorf("directory export supports only %s format", formatMarkdown)
+				}
+
+				return exportDir(c.App.Writer, storage, dir)
+			}
+
+			format := c.String("format")
+			if format != formatJSON && format != formatMarkdown {
+				return fmt.Errorf("unknown export format: %s", format)
+			}
+			if format == formatMarkdown && !c.Bool("single") {
+				return missingArg(c, "Please provide --single or --dir to export notes as markdown.")
+			}
+
+			// call a function from 'storage' object to retrieve all notes
+			notes, err := storage.GetAllNotes()
+			if err != nil {
+				return fmt.Errorf("retrieving notes: %w", err)
+			}
+
+			if c.Bool("with-history") {
+				if err = addRevisions(storage, notes); err != nil {
+					return err
+				}
+			}
+
+			// write to a file if one is given, otherwise to standard output
+			var (
+				w        = c.App.Writer
+				progress func(written int)
+			)
+			if out := c.String("out"); out != "" {
+				file, err := os.Create(out)
+				if err != nil {
+					return fmt.Errorf("creating export file: %w", err)
+				}
+				// ensure file is closed when done writing
+				defer file.Close()
+
+				w = file
+
+				// progress goes to standard error, so it never mixes with the exported data
+				if errOut := errorWriter(c); !c.GlobalBool(quietFlag) && isTerminal(errOut) {
+					progress = exportProgress(errOut, len(notes))
+				}
+			}
+
+			if format == formatMarkdown {
+				err = export.WriteMarkdownDocument(w, "Notes", notes)
+			} else {
+				err = export.WriteJSONWithProgress(w, notes, progress)
+			}
+			if err != nil {
+				return fmt.Errorf("exporting notes: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	return exportNotes
+}
+
+// addRevisions fills revisions of notes from storage
+func addRevisions(storage Storage, notes []entities.Note) error {
+	revisionStorage, ok := storage.(RevisionStorage)
+	if !ok {
+		return errors.New("storage doesn't keep revisions")
+	}
+
+	for i := range notes {
+		revisions, err := revisionStorage.GetRevisions(notes[i].ID)
+		if err != nil {
+			return fmt.Errorf("retrieving revisions: %w", err)
+		}
+		notes[i].Revisions = revisions
+	}
+
+	return nil
+}
+
+// exportProgressInterval is the number of exported notes between updates of the progress indicator
+const exportProgressInterval = 100
+
+// isTerminal reports whether w is a terminal, progress indicators are printed only to terminals
+var isTerminal = func(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// exportProgress returns a function updating a progress indicator of exporting total notes on w
+// every exportProgressInterval notes, the line is finished after the last note
+func exportProgress(w io.Writer, total int) func(written int) {
+	return func(written int) {
+		if written%exportProgressInterval != 0 && written != total {
+			return
+		}
+
+		fmt.Fprintf(w, "\rexported %d/%d", written, total)
+		if written == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// exportHTMLCommand creates new CLI command for rendering a single note as HTML
+func exportHTMLCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "export-html"
+		commandUsage = "Render markdown content of a note as an HTML page"
+	)
+
+	// create a new CLI command configuration
+	exportHTML := cli.Command{
+		Name:      commandName,  // name of command (e.g., "export-html")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "out", Usage: "file to write to, standard output by default"},
+			cli.BoolFlag{Name: "fragment", Usage: "write only the rendered content without the document around it"},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note.")
+			if !ok || err != nil {
+				return err
+			}
+
+			note, err := storage.GetNoteByID(noteID)
+			if err != nil {
+				return fmt.Errorf("retrieving note: %w", err)
+			}
+
+			// write to a file if one is given, otherwise to standard output
+			w := c.App.Writer
+			if out := c.String("out"); out != "" {
+				file, err := os.Create(out)
+				if err != nil {
+					return fmt.Errorf("creating export file: %w", err)
+				}
+				// ensure file is closed when done writing
+				defer file.Close()
+
+				w = file
+			}
+
+			if err = export.WriteHTML(w, note, c.Bool("fragment")); err != nil {
+				return fmt.Errorf("exporting note: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	return exportHTML
+}
+
+// exportByTag writes a markdown file per tag with all notes having the tag into dir, reporting the result to w
+func exportByTag(w io.Writer, storage Storage, dir string) error {
+	tagStorage, ok := storage.(TagStorage)
+	if !ok {
+		return errors.New("storage doesn't support tags")
+	}
+
+	notes, err := storage.GetAllNotes()
+	if err != nil {
+		return fmt.Errorf("retrieving notes: %w", err)
+	}
+
+	tags := make(map[int][]string, len(notes))
+	for _, note := range notes {
+		tags[note.ID], err = tagStorage.GetNoteTags(note.ID)
+		if err != nil {
+			return fmt.Errorf("retrieving tags: %w", err)
+		}
+	}
+
+	written, err := export.WriteTagDir(dir, notes, tags)
+	if err != nil {
+		return fmt.Errorf("exporting notes: %w", err)
+	}
+
+	fmt.Fprintf(w, "Exported %d note(s) into %d file(s) in %s\n", len(notes), len(written), dir)
+
+	return nil
+}
+
+// exportDir writes every note into its own markdown file in dir, including tags if storage supports them,
+// reporting the result to w
+func exportDir(w io.Writer, storage Storage, dir string) error {
+	// call a function from 'storage' object to retrieve all notes
+	notes, err := storage.GetAllNotes()
+	if err != nil {
+		return fmt.Errorf("retrieving notes: %w", err)
+	}
+
+	// collect tags of every note for front-matter
+	tags := make(map[int][]string)
+	if tagStorage, ok := storage.(TagStorage); ok {
+		for _, note := range notes {
+			tags[note.ID], err = tagStorage.GetNoteTags(note.ID)
+			if err != nil {
+				return fmt.Errorf("retrieving tags: %w", err)
+			}
+		}
+	}
+
+	err = export.WriteDir(dir, notes, tags)
+	if err != nil {
+		return fmt.Errorf("exporting notes: %w", err)
+	}
+
+	fmt.Fprintf(w, "Exported %d note(s) to %s\n", len(notes), dir)
+
+	return nil
+}
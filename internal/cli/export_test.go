@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/export"
+)
+
+// stubTerminal makes isTerminal report the given answer for the duration of the test
+func stubTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+
+	original := isTerminal
+	isTerminal = func(w io.Writer) bool { return terminal }
+	t.Cleanup(func() { isTerminal = original })
+}
+
+func TestExportProgress(t *testing.T) {
+	storage := &fakeStorage{}
+	for i := 1; i <= 250; i++ {
+		_, _ = storage.NewNote(fmt.Sprintf("Note %d", i), "content")
+	}
+	out := filepath.Join(t.TempDir(), "notes.json")
+
+	tests := []struct {
+		name     string
+		terminal bool
+		args     []string
+		progress bool
+	}{
+		{"terminal", true, []string{"go-notes", "export", "--out", out}, true},
+		{"not a terminal", false, []string{"go-notes", "export", "--out", out}, false},
+		{"quiet", true, []string{"go-notes", "--quiet", "export", "--out", out}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubTerminal(t, tt.terminal)
+
+			var stdout, stderr bytes.Buffer
+			app := NewCLI(storage)
+			app.Writer, app.ErrWriter = &stdout, &stderr
+
+			if err := app.Run(tt.args); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			for _, line := range []string{"\rexported 100/250", "\rexported 200/250", "\rexported 250/250\n"} {
+				if strings.Contains(stderr.String(), line) != tt.progress {
+					t.Errorf("Expected progress %q printed: %v, got %q", line, tt.progress, stderr.String())
+				}
+			}
+
+			// the exported file is the same with and without progress
+			var expected bytes.Buffer
+			_ = export.WriteJSON(&expected, storage.notes)
+			data, _ := os.ReadFile(out)
+			if string(data) != expected.String() {
+				t.Errorf("Expected exported JSON:\n%s\ngot:\n%s", expected.String(), data)
+			}
+		})
+	}
+}
+
+// historyStorage keeps revisions of notes created by import and exports them
+type historyStorage struct {
+	fakeStorage
+	revisions map[int][]entities.Revision
+}
+
+func (s *historyStorage) GetRevisions(noteID int) ([]entities.Revision, error) {
+	return s.revisions[noteID], nil
+}
+
+func (s *historyStorage) CreateNote(note entities.Note, _ []string) (int, error) {
+	id, err := s.NewNote(note.Title, note.Content)
+	s.revisions[id] = note.Revisions
+
+	return id, err
+}
+
+func TestExportWithHistoryRoundTrip(t *testing.T) {
+	edited := time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC)
+	revisions := []entities.Revision{{Content: "draft", EditedAt: edited}, {Content: "second draft", EditedAt: edited.Add(time.Hour)}}
+	source := &historyStorage{revisions: map[int][]entities.Revision{2: revisions}}
+	_, _ = source.NewNote("Unedited", "as written")
+	_, _ = source.NewNote("Edited", "final")
+
+	out := filepath.Join(t.TempDir(), "notes.json")
+	app := NewCLI(source)
+	app.Writer = &bytes.Buffer{}
+
+	// without the flag, the export stays as it was
+	if err := app.Run([]string{"go-notes", "export", "--out", out}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data, _ := os.ReadFile(out); strings.Contains(string(data), "revisions") {
+		t.Errorf("Expected no revisions without --with-history, got:\n%s", data)
+	}
+
+	if err := app.Run([]string{"go-notes", "export", "--with-history", "--out", out}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	dest := &historyStorage{revisions: map[int][]entities.Revision{}}
+	app = NewCLI(dest)
+	app.Writer = &bytes.Buffer{}
+	if err := app.Run([]string{"go-notes", "import", "--json", out}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(dest.notes) != 2 || dest.notes[1].Content != "final" {
+		t.Fatalf("Expected both notes imported, got %+v", dest.notes)
+	}
+	if len(dest.revisions[1]) != 0 {
+		t.Errorf("Expected no revisions of the unedited note, got %+v", dest.revisions[1])
+	}
+	if !reflect.DeepEqual(dest.revisions[2], revisions) {
+		t.Errorf("Expected revisions %+v, got %+v", revisions, dest.revisions[2])
+	}
+}
+
+func TestExportWithHistoryNeedsJSON(t *testing.T) {
+	app := NewCLI(&historyStorage{})
+	app.Writer = &bytes.Buffer{}
+
+	if err := app.Run([]string{"go-notes", "export", "--with-history", "--format", "md", "--single"}); err == nil {
+		t.Error("Expected an error exporting history as markdown")
+	}
+	if err := app.Run([]string{"go-notes", "export", "--with-history", "--dir", t.TempDir()}); err == nil {
+		t.Error("Expected an error exporting history into a directory")
+	}
+}
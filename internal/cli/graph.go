@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/export"
+	"go-notes/internal/links"
+)
+
+// graph formats supported by graphCommand
+const formatDOT = "dot"
+
+// graphCommand creates new CLI command for exporting links between notes as a graph
+func graphCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "graph"
+		commandUsage = "Print notes and links between them ([[ID]] in content) as Graphviz DOT or JSON"
+	)
+
+	// create a new CLI command configuration
+	graph := cli.Command{
+		Name:  commandName,  // name of command (e.g., "graph")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "format", Value: formatDOT, Usage: "graph format: dot or json"},
+		},
+		Action: func(c *cli.Context) error {
+			format := c.String("format")
+			if format != formatDOT && format != formatJSON {
+				return fmt.Errorf("unknown graph format: %s", format)
+			}
+
+			// call a function from 'storage' object to retrieve all notes
+			notes, err := storage.GetAllNotes()
+			if err != nil {
+				return fmt.Errorf("retrieving notes: %w", err)
+			}
+
+			edges := links.Edges(notes)
+			if format == formatDOT {
+				err = export.WriteDOT(c.App.Writer, notes, edges)
+			} else {
+				err = export.WriteGraphJSON(c.App.Writer, notes, edges)
+			}
+			if err != nil {
+				return fmt.Errorf("writing graph: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	return graph
+}
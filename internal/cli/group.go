@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// list grouping modes supported by list --group-by
+const (
+	groupByTag      = "tag"
+	groupByCategory = "category"
+	groupByDay      = "day"
+)
+
+// categoryKey is the metadata key holding category of a note
+const categoryKey = "category"
+
+// noteGroup holds notes listed under a common header
+type noteGroup struct {
+	name  string
+	notes []entities.Note
+}
+
+// groupByLabels groups notes by their labels, e.g. tags, sorted by name.
+// A note with several labels is listed in each of them, notes without labels are grouped under none at the end
+func groupByLabels(notes []entities.Note, labels map[int][]string, none string) []noteGroup {
+	byLabel := make(map[string][]entities.Note)
+	var unlabeled []entities.Note
+
+	for _, note := range notes {
+		if len(labels[note.ID]) == 0 {
+			unlabeled = append(unlabeled, note)
+			continue
+		}
+
+		for _, label := range labels[note.ID] {
+			byLabel[label] = append(byLabel[label], note)
+		}
+	}
+
+	names := make([]string, 0, len(byLabel))
+	for name := range byLabel {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]noteGroup, 0, len(names)+1)
+	for _, name := range names {
+		groups = append(groups, noteGroup{name: name, notes: byLabel[name]})
+	}
+	if len(unlabeled) > 0 {
+		groups = append(groups, noteGroup{name: none, notes: unlabeled})
+	}
+
+	return groups
+}
+
+// groupByCreationDay groups notes by the calendar day they were created on in loc, the newest day first
+func groupByCreationDay(notes []entities.Note, loc *time.Location) []noteGroup {
+	labels := make(map[int][]string, len(notes))
+	for _, note := range notes {
+		labels[note.ID] = []string{note.CreatedAt.In(loc).Format(time.DateOnly)}
+	}
+
+	// ISO dates sort chronologically, so reversing alphabetical order puts the newest day first
+	groups := groupByLabels(notes, labels, "")
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].name > groups[j].name })
+
+	return groups
+}
+
+// groupNotes groups notes by mode, fetching tags or categories from storage if needed
+func groupNotes(storage Storage, notes []entities.Note, mode string) ([]noteGroup, error) {
+	switch mode {
+	case groupByDay:
+		return groupByCreationDay(notes, time.Local), nil
+	case groupByTag:
+		tagStorage, ok := storage.(TagStorage)
+		if !ok {
+			return nil, errors.New("storage doesn't support tags")
+		}
+
+		tags := make(map[int][]string, len(notes))
+		for _, note := range notes {
+			noteTags, err := tagStorage.GetNoteTags(note.ID)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving tags: %w", err)
+			}
+			tags[note.ID] = noteTags
+		}
+
+		return groupByLabels(notes, tags, "(untagged)"), nil
+	case groupByCategory:
+		metaStorage, ok := storage.(MetaStorage)
+		if !ok {
+			return nil, errors.New("storage doesn't support note metadata")
+		}
+
+		categories := make(map[int][]string, len(notes))
+		for _, note := range notes {
+			meta, err := metaStorage.GetMeta(note.ID)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving metadata: %w", err)
+			}
+			if category := meta[categoryKey]; category != "" {
+				categories[note.ID] = []string{category}
+			}
+		}
+
+		return groupByLabels(notes, categories, "(uncategorized)"), nil
+	default:
+		return nil, fmt.Errorf("unknown grouping: %s", mode)
+	}
+}
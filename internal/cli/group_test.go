@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestGroupByCreationDay(t *testing.T) {
+	// fixed zone where UTC evening is already the next day
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	notes := []entities.Note{
+		{ID: 1, CreatedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, CreatedAt: time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC)},
+		{ID: 3, CreatedAt: time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: 4, CreatedAt: time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC)},
+	}
+
+	groups := groupByCreationDay(notes, loc)
+
+	want := []struct {
+		name string
+		ids  []int
+	}{
+		{"2024-03-02", []int{2, 3}},
+		{"2024-03-01", []int{1}},
+		{"2024-02-28", []int{4}},
+	}
+
+	if len(groups) != len(want) {
+		t.Fatalf("Expected %d groups, got %+v", len(want), groups)
+	}
+	for i, w := range want {
+		if groups[i].name != w.name || len(groups[i].notes) != len(w.ids) {
+			t.Errorf("Expected group %s with notes %v, got %+v", w.name, w.ids, groups[i])
+			continue
+		}
+		for j, id := range w.ids {
+			if groups[i].notes[j].ID != id {
+				t.Errorf("Expected note %d in group %s, got %d", id, w.name, groups[i].notes[j].ID)
+			}
+		}
+	}
+}
+
+func TestGroupByLabels(t *testing.T) {
+	notes := []entities.Note{{ID: 1}, {ID: 2}, {ID: 3}}
+	labels := map[int][]string{1: {"work", "home"}, 2: {"work"}}
+
+	groups := groupByLabels(notes, labels, "(untagged)")
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %+v", groups)
+	}
+	if groups[0].name != "home" || len(groups[0].notes) != 1 {
+		t.Errorf("Expected 'home' group with 1 note, got %+v", groups[0])
+	}
+	if groups[1].name != "work" || len(groups[1].notes) != 2 {
+		t.Errorf("Expected 'work' group with 2 notes, got %+v", groups[1])
+	}
+	if groups[2].name != "(untagged)" || groups[2].notes[0].ID != 3 {
+		t.Errorf("Expected untagged note 3 last, got %+v", groups[2])
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// defaultHeatmapWeeks is the default number of weeks shown by heatmap
+const defaultHeatmapWeeks = 12
+
+// heatmapLevels are cells of days from no notes to the most notes a day
+const heatmapLevels = ".-+*#"
+
+// DailyCountStorage is implemented by storages able to count notes created per day
+type DailyCountStorage interface {
+	// GetDailyCounts returns numbers of notes created on every local day in the range keyed by YYYY-MM-DD
+	GetDailyCounts(from, to time.Time) (map[string]int, error)
+}
+
+// heatmapCommand creates new CLI command for printing numbers of notes created per day as a calendar
+func heatmapCommand(storage DailyCountStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "heatmap"
+		commandUsage = "Print a calendar of the number of notes created per day"
+	)
+
+	// create a new CLI command configuration
+	heatmap := cli.Command{
+		Name:  commandName,  // name of command (e.g., "heatmap")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "weeks",
+				Value: defaultHeatmapWeeks,
+				Usage: "number of weeks to show, ending with the current one",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			weeks := c.Int("weeks")
+			if weeks < 1 {
+				return fmt.Errorf("invalid number of weeks: %d", weeks)
+			}
+
+			// weeks start on Sunday, so every column of the calendar is a whole week
+			end := time.Now()
+			start := end.AddDate(0, 0, -int(end.Weekday())-7*(weeks-1))
+
+			counts, err := storage.GetDailyCounts(start, end)
+			if err != nil {
+				return fmt.Errorf("counting notes: %w", err)
+			}
+
+			renderHeatmap(c.App.Writer, counts, start, end)
+
+			return nil
+		},
+	}
+
+	return heatmap
+}
+
+// renderHeatmap prints counts of days from start to end as a calendar with a row per day of the week
+// and a column per week starting on Sunday. Every day is a cell of heatmapLevels scaled to the busiest day
+func renderHeatmap(w io.Writer, counts map[string]int, start, end time.Time) {
+	start = time.Date(start.Year(), start.Month(), start.Day()-int(start.Weekday()), 0, 0, 0, 0, start.Location())
+	last := end.Format(time.DateOnly)
+	weeks := int(end.Sub(start).Hours()/24)/7 + 1
+
+	busiest, total := 0, 0
+	for _, count := range counts {
+		busiest = max(busiest, count)
+		total += count
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		var sb strings.Builder
+		sb.WriteString(weekday.String()[:3])
+
+		for week := 0; week < weeks; week++ {
+			// days of the current week after the end are left blank
+			day := start.AddDate(0, 0, 7*week+int(weekday)).Format(time.DateOnly)
+			if day > last {
+				break
+			}
+
+			sb.WriteByte(' ')
+			sb.WriteByte(heatmapLevel(counts[day], busiest))
+		}
+
+		fmt.Fprintln(w, sb.String())
+	}
+
+	fmt.Fprintf(w, "\nLess %s More, %d note(s) from %s to %s\n",
+		strings.Join(strings.Split(heatmapLevels, ""), " "), total, start.Format(time.DateOnly), last)
+}
+
+// heatmapLevel returns the cell of a day with count notes, the busiest day gets the last level
+func heatmapLevel(count, busiest int) byte {
+	if count <= 0 || busiest <= 0 {
+		return heatmapLevels[0]
+	}
+
+	// busy days are spread over the non-empty levels, rounding up so a single note is never shown as none
+	steps := len(heatmapLevels) - 1
+
+	return heatmapLevels[(count*steps+busiest-1)/busiest]
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	// Wednesday of the second week, the calendar starts on the Sunday before start
+	start := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 13, 0, 0, 0, 0, time.Local)
+	counts := map[string]int{"2024-03-04": 1, "2024-03-06": 4, "2024-03-11": 2, "2024-03-13": 3}
+
+	var out bytes.Buffer
+	renderHeatmap(&out, counts, start, end)
+
+	expected := "Sun . .\n" +
+		"Mon - +\n" +
+		"Tue . .\n" +
+		"Wed # *\n" +
+		"Thu .\n" +
+		"Fri .\n" +
+		"Sat .\n" +
+		"\nLess . - + * # More, 10 note(s) from 2024-03-03 to 2024-03-13\n"
+	if out.String() != expected {
+		t.Errorf("Expected heatmap:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
+
+func TestHeatmapLevel(t *testing.T) {
+	tests := []struct {
+		count, busiest int
+		expected       byte
+	}{
+		{0, 0, '.'},
+		{0, 10, '.'},
+		{1, 10, '-'},
+		{5, 10, '+'},
+		{7, 10, '*'},
+		{10, 10, '#'},
+	}
+
+	for _, tt := range tests {
+		if level := heatmapLevel(tt.count, tt.busiest); level != tt.expected {
+			t.Errorf("Expected level %q for %d of %d, got %q", tt.expected, tt.count, tt.busiest, level)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/urfave/cli"
+)
+
+// hookNoteIDEnv names the environment variable passing ID of the saved note to the post-save hook
+const hookNoteIDEnv = "GO_NOTES_NOTE_ID"
+
+// runHook runs a shell command of a hook for the note, tests replace it with a stub
+var runHook = defaultRunHook
+
+// defaultRunHook runs the command with sh passing the note ID in GO_NOTES_NOTE_ID and as the first argument,
+// e.g. "git -C ~/export commit -am \"note $1\""
+func defaultRunHook(command string, noteID int) error {
+	id := strconv.Itoa(noteID)
+
+	cmd := exec.Command("sh", "-c", command, "sh", id)
+	cmd.Env = append(os.Environ(), hookNoteIDEnv+"="+id)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+
+	return cmd.Run()
+}
+
+// runPostSaveHook runs the command of the hooks.post-save setting after the note was saved, if it's set.
+// The note is already stored, so a failing hook is only reported and doesn't undo the save or fail the command
+func runPostSaveHook(c *cli.Context, storage Storage, noteID int) {
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return
+	}
+
+	command, _, err := settings.GetSetting(configPostSaveHook)
+	if err == nil && command != "" {
+		err = runHook(command, noteID)
+	}
+	if err != nil {
+		fmt.Fprintf(errorWriter(c), "Warning: post-save hook failed for note with ID %s: %v\n", noteIDFormat(c).Format(noteID), err)
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPostSaveHook(t *testing.T) {
+	var calls []int
+	runHook = func(command string, noteID int) error {
+		if command != "sync-notes" {
+			t.Errorf("Expected the configured command, got %q", command)
+		}
+		calls = append(calls, noteID)
+		return nil
+	}
+	defer func() {
+		runHook = defaultRunHook
+	}()
+
+	storage := &settingsStorage{}
+	app := NewCLI(storage)
+	var errOut bytes.Buffer
+	app.ErrWriter = &errOut
+
+	// nothing runs until a hook is configured
+	_ = app.Run([]string{"go-notes", "new", "First", "Content"})
+	if len(calls) != 0 {
+		t.Errorf("Expected no hook calls, got %v", calls)
+	}
+
+	_ = storage.SetSetting(configPostSaveHook, "sync-notes")
+	_ = app.Run([]string{"go-notes", "new", "Second", "Content"})
+	_ = app.Run([]string{"go-notes", "update", "1", "Changed"})
+	if expected := []int{2, 1}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected hook calls for notes %v, got %v", expected, calls)
+	}
+
+	// a failing hook is reported, but the note stays saved
+	runHook = func(string, int) error { return errors.New("exit status 1") }
+	if err := app.Run([]string{"go-notes", "update", "2", "Saved anyway"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if note, _ := storage.GetNoteByID(2); note.Content != "Saved anyway" {
+		t.Errorf("Expected the update to be kept, got %q", note.Content)
+	}
+	if !strings.Contains(errOut.String(), "post-save hook failed for note with ID 2") {
+		t.Errorf("Expected a warning, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// formats of note IDs shown and accepted by commands, storage is always keyed by integer IDs
+const (
+	idFormatDecimal idFormat = "decimal" // plain integers, e.g. 42
+	idFormatBase36  idFormat = "base36"  // upper-case base 36, e.g. 16 for 42 and AB for 371
+	idFormatPadded  idFormat = "padded"  // zero-padded integers, e.g. 000042
+)
+
+// paddedIDWidth is the number of digits IDs are zero-padded to in the padded format
+const paddedIDWidth = 6
+
+// idFormatFlag selects the global format of note IDs
+var idFormatFlag = cli.StringFlag{
+	Name:  "id-format",
+	Value: string(idFormatDecimal),
+	Usage: "format note IDs are shown and accepted in: decimal, base36 or padded",
+}
+
+// idFormat is a presentation of integer note IDs
+type idFormat string
+
+// noteIDFormat returns the format given with the global --id-format flag
+func noteIDFormat(c *cli.Context) idFormat {
+	return idFormat(c.GlobalString(idFormatFlag.Name))
+}
+
+// validate rejects unknown formats
+func (f idFormat) validate() error {
+	switch f {
+	case idFormatDecimal, idFormatBase36, idFormatPadded:
+		return nil
+	default:
+		return fmt.Errorf("unknown ID format: %s", f)
+	}
+}
+
+// Format returns the note ID in the format
+func (f idFormat) Format(id int) string {
+	switch f {
+	case idFormatBase36:
+		return strings.ToUpper(strconv.FormatInt(int64(id), 36))
+	case idFormatPadded:
+		return fmt.Sprintf("%0*d", paddedIDWidth, id)
+	default:
+		return strconv.Itoa(id)
+	}
+}
+
+// Parse decodes a note ID given in the format, base 36 IDs are accepted in any case
+func (f idFormat) Parse(s string) (int, error) {
+	if f != idFormatBase36 {
+		// padded IDs are decimal integers with leading zeros
+		return strconv.Atoi(s)
+	}
+
+	id, err := strconv.ParseInt(s, 36, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
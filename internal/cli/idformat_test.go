@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestIDFormatRoundTrip(t *testing.T) {
+	tests := []struct {
+		format   idFormat
+		id       int
+		expected string
+	}{
+		{idFormatDecimal, 1, "1"},
+		{idFormatDecimal, 371, "371"},
+		{idFormatBase36, 1, "1"},
+		{idFormatBase36, 35, "Z"},
+		{idFormatBase36, 36, "10"},
+		{idFormatBase36, 371, "AB"},
+		{idFormatBase36, 2147483647, "ZIK0ZJ"},
+		{idFormatPadded, 42, "000042"},
+		{idFormatPadded, 1234567, "1234567"},
+	}
+
+	for _, tt := range tests {
+		formatted := tt.format.Format(tt.id)
+		if formatted != tt.expected {
+			t.Errorf("Expected %d in %s format to be %q, got %q", tt.id, tt.format, tt.expected, formatted)
+		}
+
+		id, err := tt.format.Parse(formatted)
+		if err != nil || id != tt.id {
+			t.Errorf("Expected %q in %s format to decode to %d, got %d (%v)", formatted, tt.format, tt.id, id, err)
+		}
+	}
+
+	// base 36 IDs are accepted in lower case too
+	if id, _ := idFormatBase36.Parse("ab"); id != 371 {
+		t.Errorf("Expected ab to decode to 371, got %d", id)
+	}
+	if _, err := idFormatBase36.Parse("A-B"); err == nil {
+		t.Errorf("Expected an error for an invalid base 36 ID")
+	}
+}
+
+func TestGetByBase36ID(t *testing.T) {
+	storage := &fakeStorage{}
+	for i := 0; i < 371; i++ {
+		_, _ = storage.NewNote("Note", "Content")
+	}
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "--id-format", "base36", "get", "AB"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "Note ID: AB\n") {
+		t.Errorf("Expected note 371 shown as AB, got %q", out.String())
+	}
+
+	if err := app.Run([]string{"go-notes", "--id-format", "hex", "get", "AB"}); err == nil {
+		t.Errorf("Expected an error for an unknown ID format")
+	}
+}
+
+func TestCommandsReportFormattedIDs(t *testing.T) {
+	storage := &fakeStorage{}
+	for i := 0; i < 371; i++ {
+		_, _ = storage.NewNote("Note", "- [ ] task")
+	}
+
+	app := NewCLI(storage)
+	var out, errOut bytes.Buffer
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	if err := app.Run([]string{"go-notes", "--id-format", "base36", "check", "--line", "1", "AB"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out.String() != "Checked line 1 of note with ID AB\n" {
+		t.Errorf("Expected note 371 reported as AB, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"go-notes", "--id-format", "padded", "progress"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "ID: 000371, Title: Note, Done: 1/1\n") {
+		t.Errorf("Expected padded IDs in progress, got %q", out.String())
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// IDStorage is implemented by storages able to reassign note IDs
+type IDStorage interface {
+	// ChangeNoteID moves the note to a new ID together with related data
+	ChangeNoteID(oldID, newID int) error
+}
+
+// changeIDCommand creates new CLI command for reassigning ID of a note
+func changeIDCommand(storage IDStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "change-id"
+		commandUsage = "Move a note to a new, unused ID, rewriting links to it"
+	)
+
+	// create a new CLI command configuration
+	changeID := cli.Command{
+		Name:      commandName,  // name of command (e.g., "change-id")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "oldID newID",
+		Action: func(c *cli.Context) error {
+			oldIDStr, newIDStr := c.Args().Get(0), c.Args().Get(1)
+			if oldIDStr == "" || newIDStr == "" {
+				return missingArg(c, "Please provide current and new ID of note.")
+			}
+
+			// convert note ID strings to integers
+			oldID, err := noteIDFormat(c).Parse(oldIDStr)
+			if err != nil {
+				return fmt.Errorf("invalid note ID: %w", err)
+			}
+			newID, err := noteIDFormat(c).Parse(newIDStr)
+			if err != nil {
+				return fmt.Errorf("invalid note ID: %w", err)
+			}
+
+			if err = storage.ChangeNoteID(oldID, newID); err != nil {
+				return fmt.Errorf("changing note ID: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Moved note with ID %s to ID %s\n", noteIDFormat(c).Format(oldID), noteIDFormat(c).Format(newID))
+
+			return nil
+		},
+	}
+
+	return changeID
+}
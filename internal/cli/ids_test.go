@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIDsOnly(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+	_, _ = storage.NewNote("Meeting", "Project deadline.")
+	_, _ = storage.NewNote("Bakery", "Fresh bread.")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"go-notes", "list", "--ids-only"}, "1\n2\n3\n"},
+		{[]string{"go-notes", "search", "--ids-only", "bread"}, "1\n3\n"},
+		// no message is printed when nothing matches
+		{[]string{"go-notes", "search", "--ids-only", "nothing"}, ""},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		app := NewCLI(storage)
+		app.Writer = &out
+
+		if err := app.Run(tt.args); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", tt.args, err)
+		}
+
+		if out.String() != tt.want {
+			t.Errorf("Expected output %q for %v, got %q", tt.want, tt.args, out.String())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/importer"
+)
+
+// ImportStorage is implemented by storages able to create notes with existing metadata
+type ImportStorage interface {
+	// CreateNote inserts a note with a new ID keeping its creation time and attaches tags to it.
+	// Storages keeping revisions store revisions of the note too
+	CreateNote(note entities.Note, tags []string) (int, error)
+}
+
+// importCommand creates new CLI command for importing notes from markdown files
+func importCommand(storage ImportStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "import"
+		commandUsage = "Import notes from markdown files with optional front-matter, from plain text files or from a JSON export"
+	)
+
+	// create a new CLI command configuration
+	importNotes := cli.Command{
+		Name:  commandName,  // name of command (e.g., "import")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "dir", Usage: "directory with markdown files, one note per file"},
+			cli.StringFlag{Name: "txt-dir", Usage: "directory with .txt files, one note per file titled by the file name"},
+			cli.StringFlag{Name: "json", Usage: "file written by export in JSON format, restoring revisions exported with --with-history"},
+		},
+		Action: func(c *cli.Context) error {
+			dir, txtDir, jsonFile := c.String("dir"), c.String("txt-dir"), c.String("json")
+			sources := 0
+			for _, source := range []string{dir, txtDir, jsonFile} {
+				if source != "" {
+					sources++
+				}
+			}
+			if sources > 1 {
+				return errors.New("--dir, --txt-dir and --json can't be combined")
+			}
+			if sources == 0 {
+				return missingArg(c, "Please provide a directory to import with --dir or --txt-dir, or an export with --json.")
+			}
+
+			// read all files first, unreadable ones are reported without stopping the import
+			var (
+				files []importer.File
+				errs  []error
+			)
+			switch {
+			case jsonFile != "":
+				var err error
+				if files, err = importer.ReadJSON(jsonFile); err != nil {
+					return fmt.Errorf("reading export: %w", err)
+				}
+			case txtDir != "":
+				var skipped []error
+				files, skipped, errs = importer.ReadTextDir(txtDir)
+				for _, err := range skipped {
+					fmt.Fprintf(c.App.Writer, "Warning: skipped %v\n", err)
+				}
+			default:
+				files, errs = importer.ReadDir(dir)
+			}
+
+			var imported int
+			for _, file := range files {
+				id, err := storage.CreateNote(file.Note, file.Tags)
+				if err != nil {
+					errs = append(errs, &importer.FileError{Path: file.Path, Err: err})
+					continue
+				}
+
+				imported++
+				fmt.Fprintf(c.App.Writer, "Imported %s as note %s\n", file.Path, noteIDFormat(c).Format(id))
+			}
+
+			for _, err := range errs {
+				fmt.Fprintf(c.App.Writer, "Skipped %v\n", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Imported %d note(s), %d file(s) failed\n", imported, len(errs))
+
+			if len(errs) > 0 {
+				return errors.New("some files were not imported")
+			}
+
+			return nil
+		},
+	}
+
+	return importNotes
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// importStorage adds creating notes with metadata to fakeStorage, rejecting content longer than maxContentLength
+type importStorage struct {
+	fakeStorage
+	maxContentLength int
+}
+
+func (s *importStorage) CreateNote(note entities.Note, _ []string) (int, error) {
+	if len(note.Content) > s.maxContentLength {
+		return 0, &storage.ContentTooLongError{Length: len(note.Content), Max: s.maxContentLength}
+	}
+
+	return s.NewNote(note.Title, note.Content)
+}
+
+func TestImportTextDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"groceries.txt": "Milk\r\nBread\n",
+		"Ideas.TXT":     "Write more tests",
+		"huge.txt":      strings.Repeat("a", 100),
+		"photo.jpg":     "\xff\xd8\xff",
+		"binary.txt":    "\x00\x01\x02",
+	}
+	for name, content := range files {
+		_ = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+	}
+
+	storage := &importStorage{maxContentLength: 50}
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	// the huge file fails the import, but doesn't stop other files
+	if err := app.Run([]string{"go-notes", "import", "--txt-dir", dir}); err == nil {
+		t.Errorf("Expected an error for the file over the content limit")
+	}
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 imported notes, got %+v", notes)
+	}
+	if notes[0].Title != "Ideas" || notes[0].Content != "Write more tests" {
+		t.Errorf("Expected Ideas note, got %+v", notes[0])
+	}
+	if notes[1].Title != "groceries" || notes[1].Content != "Milk\nBread" {
+		t.Errorf("Expected groceries note, got %+v", notes[1])
+	}
+
+	for _, want := range []string{
+		"Warning: skipped " + filepath.Join(dir, "binary.txt") + ": not a text file",
+		"Warning: skipped " + filepath.Join(dir, "photo.jpg") + ": not a text file",
+		"Skipped " + filepath.Join(dir, "huge.txt") + ": content too long: 100 bytes, maximum allowed is 50",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+// infoTimeLayout formats absolute times printed by info
+const infoTimeLayout = "2006-01-02 15:04"
+
+// relativeUnits are units of relative times from the largest, months and years are approximate
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+}
+
+// relativeTime describes t relative to now in the largest fitting unit, e.g. "3 days ago" for past times,
+// "in 2 hours" for future times and "just now" for times within a minute of now
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	for _, unit := range relativeUnits {
+		count := int(d / unit.size)
+		if count == 0 {
+			continue
+		}
+
+		amount := fmt.Sprintf("%d %s", count, unit.name)
+		if count != 1 {
+			amount += "s"
+		}
+		if future {
+			return "in " + amount
+		}
+		return amount + " ago"
+	}
+
+	return "just now"
+}
+
+// infoCommand creates new CLI command for showing how old a note is and when it was last edited
+func infoCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "info"
+		commandUsage = "Show when a note was created, last edited and is due, relative to now"
+	)
+
+	// create a new CLI command configuration
+	info := cli.Command{
+		Name:      commandName,  // name of command (e.g., "info")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note.")
+			if !ok || err != nil {
+				return err
+			}
+
+			note, err := storage.GetNoteByID(noteID)
+			if err != nil {
+				return fmt.Errorf("retrieving note: %w", err)
+			}
+
+			now := time.Now()
+			describe := func(t time.Time) string {
+				return fmt.Sprintf("%s (%s)", t.Local().Format(infoTimeLayout), relativeTime(t, now))
+			}
+
+			fmt.Fprintf(c.App.Writer, "Note ID: %s\nTitle: %s\nCreated: %s\nLast edited: %s\n",
+				noteIDFormat(c).Format(note.ID), note.Title, describe(note.CreatedAt), describe(note.LastEditedAt))
+			if note.DueAt != nil {
+				fmt.Fprintf(c.App.Writer, "Due: %s\n", describe(*note.DueAt))
+			}
+
+			return nil
+		},
+	}
+
+	return info
+}
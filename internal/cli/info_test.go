@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{now, "just now"},
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(20 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-2 * time.Hour), "2 hours ago"},
+		{now.AddDate(0, 0, -3), "3 days ago"},
+		{now.AddDate(0, 0, -15), "2 weeks ago"},
+		{now.AddDate(0, -2, 0), "2 months ago"},
+		{now.AddDate(-1, 0, 0), "1 year ago"},
+		{now.Add(90 * time.Minute), "in 1 hour"},
+		{now.AddDate(0, 0, 1), "in 1 day"},
+		{now.AddDate(0, 0, 10), "in 1 week"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeTime(tt.t, now); got != tt.expected {
+			t.Errorf("Expected %q for %s, got %q", tt.expected, tt.t.Sub(now), got)
+		}
+	}
+}
+
+func TestInfo(t *testing.T) {
+	now := time.Now()
+	due := now.AddDate(0, 0, 2).Add(time.Hour)
+	storage := &fakeStorage{notes: []entities.Note{
+		{ID: 1, Title: "Note", CreatedAt: now.AddDate(0, 0, -3), LastEditedAt: now.Add(-2 * time.Hour), DueAt: &due},
+	}}
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "info", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, expected := range []string{
+		"Title: Note",
+		"Created: " + now.AddDate(0, 0, -3).Format(infoTimeLayout) + " (3 days ago)",
+		"Last edited: " + now.Add(-2*time.Hour).Format(infoTimeLayout) + " (2 hours ago)",
+		"Due: " + due.Format(infoTimeLayout) + " (in 2 days)",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in %q", expected, out.String())
+		}
+	}
+}
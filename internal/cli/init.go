@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// CompressionStorage is implemented by storages able to compress note content
+type CompressionStorage interface {
+	// SetCompression enables or disables compression of newly written content and remembers the choice
+	SetCompression(enabled bool) error
+}
+
+// initCommand creates new CLI command for changing persistent storage settings
+func initCommand(storage CompressionStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "init"
+		commandUsage = "Change storage settings, e.g. enable content compression"
+	)
+
+	// create a new CLI command configuration
+	initStorage := cli.Command{
+		Name:  commandName,  // name of command (e.g., "init")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "compress", Usage: "compress large contents of notes written from now on"},
+			cli.BoolFlag{Name: "no-compress", Usage: "store contents of notes written from now on uncompressed"},
+		},
+		Action: func(c *cli.Context) error {
+			compress, noCompress := c.Bool("compress"), c.Bool("no-compress")
+			if compress == noCompress {
+				return missingArg(c, "Please provide either --compress or --no-compress.")
+			}
+
+			if err := storage.SetCompression(compress); err != nil {
+				return fmt.Errorf("changing compression: %w", err)
+			}
+
+			if compress {
+				fmt.Fprintln(c.App.Writer, "Compression of note contents enabled")
+			} else {
+				fmt.Fprintln(c.App.Writer, "Compression of note contents disabled")
+			}
+
+			return nil
+		},
+	}
+
+	return initStorage
+}
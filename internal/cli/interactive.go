@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/term"
+	"golang.org/x/text/unicode/norm"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// searchDebounce is how long interactive search waits after the last keystroke before searching
+const searchDebounce = 150 * time.Millisecond
+
+// ANSI sequences used by interactive search
+const (
+	clearScreen    = "\x1b[H\x1b[2J"
+	highlightStart = "\x1b[7m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// filterResult holds notes found for a query
+type filterResult struct {
+	query string
+	notes []entities.Note
+	err   error
+}
+
+// liveFilter debounces query updates and searches only for the latest query.
+// Results of superseded queries are dropped
+type liveFilter struct {
+	search  func(keyword string) ([]entities.Note, error)
+	delay   time.Duration
+	results chan filterResult
+
+	// mu guards timer and seq
+	mu    sync.Mutex
+	timer *time.Timer
+	seq   int
+}
+
+// newLiveFilter creates a filter searching with the given function after delay of inactivity
+func newLiveFilter(search func(keyword string) ([]entities.Note, error), delay time.Duration) *liveFilter {
+	return &liveFilter{
+		search:  search,
+		delay:   delay,
+		results: make(chan filterResult, 1),
+	}
+}
+
+// Update schedules search for the query, cancelling the pending one
+func (f *liveFilter) Update(query string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	seq := f.seq
+
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	f.timer = time.AfterFunc(f.delay, func() { f.run(seq, query) })
+}
+
+// Results returns channel receiving results of the latest query
+func (f *liveFilter) Results() <-chan filterResult {
+	return f.results
+}
+
+// Stop cancels the pending search
+func (f *liveFilter) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+}
+
+// run searches for the query unless it was superseded by a newer one
+func (f *liveFilter) run(seq int, query string) {
+	if !f.isLatest(seq) {
+		return
+	}
+
+	// an empty query matches nothing instead of failing validation
+	result := filterResult{query: query}
+	if query != "" {
+		result.notes, result.err = f.search(query)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// query might have changed while searching
+	if seq != f.seq {
+		return
+	}
+
+	// replace a result which wasn't consumed yet
+	select {
+	case <-f.results:
+	default:
+	}
+	f.results <- result
+}
+
+// isLatest reports whether seq belongs to the latest query
+func (f *liveFilter) isLatest(seq int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return seq == f.seq
+}
+
+// highlight wraps occurrences of keyword in text with ANSI reverse video. Like search, text and keyword
+// are compared in NFC with only ASCII letters matched case-insensitively
+func highlight(text, keyword string) string {
+	keyword = storage.FoldCase(keyword)
+	if keyword == "" {
+		return text
+	}
+
+	// folding keeps byte offsets of NFC text, so matches found in folded text apply to it
+	text = norm.NFC.String(text)
+	folded := storage.FoldCase(text)
+
+	var sb strings.Builder
+	for {
+		i := strings.Index(folded, keyword)
+		if i < 0 {
+			sb.WriteString(text)
+			return sb.String()
+		}
+
+		end := i + len(keyword)
+		sb.WriteString(text[:i] + highlightStart + text[i:end] + highlightEnd)
+		text, folded = text[end:], folded[end:]
+	}
+}
+
+// runInteractiveSearch filters notes live while the query is typed in the terminal, showing IDs in the format,
+// hidden leaves content out of results. Enter, Esc or Ctrl-C leaves the search
+func runInteractiveSearch(search func(keyword string) ([]entities.Note, error), in *os.File, out io.Writer, query string,
+	ids idFormat, hidden bool) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return errors.New("interactive search requires a terminal")
+	}
+
+	// read keystrokes one by one without echo
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	// ensure terminal is restored when leaving the search
+	defer term.Restore(fd, state)
+
+	filter := newLiveFilter(search, searchDebounce)
+	defer filter.Stop()
+
+	keys := make(chan rune)
+	stop := make(chan struct{})
+	go readKeys(in, keys, stop)
+	defer stopReadingKeys(in, keys, stop)
+
+	current := filterResult{}
+	filter.Update(query)
+	renderSearch(out, ids, query, current, hidden)
+
+	for {
+		select {
+		case r, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			switch {
+			case r == '\r' || r == '\n' || r == 0x1b || r == 0x03:
+				fmt.Fprint(out, "\r\n")
+				return nil
+			case r == 0x7f || r == '\b':
+				if q := []rune(query); len(q) > 0 {
+					query = string(q[:len(q)-1])
+				}
+			case unicode.IsPrint(r):
+				query += string(r)
+			default:
+				continue
+			}
+
+			filter.Update(query)
+			renderSearch(out, ids, query, current, hidden)
+		case current = <-filter.Results():
+			renderSearch(out, ids, query, current, hidden)
+		}
+	}
+}
+
+// readKeys sends runes read from the terminal until it's closed or stop is closed
+func readKeys(in io.Reader, keys chan<- rune, stop <-chan struct{}) {
+	defer close(keys)
+
+	reader := bufio.NewReader(in)
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			return
+		}
+
+		select {
+		case keys <- r:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// stopReadingKeys stops readKeys, interrupting its pending read when the terminal supports deadlines
+func stopReadingKeys(in *os.File, keys <-chan rune, stop chan<- struct{}) {
+	close(stop)
+
+	// without deadlines the reader exits after the next keystroke
+	if err := in.SetReadDeadline(time.Now()); err != nil {
+		return
+	}
+	for range keys {
+	}
+
+	// later reads of the terminal must not time out
+	in.SetReadDeadline(time.Time{})
+}
+
+// renderSearch redraws the prompt and results of the last finished search with IDs in the format,
+// hidden leaves content out
+func renderSearch(out io.Writer, ids idFormat, query string, result filterResult, hidden bool) {
+	// terminal is in raw mode, so lines must end with carriage return too
+	var sb strings.Builder
+	sb.WriteString(clearScreen)
+	sb.WriteString("Search (Enter/Esc to quit): " + query + "\r\n\r\n")
+
+	switch {
+	case result.err != nil:
+		sb.WriteString("Error searching notes: " + result.err.Error() + "\r\n")
+	case result.query != "" && len(result.notes) == 0:
+		sb.WriteString("No notes found for keyword: " + result.query + "\r\n")
+	}
+
+	for _, note := range result.notes {
+		if hidden {
+			sb.WriteString(fmt.Sprintf("ID: %s, Title: %s\r\n", ids.Format(note.ID), highlight(note.Title, result.query)))
+			continue
+		}
+
+		content := strings.ReplaceAll(note.Content, "\n", " ")
+		sb.WriteString(fmt.Sprintf("ID: %s, Title: %s, Content: %s\r\n",
+			ids.Format(note.ID), highlight(note.Title, result.query), highlight(content, result.query)))
+	}
+
+	fmt.Fprint(out, sb.String())
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestLiveFilterDebounce(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	search := func(keyword string) ([]entities.Note, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		queries = append(queries, keyword)
+
+		return []entities.Note{{ID: 1, Title: keyword}}, nil
+	}
+
+	filter := newLiveFilter(search, 50*time.Millisecond)
+	defer filter.Stop()
+
+	// keystrokes arriving faster than the debounce delay result in a single search
+	for _, query := range []string{"c", "ca", "caf", "cafe"} {
+		filter.Update(query)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case result := <-filter.Results():
+		if result.query != "cafe" || len(result.notes) != 1 || result.notes[0].Title != "cafe" {
+			t.Errorf("Expected results for 'cafe', got %+v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected search results, got none")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(queries) != 1 || queries[0] != "cafe" {
+		t.Errorf("Expected a single search for 'cafe', got %v", queries)
+	}
+}
+
+func TestLiveFilterEmptyQuery(t *testing.T) {
+	search := func(keyword string) ([]entities.Note, error) {
+		t.Errorf("Expected no search for an empty query, got %q", keyword)
+		return nil, nil
+	}
+
+	filter := newLiveFilter(search, time.Millisecond)
+	defer filter.Stop()
+
+	filter.Update("")
+
+	select {
+	case result := <-filter.Results():
+		if len(result.notes) != 0 || result.err != nil {
+			t.Errorf("Expected empty result, got %+v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected empty result, got none")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	got := highlight("Go is great, GO!", "go")
+	expected := highlightStart + "Go" + highlightEnd + " is great, " + highlightStart + "GO" + highlightEnd + "!"
+
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestHighlightFoldsLikeSearch(t *testing.T) {
+	// decomposed "é" matches the composed keyword, while non-ASCII letters keep their case
+	got := highlight("Cafe\u0301 and CAFÉ", "café")
+	expected := highlightStart + "Café" + highlightEnd + " and CAFÉ"
+
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// "ſ" (long s) folds to "s" in Unicode, but not in search
+	if got = highlight("ſ", "s"); got != "ſ" {
+		t.Errorf("Expected no highlight, got %q", got)
+	}
+}
+
+func TestStopReadingKeys(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	keys := make(chan rune)
+	stop := make(chan struct{})
+	go readKeys(r, keys, stop)
+
+	w.WriteString("a")
+	if key := <-keys; key != 'a' {
+		t.Errorf("Expected key 'a', got %q", key)
+	}
+
+	// the reader is waiting for the next keystroke when the search ends
+	done := make(chan struct{})
+	go func() {
+		stopReadingKeys(r, keys, stop)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected reading keys to stop")
+	}
+
+	if _, ok := <-keys; ok {
+		t.Error("Expected keys to be closed")
+	}
+}
+
+func TestRenderSearchHiddenContent(t *testing.T) {
+	result := filterResult{query: "bread", notes: []entities.Note{{ID: 1, Title: "Bread", Content: "secret bread recipe"}}}
+
+	var out bytes.Buffer
+	renderSearch(&out, idFormatDecimal, "bread", result, true)
+	if strings.Contains(out.String(), "secret") || strings.Contains(out.String(), "Content:") {
+		t.Errorf("Expected content to be left out, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "ID: 1, Title: "+highlightStart+"Bread"+highlightEnd+"\r\n") {
+		t.Errorf("Expected the note to be listed by title, got %q", out.String())
+	}
+
+	out.Reset()
+	renderSearch(&out, idFormatDecimal, "bread", result, false)
+	if !strings.Contains(out.String(), "secret") {
+		t.Errorf("Expected content to be shown, got %q", out.String())
+	}
+}
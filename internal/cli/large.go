@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxLines is the number of lines get prints of large notes without --full, unless get.max-lines is set
+const defaultMaxLines = 200
+
+// parseMaxLines parses a line limit of get.max-lines, 0 disables the limit
+func parseMaxLines(value string) (int, error) {
+	maxLines, err := strconv.Atoi(value)
+	if err != nil || maxLines < 0 {
+		return 0, fmt.Errorf("invalid number of lines %q, expected 0 or more", value)
+	}
+
+	return maxLines, nil
+}
+
+// maxLinesOf returns the number of lines get prints of large notes, from settings if storage supports them
+func maxLinesOf(storage Storage) (int, error) {
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return defaultMaxLines, nil
+	}
+
+	value, ok, err := settings.GetSetting(configGetMaxLines)
+	if err != nil {
+		return 0, fmt.Errorf("retrieving line limit: %w", err)
+	}
+	if !ok {
+		return defaultMaxLines, nil
+	}
+
+	return parseMaxLines(value)
+}
+
+// truncateLines returns the first maxLines lines of content and the total number of its lines.
+// Content within the limit or a limit of 0 returns content unchanged
+func truncateLines(content string, maxLines int) (string, int) {
+	lines := strings.Count(content, "\n") + 1
+	if maxLines <= 0 || lines <= maxLines {
+		return content, lines
+	}
+
+	// cut after the newline ending the last kept line
+	end := 0
+	for i := 0; i < maxLines; i++ {
+		end += strings.IndexByte(content[end:], '\n') + 1
+	}
+
+	return strings.TrimSuffix(content[:end], "\n"), lines
+}
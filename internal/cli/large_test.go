@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTruncateLines(t *testing.T) {
+	tests := []struct {
+		content   string
+		maxLines  int
+		expected  string
+		lineCount int
+	}{
+		{"one\ntwo\nthree", 2, "one\ntwo", 3},
+		{"one\ntwo\nthree", 3, "one\ntwo\nthree", 3},
+		{"one\ntwo\nthree", 0, "one\ntwo\nthree", 3},
+		{"one\n\n\nfour", 3, "one\n\n", 4},
+		{"single line", 1, "single line", 1},
+	}
+
+	for _, tt := range tests {
+		content, lines := truncateLines(tt.content, tt.maxLines)
+		if content != tt.expected || lines != tt.lineCount {
+			t.Errorf("Expected %q with %d lines for %q limited to %d, got %q with %d",
+				tt.expected, tt.lineCount, tt.content, tt.maxLines, content, lines)
+		}
+	}
+}
+
+func TestGetLargeNote(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 500; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+
+	storage := &settingsStorage{}
+	_, _ = storage.NewNote("Log", strings.Join(lines, "\n"))
+
+	app := NewCLI(storage)
+	var out, errOut bytes.Buffer
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	if err := app.Run([]string{"go-notes", "get", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "line 200\n") || strings.Contains(out.String(), "line 201") {
+		t.Errorf("Expected content cut after line 200, got:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "note has 500 lines, showing the first 200") {
+		t.Errorf("Expected a warning, got %q", errOut.String())
+	}
+
+	// --full prints everything without a warning
+	out.Reset()
+	errOut.Reset()
+	if err := app.Run([]string{"go-notes", "get", "--full", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "line 500\n") || errOut.Len() != 0 {
+		t.Errorf("Expected full content without a warning, got:\n%s\n%s", out.String(), errOut.String())
+	}
+
+	// the limit is configurable
+	_ = storage.SetSetting(configGetMaxLines, "10")
+	out.Reset()
+	_ = app.Run([]string{"go-notes", "get", "1"})
+	if !strings.Contains(out.String(), "line 10\n") || strings.Contains(out.String(), "line 11") {
+		t.Errorf("Expected content cut after line 10, got:\n%s", out.String())
+	}
+}
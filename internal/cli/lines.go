@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// numberLines prefixes every line of content with its 1-based number aligned to the widest number.
+// A trailing newline doesn't start a new line, empty content has no lines
+func numberLines(content string) string {
+	if content == "" {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+
+	var sb strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&sb, "%*d  %s\n", width, i+1, line)
+	}
+
+	return sb.String()
+}
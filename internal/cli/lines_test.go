@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumberLines(t *testing.T) {
+	got := numberLines("first\nsecond\n\nfourth")
+	want := "1  first\n2  second\n3  \n4  fourth\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestNumberLinesTrailingNewline(t *testing.T) {
+	got := numberLines("first\nsecond\n")
+	want := "1  first\n2  second\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if got = numberLines(""); got != "" {
+		t.Errorf("Expected no lines for empty content, got %q", got)
+	}
+}
+
+func TestNumberLinesAlignment(t *testing.T) {
+	content := strings.TrimSuffix(strings.Repeat("line\n", 10), "\n")
+
+	lines := strings.Split(numberLines(content), "\n")
+	if lines[0] != " 1  line" || lines[9] != "10  line" {
+		t.Errorf("Expected numbers aligned to two digits, got %q and %q", lines[0], lines[9])
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/links"
+)
+
+// linkcheckCommand creates new CLI command for finding links ([[ID]] in content) to notes which don't exist
+func linkcheckCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "linkcheck"
+		commandUsage = "List links ([[ID]] in content) pointing to notes which don't exist"
+	)
+
+	// create a new CLI command configuration
+	linkcheck := cli.Command{
+		Name:  commandName,  // name of command (e.g., "linkcheck")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			// call a function from 'storage' object to retrieve all notes
+			notes, err := storage.GetAllNotes()
+			if err != nil {
+				return fmt.Errorf("retrieving notes: %w", err)
+			}
+
+			broken := links.Broken(notes)
+			if len(broken) == 0 {
+				fmt.Fprintln(c.App.Writer, "No broken links found")
+				return nil
+			}
+
+			titles := make(map[int]string, len(notes))
+			for _, note := range notes {
+				titles[note.ID] = note.Title
+			}
+
+			// broken links are ordered by source, so each note gets a single line
+			ids := noteIDFormat(c)
+			for i := 0; i < len(broken); {
+				from := broken[i].From
+
+				var missing []string
+				for ; i < len(broken) && broken[i].From == from; i++ {
+					missing = append(missing, ids.Format(broken[i].To))
+				}
+
+				fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, Missing: %s\n", ids.Format(from), titles[from], strings.Join(missing, ", "))
+			}
+
+			return nil
+		},
+	}
+
+	return linkcheck
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLinkcheck(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Index", "See [[2]] and [[5]]")
+	_, _ = storage.NewNote("Target", "Back to [[1]]")
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "linkcheck"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "ID: 1, Title: Index, Missing: 5\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-notes/internal/entities"
+)
+
+// sortByMatches is the value of search --sort ordering results by the number of keyword occurrences
+const sortByMatches = "matches"
+
+// countFold counts non-overlapping case-insensitive occurrences of keyword in s
+func countFold(s, keyword string) int {
+	runes, kw := []rune(s), []rune(keyword)
+	if len(kw) == 0 {
+		return 0
+	}
+
+	count := 0
+	for i := 0; i+len(kw) <= len(runes); {
+		if strings.EqualFold(string(runes[i:i+len(kw)]), keyword) {
+			count++
+			i += len(kw)
+			continue
+		}
+		i++
+	}
+
+	return count
+}
+
+// countMatches counts case-insensitive occurrences of keyword in title and content of the note
+func countMatches(note entities.Note, keyword string) int {
+	return countFold(note.Title, keyword) + countFold(note.Content, keyword)
+}
+
+// sortSearchResults orders notes found for keyword as requested with search --sort,
+// an empty order keeps the order of storage
+func sortSearchResults(notes []entities.Note, keyword, order string) error {
+	switch order {
+	case "":
+		return nil
+	case sortByMatches:
+		counts := make(map[int]int, len(notes))
+		for _, note := range notes {
+			counts[note.ID] = countMatches(note, keyword)
+		}
+
+		// notes with the same count keep the order of storage
+		sort.SliceStable(notes, func(i, j int) bool {
+			return counts[notes[i].ID] > counts[notes[j].ID]
+		})
+
+		return nil
+	default:
+		return fmt.Errorf("unknown sort order: %s", order)
+	}
+}
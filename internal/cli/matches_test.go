@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestCountMatches(t *testing.T) {
+	tests := []struct {
+		note     entities.Note
+		keyword  string
+		expected int
+	}{
+		{entities.Note{Title: "Go notes", Content: "go, GO and gopher"}, "go", 4},
+		{entities.Note{Title: "aaaa"}, "aa", 2},
+		{entities.Note{Title: "Straße", Content: "STRASSE straße"}, "straße", 2},
+		{entities.Note{Title: "nothing"}, "go", 0},
+		{entities.Note{Title: "empty keyword"}, "", 0},
+	}
+
+	for _, tt := range tests {
+		if count := countMatches(tt.note, tt.keyword); count != tt.expected {
+			t.Errorf("Expected %d matches of %q in %+v, got %d", tt.expected, tt.keyword, tt.note, count)
+		}
+	}
+}
+
+func TestSearchSortByMatches(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Once", "a single go")
+	_, _ = storage.NewNote("Thrice", "go go, Go")
+	_, _ = storage.NewNote("Twice go", "go")
+	_, _ = storage.NewNote("Also once", "go away")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "search", "--sort", "matches", "--ids-only", "go"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// notes with the same count keep their order
+	if expected := "2\n3\n1\n4\n"; out.String() != expected {
+		t.Errorf("Expected IDs ordered by matches %q, got %q", expected, out.String())
+	}
+
+	if err := app.Run([]string{"go-notes", "search", "--sort", "size", "go"}); err == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// MetaStorage is implemented by storages supporting arbitrary key/value metadata of notes
+type MetaStorage interface {
+	// SetMeta sets a metadata value of the note with the specified ID
+	SetMeta(noteID int, key, value string) error
+
+	// GetMeta retrieves all metadata of the note with the specified ID
+	GetMeta(noteID int) (map[string]string, error)
+
+	// DeleteMeta removes a metadata key from the note with the specified ID
+	DeleteMeta(noteID int, key string) error
+
+	// GetNotesByMeta retrieves notes having the key set to value, an empty value matches any note having the key
+	GetNotesByMeta(key, value string) ([]entities.Note, error)
+}
+
+// metaCommand creates new CLI command for managing metadata of a single note
+func metaCommand(storage MetaStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "meta"
+		commandUsage = "Manage custom key/value fields of a note"
+	)
+
+	// create a new CLI command configuration with a subcommand per operation
+	meta := cli.Command{
+		Name:  commandName,  // name of command (e.g., "meta")
+		Usage: commandUsage, // description of command
+		Subcommands: []cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Set a field of a note",
+				ArgsUsage: "noteID key value",
+				Action: func(c *cli.Context) error {
+					noteID, ok, err := noteIDArg(c, "Please provide ID of note, a key and a value.")
+					if !ok || err != nil {
+						return err
+					}
+
+					key, value := c.Args().Get(1), c.Args().Get(2)
+					if key == "" || value == "" {
+						return missingArg(c, "Please provide ID of note, a key and a value.")
+					}
+
+					if err = storage.SetMeta(noteID, key, value); err != nil {
+						return fmt.Errorf("setting metadata: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Set '%s' of note with ID %s\n", key, noteIDFormat(c).Format(noteID))
+
+					return nil
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Print all fields of a note or a single one",
+				ArgsUsage: "noteID [key]",
+				Action: func(c *cli.Context) error {
+					noteID, ok, err := noteIDArg(c, "Please provide ID of note.")
+					if !ok || err != nil {
+						return err
+					}
+
+					meta, err := storage.GetMeta(noteID)
+					if err != nil {
+						return fmt.Errorf("retrieving metadata: %w", err)
+					}
+
+					// print only the value of a single key if one is given
+					if key := c.Args().Get(1); key != "" {
+						value, found := meta[normalizeMetaKey(key)]
+						if !found {
+							return fmt.Errorf("note with ID %s has no '%s' field", noteIDFormat(c).Format(noteID), key)
+						}
+
+						fmt.Fprintln(c.App.Writer, value)
+
+						return nil
+					}
+
+					for _, key := range sortedKeys(meta) {
+						fmt.Fprintf(c.App.Writer, "%s: %s\n", key, meta[key])
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:      "del",
+				Usage:     "Delete a field of a note",
+				ArgsUsage: "noteID key",
+				Action: func(c *cli.Context) error {
+					noteID, ok, err := noteIDArg(c, "Please provide ID of note and a key.")
+					if !ok || err != nil {
+						return err
+					}
+
+					key := c.Args().Get(1)
+					if key == "" {
+						return missingArg(c, "Please provide ID of note and a key.")
+					}
+
+					if err = storage.DeleteMeta(noteID, key); err != nil {
+						return fmt.Errorf("deleting metadata: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Deleted '%s' of note with ID %s\n", key, noteIDFormat(c).Format(noteID))
+
+					return nil
+				},
+			},
+		},
+	}
+
+	return meta
+}
+
+// normalizeMetaKey matches keys the same way storages store them
+func normalizeMetaKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// sortedKeys returns keys of the map in alphabetical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/export"
+)
+
+// defaultMirrorInterval is the default time between checks for changes of the mirrored note
+const defaultMirrorInterval = 2 * time.Second
+
+// mirrorCommand creates new CLI command for keeping a markdown file in sync with a note
+func mirrorCommand(storage Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "mirror"
+		commandUsage = "Export a note as markdown and re-write the file whenever the note changes, until interrupted"
+	)
+
+	// create a new CLI command configuration
+	mirror := cli.Command{
+		Name:      commandName,  // name of command (e.g., "mirror")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "markdown file to keep in sync with the note",
+			},
+			cli.DurationFlag{
+				Name:  "interval",
+				Value: defaultMirrorInterval,
+				Usage: "time between checks for changes of the note",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to mirror.")
+			if !ok || err != nil {
+				return err
+			}
+
+			path := c.String("to")
+			if path == "" {
+				return missingArg(c, "Please provide a file to mirror the note to with --to.")
+			}
+
+			return mirrorNote(storage, noteID, path, c.Duration("interval"), func(note entities.Note) {
+				fmt.Fprintf(c.App.Writer, "Wrote note with ID %s to %s\n", noteIDFormat(c).Format(note.ID), path)
+			})
+		},
+	}
+
+	return mirror
+}
+
+// fetchChangedNote retrieves the note and reports whether it changed since prev was retrieved.
+// Storages may keep last_edited_at with a resolution of a second, so title and content are compared too,
+// catching edits made within the same second
+func fetchChangedNote(storage Storage, prev entities.Note) (entities.Note, bool, error) {
+	note, err := storage.GetNoteByID(prev.ID)
+	if err != nil {
+		return prev, false, err
+	}
+
+	changed := !note.LastEditedAt.Equal(prev.LastEditedAt) || note.Title != prev.Title || note.Content != prev.Content
+
+	return note, changed, nil
+}
+
+// mirrorNote writes the note to path and re-writes it whenever the note changes, checking for changes every interval.
+// written is called after every write, it returns once interrupted with Ctrl-C
+func mirrorNote(storage Storage, noteID int, path string, interval time.Duration, written func(note entities.Note)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval: %s", interval)
+	}
+
+	note, err := storage.GetNoteByID(noteID)
+	if err != nil {
+		return fmt.Errorf("retrieving note: %w", err)
+	}
+
+	if err = writeNoteFile(path, note); err != nil {
+		return fmt.Errorf("writing note: %w", err)
+	}
+	written(note)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		var changed bool
+		note, changed, err = fetchChangedNote(storage, note)
+		if err != nil {
+			return fmt.Errorf("retrieving note: %w", err)
+		}
+		if !changed {
+			continue
+		}
+
+		if err = writeNoteFile(path, note); err != nil {
+			return fmt.Errorf("writing note: %w", err)
+		}
+		written(note)
+	}
+}
+
+// writeNoteFile writes the note as markdown to path atomically,
+// so readers of the file never see it partially written
+func writeNoteFile(path string, note entities.Note) error {
+	var buf bytes.Buffer
+	if err := export.WriteMarkdown(&buf, note); err != nil {
+		return err
+	}
+
+	// the temporary file is created next to the target, as renames are atomic only within a filesystem
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	// removing fails harmlessly once the file is renamed
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	// temporary files are private, the mirror is readable like other exported files
+	if err = os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchChangedNote(t *testing.T) {
+	storage := &fakeStorage{}
+	id, _ := storage.NewNote("Plan", "Draft")
+
+	note, _ := storage.GetNoteByID(id)
+	note, changed, err := fetchChangedNote(storage, note)
+	if err != nil || changed {
+		t.Fatalf("Expected unchanged note, got changed %v (%v)", changed, err)
+	}
+
+	// an edit within the same second leaves last_edited_at as is, but changes the content
+	_ = storage.SetNoteContent(id, "Final")
+	note, changed, _ = fetchChangedNote(storage, note)
+	if !changed || note.Content != "Final" {
+		t.Errorf("Expected edited note to be changed, got %v with %+v", changed, note)
+	}
+
+	// the edit is reported once
+	note, changed, _ = fetchChangedNote(storage, note)
+	if changed {
+		t.Errorf("Expected no change after the edit was seen")
+	}
+
+	// an edit keeping the content still moves last_edited_at
+	storage.notes[0].LastEditedAt = time.Now()
+	_, changed, _ = fetchChangedNote(storage, note)
+	if !changed {
+		t.Errorf("Expected note with new last edit time to be changed")
+	}
+}
+
+func TestWriteNoteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+
+	storage := &fakeStorage{}
+	id, _ := storage.NewNote("Plan", "Draft")
+	note, _ := storage.GetNoteByID(id)
+
+	if err := writeNoteFile(path, note); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# Plan") || !strings.Contains(string(data), "Draft") {
+		t.Errorf("Expected note as markdown, got %q", data)
+	}
+
+	// no temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the mirrored file, got %d files", len(entries))
+	}
+}
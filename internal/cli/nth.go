@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// NthStorage is implemented by storages able to retrieve a note by its position in sorted notes
+type NthStorage interface {
+	// GetNthNote retrieves the note at the 1-based position n of notes sorted in the order
+	GetNthNote(order storage.NoteOrder, n int) (entities.Note, error)
+}
+
+// NoteOfTheDayStorage is implemented by storages able to pick a note for every day
+type NoteOfTheDayStorage interface {
+	// GetNoteOfTheDay retrieves the note picked for the day of date
+	GetNoteOfTheDay(date time.Time) (entities.Note, error)
+}
+
+// parseNoteOrder parses the name of an order of notes
+func parseNoteOrder(s string) (storage.NoteOrder, error) {
+	names := make([]string, len(storage.NoteOrders))
+	for i, order := range storage.NoteOrders {
+		if s == string(order) {
+			return order, nil
+		}
+
+		names[i] = string(order)
+	}
+
+	return "", fmt.Errorf("unknown sort %q, expected one of: %s", s, strings.Join(names, ", "))
+}
+
+// nthCommand creates new CLI command for printing the note at a position in sorted notes
+func nthCommand(storage NthStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "nth"
+		commandUsage = "Print the note at a position in sorted notes, e.g. the 3rd most recently edited"
+	)
+
+	// create a new CLI command configuration
+	nth := cli.Command{
+		Name:  commandName,  // name of command (e.g., "nth")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "sort", Value: "edited", Usage: "order of notes: edited, created, title or id"},
+			cli.IntFlag{Name: "index", Value: 1, Usage: "1-based position of the note"},
+		},
+		Action: func(c *cli.Context) error {
+			order, err := parseNoteOrder(c.String("sort"))
+			if err != nil {
+				return err
+			}
+
+			index := c.Int("index")
+			note, err := storage.GetNthNote(order, index)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("there is no note at index %d", index)
+			}
+			if err != nil {
+				return fmt.Errorf("retrieving note: %w", err)
+			}
+
+			printNoteList(c.App.Writer, noteIDFormat(c), []entities.Note{note}, 0)
+
+			return nil
+		},
+	}
+
+	return nth
+}
+
+// noteOfTheDayCommand creates new CLI command for printing the note picked for today
+func noteOfTheDayCommand(storage NoteOfTheDayStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "note-of-the-day"
+		commandUsage = "Print a note picked for today, the same one all day"
+	)
+
+	// create a new CLI command configuration
+	noteOfTheDay := cli.Command{
+		Name:  commandName,  // name of command (e.g., "note-of-the-day")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			note, err := storage.GetNoteOfTheDay(time.Now())
+			if errors.Is(err, sql.ErrNoRows) {
+				fmt.Fprintln(c.App.Writer, "There are no notes yet")
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("retrieving note of the day: %w", err)
+			}
+
+			printNoteList(c.App.Writer, noteIDFormat(c), []entities.Note{note}, 0)
+
+			return nil
+		},
+	}
+
+	return noteOfTheDay
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// OrphanStorage is implemented by storages able to find notes not connected to anything
+type OrphanStorage interface {
+	// GetOrphanNotes retrieves notes without tags and without links to or from other notes
+	GetOrphanNotes() ([]entities.Note, error)
+}
+
+// orphansCommand creates new CLI command for listing notes without tags and links
+func orphansCommand(storage OrphanStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "orphans"
+		commandUsage = "List notes with no tags and no links to or from other notes"
+	)
+
+	// create a new CLI command configuration
+	orphans := cli.Command{
+		Name:  commandName,  // name of command (e.g., "orphans")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			notes, err := storage.GetOrphanNotes()
+			if err != nil {
+				return fmt.Errorf("listing orphan notes: %w", err)
+			}
+
+			if len(notes) == 0 {
+				fmt.Fprintln(c.App.Writer, "No orphan notes found")
+				return nil
+			}
+
+			for _, note := range notes {
+				fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, CreatedAt: %s\n", noteIDFormat(c).Format(note.ID), note.Title, note.CreatedAt)
+			}
+
+			return nil
+		},
+	}
+
+	return orphans
+}
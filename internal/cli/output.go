@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isStructured reports whether the output format is meant for other programs rather than people
+func isStructured(format string) bool {
+	return format == outputJSON || format == outputYAML
+}
+
+// writeStructured writes value as indented JSON or as YAML, keys follow the order of struct fields
+// and timestamps are written in RFC 3339 format
+func writeStructured(w io.Writer, format string, value interface{}) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	default:
+		return fmt.Errorf("%s output is not structured", format)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"go-notes/internal/entities"
+)
+
+// outputStorage returns notes with timestamps, as a real storage does
+func outputStorage() *fakeStorage {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	due := created.Add(24 * time.Hour)
+
+	return &fakeStorage{notes: []entities.Note{
+		{ID: 1, Title: "Groceries", Content: "Milk and bread.", CreatedAt: created, LastEditedAt: created.Add(time.Hour), DueAt: &due},
+		{ID: 2, Title: "Meeting", Content: "Discuss: the release", CreatedAt: created, LastEditedAt: created},
+	}}
+}
+
+func TestListOutputYAML(t *testing.T) {
+	storage := outputStorage()
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "list", "--output", "yaml"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// timestamps are written in RFC 3339 format
+	if !strings.Contains(out.String(), "created_at: 2024-01-02T03:04:05Z") {
+		t.Errorf("Expected RFC 3339 timestamps, got %q", out.String())
+	}
+
+	var notes []entities.Note
+	if err := yaml.Unmarshal(out.Bytes(), &notes); err != nil {
+		t.Fatalf("Expected valid YAML, got %v", err)
+	}
+	if len(notes) != len(storage.notes) {
+		t.Fatalf("Expected %d notes, got %+v", len(storage.notes), notes)
+	}
+	for i, note := range notes {
+		want := storage.notes[i]
+		if note.ID != want.ID || note.Title != want.Title || note.Content != want.Content ||
+			!note.CreatedAt.Equal(want.CreatedAt) || !note.LastEditedAt.Equal(want.LastEditedAt) ||
+			(note.DueAt == nil) != (want.DueAt == nil) || (note.DueAt != nil && !note.DueAt.Equal(*want.DueAt)) {
+			t.Errorf("Expected %+v, got %+v", want, note)
+		}
+	}
+}
+
+func TestGetOutputYAML(t *testing.T) {
+	storage := outputStorage()
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "get", "--output", "yaml", "2"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var note entities.Note
+	if err := yaml.Unmarshal(out.Bytes(), &note); err != nil {
+		t.Fatalf("Expected valid YAML, got %v", err)
+	}
+	if note.ID != 2 || note.Content != "Discuss: the release" || note.DueAt != nil {
+		t.Errorf("Expected the second note, got %+v", note)
+	}
+}
+
+func TestSearchOutputJSON(t *testing.T) {
+	storage := outputStorage()
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	// nothing found is still a valid list
+	if err := app.Run([]string{"go-notes", "search", "--output", "json", "nothing"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var notes []entities.Note
+	if err := json.Unmarshal(out.Bytes(), &notes); err != nil || notes == nil || len(notes) != 0 {
+		t.Errorf("Expected an empty JSON list, got %q (%v)", out.String(), err)
+	}
+}
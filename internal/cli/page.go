@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"go-notes/internal/entities"
+)
+
+// PageStorage is implemented by storages able to retrieve notes page by page
+type PageStorage interface {
+	// GetNotesPageMeta retrieves up to limit notes after the first offset ones with the total number of notes
+	GetNotesPageMeta(limit, offset int) (entities.Page, error)
+}
+
+// printPageFooter prints which notes of all of them a page starting at offset holds, e.g. "Showing 21-40 of 340 notes"
+func printPageFooter(w io.Writer, page entities.Page, offset int) {
+	if len(page.Notes) == 0 {
+		fmt.Fprintf(w, "Showing 0 of %d notes\n", page.Total)
+		return
+	}
+
+	fmt.Fprintf(w, "Showing %d-%d of %d notes", offset+1, offset+len(page.Notes), page.Total)
+	if page.HasMore {
+		fmt.Fprintf(w, ", next page with --offset %d", offset+len(page.Notes))
+	}
+	fmt.Fprintln(w)
+}
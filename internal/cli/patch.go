@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/urfave/cli"
+)
+
+// PatchStorage is implemented by storages able to update contents of many notes at once
+type PatchStorage interface {
+	// SetNotesContent updates contents of notes keyed by their IDs in a single transaction
+	// and returns the result of every update, see sqlite.Storage.SetNotesContent
+	SetNotesContent(contents map[int]string, continueOnError bool) (map[int]error, error)
+}
+
+// patchCommand creates new CLI command for updating contents of many notes from a JSON file
+func patchCommand(storage PatchStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "patch"
+		commandUsage = `Update contents of notes from a JSON file mapping note IDs to content, e.g. {"1": "new content"}`
+	)
+
+	// create a new CLI command configuration
+	patch := cli.Command{
+		Name:  commandName,  // name of command (e.g., "patch")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "in", Usage: `JSON patch file, "-" reads standard input`},
+			cli.BoolFlag{Name: "continue-on-error", Usage: "apply the rest of the patch if some notes can't be updated"},
+		},
+		Action: func(c *cli.Context) error {
+			path := c.String("in")
+			if path == "" {
+				return missingArg(c, "Please provide a patch file with --in.")
+			}
+
+			var r io.Reader = os.Stdin
+			if path != "-" {
+				file, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("opening patch: %w", err)
+				}
+				// ensure file is closed when done reading
+				defer file.Close()
+
+				r = file
+			}
+
+			contents, err := parsePatch(r, noteIDFormat(c))
+			if err != nil {
+				return err
+			}
+
+			continueOnError := c.Bool("continue-on-error")
+			results, err := storage.SetNotesContent(contents, continueOnError)
+			printPatchResults(c.App.Writer, noteIDFormat(c), results, err != nil)
+			if err != nil {
+				return fmt.Errorf("patch was rolled back: %w", err)
+			}
+
+			var failed int
+			for _, result := range results {
+				if result != nil {
+					failed++
+				}
+			}
+
+			fmt.Fprintf(c.App.Writer, "Updated %d note(s), %d failed\n", len(results)-failed, failed)
+			if failed > 0 {
+				return errors.New("some notes were not updated")
+			}
+
+			return nil
+		},
+	}
+
+	return patch
+}
+
+// parsePatch reads a JSON object mapping note IDs in the format to new contents
+func parsePatch(r io.Reader, ids idFormat) (map[int]string, error) {
+	var patch map[string]string
+	if err := json.NewDecoder(r).Decode(&patch); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	contents := make(map[int]string, len(patch))
+	for key, content := range patch {
+		id, err := ids.Parse(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid patch: invalid note ID %q: %w", key, err)
+		}
+		if _, ok := contents[id]; ok {
+			return nil, fmt.Errorf("invalid patch: note ID %q is repeated", key)
+		}
+
+		contents[id] = content
+	}
+
+	return contents, nil
+}
+
+// printPatchResults prints the result of the update of every note in ID order,
+// successful updates of a rolled back patch are reported as not applied
+func printPatchResults(w io.Writer, ids idFormat, results map[int]error, rolledBack bool) {
+	noteIDs := make([]int, 0, len(results))
+	for id := range results {
+		noteIDs = append(noteIDs, id)
+	}
+	sort.Ints(noteIDs)
+
+	for _, id := range noteIDs {
+		if err := results[id]; err != nil {
+			fmt.Fprintf(w, "Note %s: failed: %v\n", ids.Format(id), err)
+			continue
+		}
+		if rolledBack {
+			fmt.Fprintf(w, "Note %s: not applied\n", ids.Format(id))
+			continue
+		}
+
+		fmt.Fprintf(w, "Note %s: updated\n", ids.Format(id))
+	}
+}
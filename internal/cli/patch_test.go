@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePatch(t *testing.T) {
+	contents, err := parsePatch(strings.NewReader(`{"1": "One", "12": "Twelve\nlines"}`), idFormatDecimal)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(contents) != 2 || contents[1] != "One" || contents[12] != "Twelve\nlines" {
+		t.Errorf("Expected contents of notes 1 and 12, got %v", contents)
+	}
+
+	// keys follow the ID format, so the same note can't be given twice
+	for _, patch := range []string{`["One"]`, `{"x": "One"}`, `{"1": 2}`, `{"1": "One", "01": "Again"}`} {
+		if _, err = parsePatch(strings.NewReader(patch), idFormatDecimal); err == nil {
+			t.Errorf("Expected an error for patch %s", patch)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// notePayload is a JSON object describing a new note
+type notePayload struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// readNotePayload parses a JSON note payload, "-" reads the payload from r instead
+func readNotePayload(payload string, r io.Reader) (title, content string, err error) {
+	if payload == "-" {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return "", "", fmt.Errorf("reading payload: %w", err)
+		}
+
+		payload = string(data)
+	}
+
+	return parseNotePayload(payload)
+}
+
+// parseNotePayload parses a single JSON object with required title and content fields, unknown fields are rejected
+func parseNotePayload(payload string) (title, content string, err error) {
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.DisallowUnknownFields()
+
+	var note notePayload
+	if err = decoder.Decode(&note); err != nil {
+		return "", "", fmt.Errorf("invalid note payload: %w", err)
+	}
+
+	// only a single object is accepted, trailing data is most likely a mistake
+	if decoder.More() {
+		return "", "", errors.New("invalid note payload: unexpected data after the object")
+	}
+
+	switch {
+	case strings.TrimSpace(note.Title) == "":
+		return "", "", errors.New(`invalid note payload: missing "title"`)
+	case note.Content == "":
+		return "", "", errors.New(`invalid note payload: missing "content"`)
+	}
+
+	return note.Title, note.Content, nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseNotePayload(t *testing.T) {
+	title, content, err := parseNotePayload(`{"title": "Groceries", "content": "Milk and bread."}`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if title != "Groceries" || content != "Milk and bread." {
+		t.Errorf("Expected title and content from payload, got %q and %q", title, content)
+	}
+}
+
+func TestParseNotePayloadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing title":   `{"content": "Milk"}`,
+		"empty title":     `{"title": " ", "content": "Milk"}`,
+		"missing content": `{"title": "Groceries"}`,
+		"unknown field":   `{"title": "Groceries", "content": "Milk", "tags": ["shop"]}`,
+		"wrong type":      `{"title": 1, "content": "Milk"}`,
+		"trailing data":   `{"title": "Groceries", "content": "Milk"} {}`,
+		"not an object":   `"Groceries"`,
+	}
+	for name, payload := range tests {
+		if _, _, err := parseNotePayload(payload); err == nil {
+			t.Errorf("Expected error for %s, got nil", name)
+		}
+	}
+}
+
+func TestNewNoteFromPayload(t *testing.T) {
+	storage := &fakeStorage{}
+
+	title, content, err := readNotePayload("-", strings.NewReader(`{"title": "T", "content": "C"}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if title != "T" || content != "C" {
+		t.Errorf("Expected payload from reader, got %q and %q", title, content)
+	}
+
+	app := NewCLI(storage)
+	app.Writer = &bytes.Buffer{}
+	if err = app.Run([]string{"go-notes", "new", "--json", `{"title":"T","content":"C"}`}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(storage.notes) != 1 || storage.notes[0].Title != "T" || storage.notes[0].Content != "C" {
+		t.Errorf("Expected a note created from payload, got %+v", storage.notes)
+	}
+
+	// invalid payloads don't create notes
+	if err = app.Run([]string{"go-notes", "new", "--json", `{"title":"T"}`}); err == nil {
+		t.Errorf("Expected error for a payload without content, got nil")
+	}
+	if len(storage.notes) != 1 {
+		t.Errorf("Expected no note for an invalid payload, got %+v", storage.notes)
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// PinStorage is implemented by storages supporting pinned notes
+type PinStorage interface {
+	// PinNote pins the note with the specified ID
+	PinNote(noteID int) error
+
+	// UnpinNote unpins the note with the specified ID
+	UnpinNote(noteID int) error
+
+	// GetNotesForDashboard retrieves pinned notes followed by the rest of notes from the most recently edited
+	GetNotesForDashboard() ([]entities.Note, error)
+}
+
+// pinNoteCommand creates new CLI command for pinning a note
+func pinNoteCommand(storage PinStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "pin"
+		commandUsage = "Pin a note to the top of the dashboard"
+	)
+
+	// create a new CLI command configuration
+	pinNote := cli.Command{
+		Name:      commandName,  // name of command (e.g., "pin")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to pin.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if err = storage.PinNote(noteID); err != nil {
+				return fmt.Errorf("pinning note: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Pinned note with ID %s\n", noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return pinNote
+}
+
+// unpinNoteCommand creates new CLI command for unpinning a note
+func unpinNoteCommand(storage PinStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "unpin"
+		commandUsage = "Unpin a note"
+	)
+
+	// create a new CLI command configuration
+	unpinNote := cli.Command{
+		Name:      commandName,  // name of command (e.g., "unpin")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to unpin.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if err = storage.UnpinNote(noteID); err != nil {
+				return fmt.Errorf("unpinning note: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Unpinned note with ID %s\n", noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return unpinNote
+}
+
+// dashboardCommand creates new CLI command for listing pinned notes first and then the recently edited ones
+func dashboardCommand(storage PinStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "dashboard"
+		commandUsage = "List pinned notes followed by recently edited ones"
+	)
+
+	// create a new CLI command configuration
+	dashboard := cli.Command{
+		Name:  commandName,  // name of command (e.g., "dashboard")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			notes, err := storage.GetNotesForDashboard()
+			if err != nil {
+				return fmt.Errorf("retrieving dashboard: %w", err)
+			}
+
+			// pinned notes come first, so a marker is enough to separate them
+			for _, note := range notes {
+				marker := " "
+				if note.PinnedAt != nil {
+					marker = "*"
+				}
+
+				fmt.Fprintf(c.App.Writer, "%s ID: %s, Title: %s, LastEditedAt: %s\n",
+					marker, noteIDFormat(c).Format(note.ID), note.Title, note.LastEditedAt)
+			}
+
+			return nil
+		},
+	}
+
+	return dashboard
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/storage"
+)
+
+// PragmaStorage is implemented by storages able to report settings of the underlying database
+type PragmaStorage interface {
+	// GetPragmas retrieves effective settings and size of the database
+	GetPragmas() (storage.Pragmas, error)
+}
+
+// pragmasCommand creates new CLI command for printing settings of the database
+func pragmasCommand(storage PragmaStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "pragmas"
+		commandUsage = "Print effective database settings, e.g. journal mode and foreign keys, and its size"
+	)
+
+	// create a new CLI command configuration
+	pragmas := cli.Command{
+		Name:  commandName,  // name of command (e.g., "pragmas")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			p, err := storage.GetPragmas()
+			if err != nil {
+				return fmt.Errorf("retrieving database settings: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "journal_mode: %s\n", p.JournalMode)
+			fmt.Fprintf(c.App.Writer, "foreign_keys: %t\n", p.ForeignKeys)
+			fmt.Fprintf(c.App.Writer, "page_size: %d\n", p.PageSize)
+			fmt.Fprintf(c.App.Writer, "page_count: %d\n", p.PageCount)
+			fmt.Fprintf(c.App.Writer, "size: %d bytes\n", p.Size())
+
+			return nil
+		},
+	}
+
+	return pragmas
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// noContentFlag hides content of notes printed by search, get and list, e.g. while sharing the screen.
+// It takes precedence over --content of list
+var noContentFlag = cli.BoolFlag{Name: "no-content", Usage: "omit content of notes, showing only their details, see the output.no-content setting"}
+
+// hideContent reports whether content must be omitted, by --no-content or by settings if storage supports them
+func hideContent(c *cli.Context, storage Storage) (bool, error) {
+	if c.Bool(noContentFlag.Name) {
+		return true, nil
+	}
+
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return false, nil
+	}
+
+	value, _, err := settings.GetSetting(configNoContent)
+	if err != nil {
+		return false, fmt.Errorf("retrieving content visibility: %w", err)
+	}
+
+	return parseBoolSetting(value)
+}
+
+// withoutContent returns copies of notes with empty content, for output formats printing every field
+func withoutContent(notes []entities.Note) []entities.Note {
+	if notes == nil {
+		return nil
+	}
+
+	hidden := make([]entities.Note, len(notes))
+	for i, note := range notes {
+		note.Content = ""
+		hidden[i] = note
+	}
+
+	return hidden
+}
+
+// withoutColumn returns columns without the named one, nil if no other column is left
+func withoutColumn(columns []string, name string) []string {
+	var kept []string
+	for _, column := range columns {
+		if column != name {
+			kept = append(kept, column)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNoContent(t *testing.T) {
+	storage := &settingsStorage{}
+	_, _ = storage.NewNote("Groceries", "secret bread recipe")
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	run := func(args ...string) string {
+		out.Reset()
+		if err := app.Run(append([]string{"go-notes"}, args...)); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", args, err)
+		}
+		return out.String()
+	}
+
+	// search matches content without printing it, in every format
+	for _, args := range [][]string{
+		{"search", "--no-content", "bread"},
+		{"search", "--no-content", "--output", "json", "bread"},
+		{"search", "--no-content", "--output", "table", "bread"},
+		{"get", "--no-content", "1"},
+		{"list", "--content", "--no-content"},
+	} {
+		output := run(args...)
+		if strings.Contains(output, "secret") || !strings.Contains(output, "Groceries") {
+			t.Errorf("Expected only details of the note for %v, got %q", args, output)
+		}
+	}
+
+	// the setting hides content by default
+	if output := run("search", "bread"); !strings.Contains(output, "secret") {
+		t.Errorf("Expected content without the flag, got %q", output)
+	}
+	_ = storage.SetSetting(configNoContent, "true")
+	if output := run("search", "bread"); strings.Contains(output, "secret") {
+		t.Errorf("Expected content hidden by the setting, got %q", output)
+	}
+}
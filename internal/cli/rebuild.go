@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/storage"
+)
+
+// RebuildStorage is implemented by storages able to regenerate data derived from notes
+type RebuildStorage interface {
+	// Rebuild regenerates derived data from the stored notes and reports what was rebuilt
+	Rebuild() (storage.RebuildReport, error)
+}
+
+// rebuildCommand creates new CLI command for regenerating content hashes and indexes from notes
+func rebuildCommand(rebuildStorage RebuildStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "rebuild"
+		commandUsage = "Regenerate content hashes and indexes from the stored notes"
+	)
+
+	// create a new CLI command configuration
+	rebuild := cli.Command{
+		Name:  commandName,  // name of command (e.g., "rebuild")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			report, err := rebuildStorage.Rebuild()
+			if err != nil {
+				return fmt.Errorf("rebuilding: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Checked %d note(s), fixed %d content hash(es)\n", report.Notes, report.ContentHashes)
+			if len(report.Indexes) > 0 {
+				fmt.Fprintf(c.App.Writer, "Rebuilt indexes: %s\n", strings.Join(report.Indexes, ", "))
+			}
+
+			return nil
+		},
+	}
+
+	return rebuild
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// RecentSearchStorage is implemented by storages able to search only recently changed notes
+type RecentSearchStorage interface {
+	// SearchRecentNotes searches for notes created or edited at or after since containing the keyword in the scope
+	SearchRecentNotes(keyword string, scope storage.SearchScope, since time.Time) ([]entities.Note, error)
+}
+
+// recentSearch returns the search of search --recent-days, finding notes changed within the last days
+// in the scope selected by --include-tags and --title-only
+func recentSearch(c *cli.Context, notes Storage) (func(keyword string) ([]entities.Note, error), error) {
+	days := c.Int("recent-days")
+	if days < 0 {
+		return nil, fmt.Errorf("invalid number of days: %d", days)
+	}
+
+	recentStorage, ok := notes.(RecentSearchStorage)
+	if !ok {
+		return nil, errors.New("storage doesn't support searching recent notes")
+	}
+
+	scope := storage.ScopeText
+	switch {
+	case c.Bool("title-only"):
+		scope = storage.ScopeTitle
+	case c.Bool("include-tags"):
+		scope = storage.ScopeTags
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+
+	return func(keyword string) ([]entities.Note, error) {
+		return recentStorage.SearchRecentNotes(keyword, scope, since)
+	}, nil
+}
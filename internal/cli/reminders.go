@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// global flags suppressing notices printed before commands
+const (
+	// quietFlag names the global flag suppressing all notices, i.e. reminders and the backup reminder
+	quietFlag = "quiet"
+
+	// noRemindersFlag names the global flag suppressing reminders of due notes
+	noRemindersFlag = "no-reminders"
+)
+
+// parseBoolSetting parses a value of a setting which is on or off, e.g. reminders.enabled, empty value is off
+func parseBoolSetting(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q, expected true or false", value)
+	}
+
+	return enabled, nil
+}
+
+// reminderBanner describes notes which are overdue or due later today at now, notes must be ordered by due time.
+// Notes without a due date or due after today are left out, an empty banner means there is nothing to remind of
+func reminderBanner(ids idFormat, notes []entities.Note, now time.Time) string {
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	var banner strings.Builder
+	for _, note := range notes {
+		if note.DueAt == nil || !note.DueAt.Before(tomorrow) {
+			continue
+		}
+
+		state := "Due today"
+		if note.DueAt.Before(now) {
+			state = "Overdue"
+		}
+
+		if banner.Len() == 0 {
+			banner.WriteString("Reminders:\n")
+		}
+		fmt.Fprintf(&banner, "  %s: ID: %s, Title: %s, Due: %s\n",
+			state, ids.Format(note.ID), note.Title, note.DueAt.In(now.Location()).Format(dueDateLayouts[0]))
+	}
+
+	return banner.String()
+}
+
+// printReminders prints the banner of overdue and due today notes before a command if reminders.enabled is set,
+// unless --no-reminders is given. Failures are ignored, as a reminder must not break commands
+func printReminders(c *cli.Context, storage Storage) {
+	if c.Bool(noRemindersFlag) {
+		return
+	}
+	due, ok := storage.(DueStorage)
+	if !ok {
+		return
+	}
+	settings, ok := storage.(SettingsStorage)
+	if !ok {
+		return
+	}
+
+	value, _, err := settings.GetSetting(configRemindersEnabled)
+	if err != nil {
+		return
+	}
+	if enabled, err := parseBoolSetting(value); err != nil || !enabled {
+		return
+	}
+
+	now := time.Now()
+	notes, err := due.GetDueNotes(time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		return
+	}
+
+	fmt.Fprint(errorWriter(c), reminderBanner(noteIDFormat(c), hideExpired(c, notes), now))
+}
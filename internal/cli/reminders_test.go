@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+type dueStorage struct {
+	settingsStorage
+}
+
+func (s *dueStorage) SetDueDate(noteID int, due time.Time) error {
+	for i := range s.notes {
+		if s.notes[i].ID == noteID {
+			s.notes[i].DueAt = &due
+		}
+	}
+
+	return nil
+}
+
+func (s *dueStorage) GetDueNotes(before time.Time) ([]entities.Note, error) {
+	var due []entities.Note
+	for _, note := range s.notes {
+		if note.DueAt != nil && note.DueAt.Before(before) {
+			due = append(due, note)
+		}
+	}
+
+	return due, nil
+}
+
+func TestReminderBanner(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		due := time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC)
+		return &due
+	}
+	notes := []entities.Note{
+		{ID: 1, Title: "Pay rent", DueAt: at(14, 9)},
+		{ID: 2, Title: "Call mom", DueAt: at(15, 18)},
+		{ID: 3, Title: "Next week", DueAt: at(22, 9)},
+	}
+
+	expected := "Reminders:\n" +
+		"  Overdue: ID: 1, Title: Pay rent, Due: 2024-03-14 09:00\n" +
+		"  Due today: ID: 2, Title: Call mom, Due: 2024-03-15 18:00\n"
+	if banner := reminderBanner(idFormatDecimal, notes, now); banner != expected {
+		t.Errorf("Expected banner %q, got %q", expected, banner)
+	}
+
+	if banner := reminderBanner(idFormatDecimal, notes[2:], now); banner != "" {
+		t.Errorf("Expected no banner, got %q", banner)
+	}
+}
+
+func TestRemindersBeforeCommands(t *testing.T) {
+	storage := &dueStorage{}
+	id, _ := storage.NewNote("Pay rent", "Before Friday")
+	_ = storage.SetDueDate(id, time.Now().Add(-time.Hour))
+
+	app := NewCLI(storage)
+	var out, errOut bytes.Buffer
+	app.Writer, app.ErrWriter = &out, &errOut
+
+	run := func(args ...string) string {
+		errOut.Reset()
+		if err := app.Run(append([]string{"go-notes"}, args...)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return errOut.String()
+	}
+
+	// reminders are off until enabled
+	if banner := run("list"); banner != "" {
+		t.Errorf("Expected no banner, got %q", banner)
+	}
+
+	_ = storage.SetSetting(configRemindersEnabled, "true")
+	if banner := run("list"); !strings.Contains(banner, "Overdue: ID: 1, Title: Pay rent") {
+		t.Errorf("Expected the overdue note in banner, got %q", banner)
+	}
+	for _, flag := range []string{"--no-reminders", "--quiet"} {
+		if banner := run(flag, "list"); banner != "" {
+			t.Errorf("Expected %s to suppress the banner, got %q", flag, banner)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// RetitleStorage is implemented by storages able to change titles of many notes at once
+type RetitleStorage interface {
+	// RetitleNotes changes titles of notes whose title contains match to the result of retitle in a single transaction
+	// and returns the result of every changed title keyed by note ID
+	RetitleNotes(match string, retitle func(title string) string) (map[int]error, error)
+}
+
+// retitleCommand creates new CLI command for changing titles of all notes matching a pattern
+func retitleCommand(storage RetitleStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "retitle"
+		commandUsage = "Add a prefix or a suffix to titles of notes containing a text, or replace text in them"
+	)
+
+	// create a new CLI command configuration
+	retitle := cli.Command{
+		Name:  commandName,  // name of command (e.g., "retitle")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "match", Usage: "change titles containing this text, ignoring case"},
+			cli.StringFlag{Name: "prefix", Usage: "text to add before titles"},
+			cli.StringFlag{Name: "suffix", Usage: "text to add after titles"},
+			cli.StringFlag{Name: "replace", Usage: "replace text in titles, given as old=new"},
+		},
+		Action: func(c *cli.Context) error {
+			match := c.String("match")
+			if match == "" {
+				return missingArg(c, "Please provide a text titles must contain with --match.")
+			}
+
+			transform, err := titleTransform(c.String("prefix"), c.String("suffix"), c.String("replace"))
+			if err != nil {
+				return err
+			}
+			if transform == nil {
+				return missingArg(c, "Please provide --prefix, --suffix or --replace.")
+			}
+
+			results, err := storage.RetitleNotes(match, transform)
+			if err != nil {
+				return fmt.Errorf("retitling notes: %w", err)
+			}
+
+			// report failures in ID order
+			noteIDs := make([]int, 0, len(results))
+			for id := range results {
+				noteIDs = append(noteIDs, id)
+			}
+			sort.Ints(noteIDs)
+
+			var failed int
+			for _, id := range noteIDs {
+				if results[id] != nil {
+					failed++
+					fmt.Fprintf(c.App.Writer, "Note %s: failed: %v\n", noteIDFormat(c).Format(id), results[id])
+				}
+			}
+
+			fmt.Fprintf(c.App.Writer, "Retitled %d note(s), %d failed\n", len(results)-failed, failed)
+			if failed > 0 {
+				return errors.New("some notes were not retitled")
+			}
+
+			return nil
+		},
+	}
+
+	return retitle
+}
+
+// titleTransform returns a function replacing text given as old=new in a title and adding prefix and suffix to it.
+// It returns nil if there is nothing to change
+func titleTransform(prefix, suffix, replace string) (func(title string) string, error) {
+	var oldText, newText string
+	if replace != "" {
+		var ok bool
+		if oldText, newText, ok = strings.Cut(replace, "="); !ok || oldText == "" {
+			return nil, fmt.Errorf("invalid replacement %q, expected old=new", replace)
+		}
+	}
+
+	if prefix == "" && suffix == "" && oldText == "" {
+		return nil, nil
+	}
+
+	return func(title string) string {
+		if oldText != "" {
+			title = strings.ReplaceAll(title, oldText, newText)
+		}
+
+		return prefix + title + suffix
+	}, nil
+}
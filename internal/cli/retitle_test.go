@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestTitleTransform(t *testing.T) {
+	transform, err := titleTransform("[Archived] ", " (2023)", "Meeting=Call")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if title := transform("Meeting with Anna"); title != "[Archived] Call with Anna (2023)" {
+		t.Errorf("Expected replaced, prefixed and suffixed title, got %q", title)
+	}
+
+	// the replacement may remove text
+	transform, _ = titleTransform("", "", "Draft: =")
+	if title := transform("Draft: Plan"); title != "Plan" {
+		t.Errorf("Expected removed text, got %q", title)
+	}
+
+	if transform, err = titleTransform("", "", ""); transform != nil || err != nil {
+		t.Errorf("Expected no transform without changes, got error %v", err)
+	}
+	for _, replace := range []string{"Meeting", "=Call"} {
+		if _, err = titleTransform("", "", replace); err == nil {
+			t.Errorf("Expected an error for replacement %q", replace)
+		}
+	}
+}
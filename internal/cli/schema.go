@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/storage"
+)
+
+// SchemaStorage is implemented by storages able to describe their database schema
+type SchemaStorage interface {
+	// GetSchema retrieves statements creating the schema and the migration version
+	GetSchema() (storage.Schema, error)
+}
+
+// schemaCommand creates new CLI command for printing the database schema
+func schemaCommand(schemaStorage SchemaStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "schema"
+		commandUsage = "Print statements creating tables, indexes and triggers, and the migration version"
+	)
+
+	// create a new CLI command configuration
+	schema := cli.Command{
+		Name:  commandName,  // name of command (e.g., "schema")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			s, err := schemaStorage.GetSchema()
+			if err != nil {
+				return fmt.Errorf("retrieving schema: %w", err)
+			}
+
+			// the output is valid SQL, so the version is a comment
+			fmt.Fprintf(c.App.Writer, "-- migration version: %d\n", s.Version)
+			for _, object := range s.Objects {
+				fmt.Fprintf(c.App.Writer, "\n%s;\n", object.SQL)
+			}
+
+			return nil
+		},
+	}
+
+	return schema
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"go-notes/internal/storage"
+)
+
+type schemaStorage struct {
+	fakeStorage
+}
+
+func (s *schemaStorage) GetSchema() (storage.Schema, error) {
+	return storage.Schema{Version: 3, Objects: []storage.SchemaObject{
+		{Type: "table", Name: "notes", SQL: "CREATE TABLE notes (note_id INTEGER PRIMARY KEY)"},
+		{Type: "trigger", Name: "update_last_edited_at", SQL: "CREATE TRIGGER update_last_edited_at AFTER UPDATE ON notes BEGIN SELECT 1; END"},
+	}}, nil
+}
+
+func TestSchema(t *testing.T) {
+	app := NewCLI(&schemaStorage{})
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "schema"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "-- migration version: 3\n\n" +
+		"CREATE TABLE notes (note_id INTEGER PRIMARY KEY);\n\n" +
+		"CREATE TRIGGER update_last_edited_at AFTER UPDATE ON notes BEGIN SELECT 1; END;\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
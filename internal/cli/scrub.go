@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// ScrubStorage is implemented by storages able to wipe a field of all notes at once
+type ScrubStorage interface {
+	// ScrubContent empties content of every note and returns the number of notes which had content
+	ScrubContent() (int, error)
+
+	// ScrubTitles replaces the title of every note with a placeholder and returns the number of replaced titles
+	ScrubTitles() (int, error)
+}
+
+// fields of notes scrub can wipe
+const (
+	scrubContent = "content"
+	scrubTitle   = "title"
+)
+
+// confirmInput is where answers to confirmation questions are read from, replaced in tests
+var confirmInput io.Reader = os.Stdin
+
+// confirm asks the question on the output of the command and reports whether it was answered with yes
+func confirm(c *cli.Context, question string) (bool, error) {
+	fmt.Fprintf(c.App.Writer, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// scrubCommand creates new CLI command for wiping content or titles of all notes
+func scrubCommand(storage ScrubStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "scrub"
+		commandUsage = "Wipe content and revisions of all notes, or replace all titles with placeholders, including notes in the trash"
+	)
+
+	// create a new CLI command configuration
+	scrub := cli.Command{
+		Name:  commandName,  // name of command (e.g., "scrub")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "field", Usage: `field to wipe: content, or title which is replaced with "Note <ID>"`},
+			cli.BoolFlag{Name: "yes", Usage: "don't ask for confirmation"},
+		},
+		Action: func(c *cli.Context) error {
+			field := c.String("field")
+			scrubField := map[string]func() (int, error){
+				scrubContent: storage.ScrubContent,
+				scrubTitle:   storage.ScrubTitles,
+			}[field]
+			if field == "" {
+				return missingArg(c, "Please provide the field to scrub with --field content or --field title.")
+			}
+			if scrubField == nil {
+				return fmt.Errorf("unknown field to scrub: %s", field)
+			}
+
+			if !c.Bool("yes") {
+				ok, err := confirm(c, fmt.Sprintf("Scrub %s of all notes? This can't be undone.", field))
+				if err != nil {
+					return fmt.Errorf("reading confirmation: %w", err)
+				}
+				if !ok {
+					fmt.Fprintln(c.App.Writer, "Nothing was scrubbed.")
+					return nil
+				}
+			}
+
+			scrubbed, err := scrubField()
+			if err != nil {
+				return fmt.Errorf("scrubbing %s: %w", field, err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Scrubbed %s of %d note(s)\n", field, scrubbed)
+
+			return nil
+		},
+	}
+
+	return scrub
+}
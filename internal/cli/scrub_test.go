@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// scrubStorage is a fake storage wiping fields of its notes
+type scrubStorage struct {
+	fakeStorage
+}
+
+func (s *scrubStorage) ScrubContent() (int, error) {
+	for i := range s.notes {
+		s.notes[i].Content = ""
+	}
+
+	return len(s.notes), nil
+}
+
+func (s *scrubStorage) ScrubTitles() (int, error) {
+	return 0, nil
+}
+
+// stubConfirmInput answers confirmation questions with input for the duration of the test
+func stubConfirmInput(t *testing.T, input string) {
+	t.Helper()
+
+	original := confirmInput
+	confirmInput = strings.NewReader(input)
+	t.Cleanup(func() { confirmInput = original })
+}
+
+func TestScrubContent(t *testing.T) {
+	storage := &scrubStorage{}
+	_, _ = storage.NewNote("Diary", "private thoughts")
+	_, _ = storage.NewNote("Passwords", "hunter2")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	// declining leaves notes as they are
+	stubConfirmInput(t, "n\n")
+	if err := app.Run([]string{"go-notes", "scrub", "--field", "content"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if storage.notes[0].Content == "" || !strings.Contains(out.String(), "Nothing was scrubbed.") {
+		t.Fatalf("Expected content to be kept, got %q with output %q", storage.notes[0].Content, out.String())
+	}
+
+	out.Reset()
+	stubConfirmInput(t, "y\n")
+	if err := app.Run([]string{"go-notes", "scrub", "--field", "content"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, note := range storage.notes {
+		if note.Content != "" || note.Title == "" {
+			t.Errorf("Expected empty content and kept title, got %+v", note)
+		}
+	}
+	if !strings.Contains(out.String(), "Scrubbed content of 2 note(s)") {
+		t.Errorf("Expected scrubbed count, got %q", out.String())
+	}
+
+	if err := app.Run([]string{"go-notes", "scrub", "--field", "tags", "--yes"}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
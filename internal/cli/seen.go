@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// SeenStorage is implemented by storages able to remember when notes were last viewed
+type SeenStorage interface {
+	// MarkSeen stores the time notes were last viewed at
+	MarkSeen(at time.Time) error
+
+	// GetUnseenNotes retrieves notes created or edited after the time stored by MarkSeen
+	GetUnseenNotes() ([]entities.Note, error)
+}
+
+// markSeenCommand creates new CLI command for marking all current notes as seen
+func markSeenCommand(storage SeenStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "mark-seen"
+		commandUsage = "Mark all notes as seen, so unseen lists only later changes"
+	)
+
+	// create a new CLI command configuration
+	markSeen := cli.Command{
+		Name:  commandName,  // name of command (e.g., "mark-seen")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			if err := storage.MarkSeen(time.Now()); err != nil {
+				return fmt.Errorf("marking notes as seen: %w", err)
+			}
+
+			fmt.Fprintln(c.App.Writer, "Marked all notes as seen")
+
+			return nil
+		},
+	}
+
+	return markSeen
+}
+
+// unseenCommand creates new CLI command for listing notes changed since they were last marked as seen
+func unseenCommand(storage SeenStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "unseen"
+		commandUsage = "List notes created or edited since the last mark-seen"
+	)
+
+	// create a new CLI command configuration
+	unseen := cli.Command{
+		Name:  commandName,  // name of command (e.g., "unseen")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			notes, err := storage.GetUnseenNotes()
+			if err != nil {
+				return fmt.Errorf("retrieving unseen notes: %w", err)
+			}
+
+			if len(notes) == 0 {
+				fmt.Fprintln(c.App.Writer, "No unseen notes")
+				return nil
+			}
+
+			printNoteList(c.App.Writer, noteIDFormat(c), notes, -1)
+
+			return nil
+		},
+	}
+
+	return unseen
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/server"
+)
+
+// serveCommand creates new CLI command for serving notes over a REST API
+func serveCommand(storage server.Storage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "serve"
+		commandUsage = "Serve notes over a REST API"
+	)
+
+	// create a new CLI command configuration
+	serve := cli.Command{
+		Name:  commandName,  // name of command (e.g., "serve")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "addr", Value: "localhost:8080", Usage: "address to listen on"},
+		},
+		Action: func(c *cli.Context) error {
+			addr := c.String("addr")
+
+			// links of share work only if a secret is configured, share creates one on first use
+			var opts []server.Option
+			if settings, ok := storage.(SettingsStorage); ok {
+				secret, err := shareSecret(settings, false)
+				if err != nil {
+					return fmt.Errorf("reading share secret: %w", err)
+				}
+				if len(secret) > 0 {
+					opts = append(opts, server.WithShareSecret(secret))
+				}
+			}
+
+			fmt.Fprintf(c.App.Writer, "Serving notes on http://%s\n", addr)
+
+			// serve until the process is interrupted
+			return http.ListenAndServe(addr, server.New(storage, opts...))
+		},
+	}
+
+	return serve
+}
+
+// specCommand creates new CLI command for printing the OpenAPI document of the REST API
+func specCommand() cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "spec"
+		commandUsage = "Print OpenAPI document describing the REST API of 'serve'"
+	)
+
+	// create a new CLI command configuration
+	spec := cli.Command{
+		Name:  commandName,  // name of command (e.g., "spec")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			doc, err := server.Spec()
+			if err != nil {
+				return fmt.Errorf("generating spec: %w", err)
+			}
+
+			fmt.Fprintln(c.App.Writer, string(doc))
+
+			return nil
+		},
+	}
+
+	return spec
+}
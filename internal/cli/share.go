@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/server"
+)
+
+// minShareSecretLength is the minimum length of share.secret set with config, shorter secrets are easy to guess
+const minShareSecretLength = 16
+
+// parseShareSecret validates a value of share.secret, empty value makes share generate a new secret
+func parseShareSecret(value string) error {
+	if value != "" && len(value) < minShareSecretLength {
+		return fmt.Errorf("share secret must have at least %d characters", minShareSecretLength)
+	}
+
+	return nil
+}
+
+// shareSecret reads the secret signing share tokens, generating and storing a random one if create is set.
+// Nil is returned if no secret is configured and create is not set
+func shareSecret(settings SettingsStorage, create bool) ([]byte, error) {
+	secret, _, err := settings.GetSetting(configShareSecret)
+	if err != nil || secret != "" || !create {
+		return []byte(secret), err
+	}
+
+	random := make([]byte, 32)
+	if _, err = rand.Read(random); err != nil {
+		return nil, err
+	}
+	secret = hex.EncodeToString(random)
+
+	if err = settings.SetSetting(configShareSecret, secret); err != nil {
+		return nil, err
+	}
+
+	return []byte(secret), nil
+}
+
+// shareCommand creates new CLI command for sharing a single note read-only through 'serve'
+func shareCommand(storage Storage, settings SettingsStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "share"
+		commandUsage = "Print a link sharing a note read-only while 'serve' is running"
+	)
+
+	// create a new CLI command configuration
+	share := cli.Command{
+		Name:      commandName,  // name of command (e.g., "share")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Flags: []cli.Flag{
+			cli.DurationFlag{Name: "expires", Usage: "time the link is valid for, e.g. 24h, it never expires by default"},
+			cli.StringFlag{Name: "addr", Value: "localhost:8080", Usage: "address 'serve' listens on"},
+		},
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note.")
+			if !ok || err != nil {
+				return err
+			}
+
+			expires := c.Duration("expires")
+			if expires < 0 {
+				return fmt.Errorf("invalid expiry: %s", expires)
+			}
+
+			// only existing notes are shared, though a link of a deleted note stops working anyway
+			if _, err = storage.GetNoteByID(noteID); err != nil {
+				return fmt.Errorf("retrieving note: %w", err)
+			}
+
+			secret, err := shareSecret(settings, true)
+			if err != nil {
+				return fmt.Errorf("reading share secret: %w", err)
+			}
+
+			var expiresAt time.Time
+			if expires > 0 {
+				expiresAt = time.Now().Add(expires)
+			}
+			token := server.SignShareToken(secret, noteID, expiresAt)
+
+			fmt.Fprintf(c.App.Writer, "http://%s/shared/%s\n", c.String("addr"), token)
+
+			return nil
+		},
+	}
+
+	return share
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// SizeStorage is implemented by storages able to order notes by content length
+type SizeStorage interface {
+	// GetLongestNotes retrieves up to n notes with the longest content in bytes
+	GetLongestNotes(n int) ([]entities.Note, error)
+
+	// GetShortestNotes retrieves up to n notes with the shortest content in bytes
+	GetShortestNotes(n int) ([]entities.Note, error)
+}
+
+// sizeCommand creates new CLI command for listing the biggest or the smallest notes
+func sizeCommand(storage SizeStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "size"
+		commandUsage = "List the longest (--top N) or the shortest (--bottom N) notes by content size in bytes"
+	)
+
+	// create a new CLI command configuration
+	size := cli.Command{
+		Name:  commandName,  // name of command (e.g., "size")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.IntFlag{Name: "top", Usage: "number of the longest notes to list"},
+			cli.IntFlag{Name: "bottom", Usage: "number of the shortest notes to list"},
+		},
+		Action: func(c *cli.Context) error {
+			// exactly one of --top and --bottom must be set
+			top, bottom := c.Int("top"), c.Int("bottom")
+			if (top > 0) == (bottom > 0) {
+				return missingArg(c, "Please provide either --top N or --bottom N.")
+			}
+
+			var (
+				notes []entities.Note
+				err   error
+			)
+			if top > 0 {
+				notes, err = storage.GetLongestNotes(top)
+			} else {
+				notes, err = storage.GetShortestNotes(bottom)
+			}
+			if err != nil {
+				return fmt.Errorf("listing notes by size: %w", err)
+			}
+
+			// print notes with their content size, which they are ordered by
+			for _, note := range notes {
+				fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, Bytes: %d\n",
+					noteIDFormat(c).Format(note.ID), note.Title, len(note.Content))
+			}
+
+			return nil
+		},
+	}
+
+	return size
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+// sizeStorage lists notes in the order they were given, together with a breakdown
+type sizeStorage struct {
+	fakeStorage
+	labels []entities.LabelSize
+}
+
+func (s *sizeStorage) GetLongestNotes(n int) ([]entities.Note, error) {
+	return s.notes[:min(n, len(s.notes))], nil
+}
+
+func (s *sizeStorage) GetShortestNotes(n int) ([]entities.Note, error) {
+	return s.notes[:min(n, len(s.notes))], nil
+}
+
+func (s *sizeStorage) GetTagBreakdown() ([]entities.LabelSize, error) {
+	return s.labels, nil
+}
+
+func (s *sizeStorage) GetMetaBreakdown(string) ([]entities.LabelSize, error) {
+	return s.labels, nil
+}
+
+func TestSizeInBytes(t *testing.T) {
+	// five characters taking ten bytes
+	storage := &sizeStorage{fakeStorage: fakeStorage{notes: []entities.Note{{ID: 1, Title: "Accents", Content: "ééééé"}}}}
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "size", "--top", "1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out.String() != "ID: 1, Title: Accents, Bytes: 10\n" {
+		t.Errorf("Expected the size in bytes, got %q", out.String())
+	}
+}
+
+func TestBreakdownInBytes(t *testing.T) {
+	storage := &sizeStorage{labels: []entities.LabelSize{{Label: "work", Notes: 2, Bytes: 15}}}
+
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "breakdown"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if header := strings.Fields(strings.SplitN(out.String(), "\n", 2)[0]); len(header) != 3 || header[2] != "BYTES" {
+		t.Errorf("Expected the size column named BYTES, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"go-notes", "breakdown", "--json"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), `"bytes": 15`) {
+		t.Errorf("Expected the size in bytes, got %q", out.String())
+	}
+}
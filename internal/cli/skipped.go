@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/storage"
+)
+
+// warnSkippedNotes prints a warning about notes storage couldn't read and clears the error,
+// so the notes which were read are still printed. Other errors are returned as is
+func warnSkippedNotes(c *cli.Context, err error) error {
+	if !errors.Is(err, storage.ErrSkippedNotes) {
+		return err
+	}
+
+	fmt.Fprintf(errorWriter(c), "Warning: %v\n", err)
+
+	return nil
+}
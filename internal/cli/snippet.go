@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+)
+
+// defaultPreviewLength is the default number of characters of content shown by list and search
+const defaultPreviewLength = 80
+
+// preview shortens content to length runes around the first match of keyword, 0 keeps the full content
+func preview(content, keyword string, length int) string {
+	if length == 0 {
+		return content
+	}
+
+	return snippet(content, keyword, length)
+}
+
+// snippet returns a window of up to width runes of content around the first case-insensitive match of keyword.
+// Cut off parts are marked with ellipses, content without a match is shown from the start
+func snippet(content, keyword string, width int) string {
+	// keep the snippet on a single line
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= width {
+		return string(runes)
+	}
+
+	// center the window on the match, the start of content is shown if nothing matches
+	start := 0
+	if idx, matchLen := indexFold(runes, []rune(keyword)); idx >= 0 {
+		start = idx - (width-matchLen)/2
+	}
+	start = max(0, min(start, len(runes)-width))
+	end := start + width
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	sb.WriteString(string(runes[start:end]))
+	if end < len(runes) {
+		sb.WriteString("…")
+	}
+
+	return sb.String()
+}
+
+// indexFold returns the rune index and length of the first case-insensitive occurrence of keyword in runes, or -1
+func indexFold(runes, keyword []rune) (int, int) {
+	if len(keyword) == 0 {
+		return -1, 0
+	}
+
+	for i := 0; i+len(keyword) <= len(runes); i++ {
+		if strings.EqualFold(string(runes[i:i+len(keyword)]), string(keyword)) {
+			return i, len(keyword)
+		}
+	}
+
+	return -1, 0
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSnippetShortContent(t *testing.T) {
+	got := snippet("Buy milk\nand bread", "milk", 20)
+	if got != "Buy milk and bread" {
+		t.Errorf("Expected whole content on one line, got %q", got)
+	}
+}
+
+func TestSnippetMatchNearStart(t *testing.T) {
+	content := "needle " + strings.Repeat("x", 50)
+
+	got := snippet(content, "NEEDLE", 20)
+	want := "needle " + strings.Repeat("x", 13) + "…"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSnippetMatchInMiddle(t *testing.T) {
+	content := strings.Repeat("a", 50) + "needle" + strings.Repeat("b", 50)
+
+	got := snippet(content, "needle", 20)
+	want := "…" + strings.Repeat("a", 7) + "needle" + strings.Repeat("b", 7) + "…"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSnippetMatchNearEnd(t *testing.T) {
+	content := strings.Repeat("x", 50) + " needle"
+
+	got := snippet(content, "needle", 20)
+	want := "…" + strings.Repeat("x", 13) + " needle"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSnippetMultibyteEdges(t *testing.T) {
+	// every character takes several bytes, so byte slicing would cut them in half
+	content := strings.Repeat("ж", 30) + "ключ" + strings.Repeat("😀", 30)
+
+	got := snippet(content, "КЛЮЧ", 10)
+	if !utf8.ValidString(got) {
+		t.Fatalf("Expected valid UTF-8, got %q", got)
+	}
+
+	want := "…" + strings.Repeat("ж", 3) + "ключ" + strings.Repeat("😀", 3) + "…"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestPreviewLength(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Long", strings.Repeat("word ", 40))
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"go-notes", "list", "--content", "--preview", "9"}, "Content: word word…,"},
+		{[]string{"go-notes", "search", "--preview", "9", "word"}, "Content: word word…,"},
+		// 0 shows full content
+		{[]string{"go-notes", "list", "--content", "--preview", "0"}, "Content: " + strings.Repeat("word ", 40) + ","},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		app := NewCLI(storage)
+		app.Writer = &out
+
+		if err := app.Run(tt.args); err != nil {
+			t.Fatalf("Expected no error for %v, got %v", tt.args, err)
+		}
+
+		if !strings.Contains(out.String(), tt.want) {
+			t.Errorf("Expected output of %v to contain %q, got %q", tt.args, tt.want, out.String())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// StreamSearchStorage is implemented by storages able to pass search results on as they are found
+type StreamSearchStorage interface {
+	// SearchNotesStream calls fn with every note containing the keyword, stopping at the first error of fn
+	SearchNotesStream(keyword string, fn func(note entities.Note) error) error
+}
+
+// canStreamSearch reports whether search results can be printed while searching: only plain keyword search
+// printed as text or IDs streams, as other formats, --sort and --clipboard need all results at once
+func canStreamSearch(c *cli.Context, output string) bool {
+	return output == outputText && !c.Bool(clipboardFlag.Name) && !c.Bool("include-tags") &&
+		!c.Bool("title-only") && c.Int("recent-days") == 0 && c.String("sort") == ""
+}
+
+// streamSearch prints notes containing the keyword as soon as storage finds them, in the same format
+// search prints collected results in
+func streamSearch(c *cli.Context, storage StreamSearchStorage, keyword string, previewLength int) error {
+	ids := noteIDFormat(c)
+	idsOnly := c.Bool("ids-only")
+
+	found := 0
+	err := storage.SearchNotesStream(keyword, func(note entities.Note) error {
+		if len(hideExpired(c, []entities.Note{note})) == 0 {
+			return nil
+		}
+
+		if idsOnly {
+			_, err := fmt.Fprintln(c.App.Writer, ids.Format(note.ID))
+			return err
+		}
+
+		// the header is printed with the first note, so nothing but the final message is printed without results
+		if found++; found == 1 {
+			fmt.Fprintf(c.App.Writer, "Notes found for keyword '%s':\n", keyword)
+		}
+		return printSearchResult(c.App.Writer, ids, note, keyword, previewLength)
+	})
+	if err != nil {
+		return fmt.Errorf("searching notes: %w", err)
+	}
+
+	if found == 0 && !idsOnly {
+		fmt.Fprintf(c.App.Writer, "No notes found for keyword: %s\n", keyword)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+type streamStorage struct {
+	fakeStorage
+	streamed int
+}
+
+func (s *streamStorage) SearchNotesStream(keyword string, fn func(note entities.Note) error) error {
+	notes, _ := s.SearchNotesByKeyword(keyword)
+	for _, note := range notes {
+		s.streamed++
+		if err := fn(note); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestStreamSearchOutput(t *testing.T) {
+	collected, streamed := &fakeStorage{}, &streamStorage{}
+	for _, s := range []Storage{collected, streamed} {
+		_, _ = s.NewNote("Groceries", "Buy bread")
+		_, _ = s.NewNote("Bakery", "bread and rolls")
+		_, _ = s.NewNote("Meeting", "Agenda")
+	}
+
+	// streamed results are printed exactly like collected ones
+	for _, args := range [][]string{{"bread"}, {"--ids-only", "bread"}, {"nothing"}} {
+		run := func(s Storage) string {
+			var out bytes.Buffer
+			app := NewCLI(s)
+			app.Writer = &out
+			if err := app.Run(append([]string{"go-notes", "search"}, args...)); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			return out.String()
+		}
+
+		if want, got := run(collected), run(streamed); got != want {
+			t.Errorf("Expected streamed output of %v to be %q, got %q", args, want, got)
+		}
+	}
+	if streamed.streamed == 0 {
+		t.Error("Expected search to use streaming")
+	}
+}
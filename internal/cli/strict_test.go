@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStrictMissingArgument(t *testing.T) {
+	storage := &settingsStorage{}
+	app := NewCLI(storage)
+	var out bytes.Buffer
+	app.Writer = &out
+
+	// by default a missing title is only a hint
+	if err := app.Run([]string{"go-notes", "new"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "Please provide a title for new note.") {
+		t.Errorf("Expected a hint, got %q", out.String())
+	}
+
+	err := app.Run([]string{"go-notes", "--strict", "new"})
+	if !errors.Is(err, errMissingArgument) {
+		t.Errorf("Expected errMissingArgument under --strict, got %v", err)
+	}
+
+	// subcommands see the global flag too
+	err = app.Run([]string{"go-notes", "--strict", "config", "set"})
+	if !errors.Is(err, errMissingArgument) {
+		t.Errorf("Expected errMissingArgument for a subcommand under --strict, got %v", err)
+	}
+
+	if len(storage.notes) != 0 {
+		t.Errorf("Expected no notes to be created, got %+v", storage.notes)
+	}
+}
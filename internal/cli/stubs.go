@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// StubStorage is implemented by storages able to find notes without content
+type StubStorage interface {
+	// GetEmptyNotes retrieves notes whose content is missing or empty
+	GetEmptyNotes() ([]entities.Note, error)
+}
+
+// stubsCommand creates new CLI command for listing placeholder notes which were never filled in
+func stubsCommand(storage StubStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "stubs"
+		commandUsage = "List notes with no content"
+	)
+
+	// create a new CLI command configuration
+	stubs := cli.Command{
+		Name:  commandName,  // name of command (e.g., "stubs")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			notes, err := storage.GetEmptyNotes()
+			if err != nil {
+				return fmt.Errorf("listing empty notes: %w", err)
+			}
+
+			if len(notes) == 0 {
+				fmt.Fprintln(c.App.Writer, "No empty notes found")
+				return nil
+			}
+
+			for _, note := range notes {
+				fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, CreatedAt: %s\n", noteIDFormat(c).Format(note.ID), note.Title, note.CreatedAt)
+			}
+
+			return nil
+		},
+	}
+
+	return stubs
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// SwapStorage is implemented by storages able to exchange title and content of a note
+type SwapStorage interface {
+	// SwapTitleContent exchanges the title and content of a note, rejecting notes with empty content
+	SwapTitleContent(noteID int) error
+}
+
+// swapCommand creates new CLI command for exchanging title and content of a note
+func swapCommand(storage SwapStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "swap"
+		commandUsage = "Exchange title and content of a note"
+	)
+
+	// create a new CLI command configuration
+	swap := cli.Command{
+		Name:      commandName,  // name of command (e.g., "swap")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to swap title and content of.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if err = storage.SwapTitleContent(noteID); err != nil {
+				return fmt.Errorf("swapping title and content: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Swapped title and content of note with ID %s\n", noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return swap
+}
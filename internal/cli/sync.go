@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/syncer"
+)
+
+// syncCommand creates new CLI command for synchronizing notes with a remote server
+func syncCommand(storage syncer.Local) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "sync"
+		commandUsage = "Synchronize notes with a remote server started by 'serve'"
+	)
+
+	// both subcommands need the remote URL
+	urlFlag := cli.StringFlag{Name: "url", Usage: "URL of the remote server, e.g. http://host:8080"}
+
+	// run executes push or pull and prints the result
+	run := func(c *cli.Context, direction string, sync func(*syncer.Client) (syncer.Result, error)) error {
+		url := c.String("url")
+		if url == "" {
+			return missingArg(c, "Please provide URL of the remote server with --url.")
+		}
+
+		result, err := sync(syncer.New(storage, url, nil))
+		if err != nil {
+			return fmt.Errorf("%s: %w", direction, err)
+		}
+
+		fmt.Fprintf(c.App.Writer, "Transferred %d note(s)\n", result.Transferred)
+		for _, id := range result.Conflicts {
+			fmt.Fprintf(c.App.Writer, "Conflict: note with ID %s differs on both sides, resolve it manually\n", noteIDFormat(c).Format(id))
+		}
+
+		return nil
+	}
+
+	// create a new CLI command configuration
+	sync := cli.Command{
+		Name:  commandName,  // name of command (e.g., "sync")
+		Usage: commandUsage, // description of command
+		Subcommands: []cli.Command{
+			{
+				Name:  "push",
+				Usage: "Send notes created since the last push to the remote",
+				Flags: []cli.Flag{urlFlag},
+				Action: func(c *cli.Context) error {
+					return run(c, "pushing notes", (*syncer.Client).Push)
+				},
+			},
+			{
+				Name:  "pull",
+				Usage: "Fetch notes created on the remote since the last pull",
+				Flags: []cli.Flag{urlFlag},
+				Action: func(c *cli.Context) error {
+					return run(c, "pulling notes", (*syncer.Client).Pull)
+				},
+			},
+		},
+	}
+
+	return sync
+}
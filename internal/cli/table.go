@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// output formats of list, search and get
+const (
+	outputText  = "text"
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// outputFlag selects the output format of list, search and get
+var outputFlag = cli.StringFlag{Name: "output", Value: outputText, Usage: "output format: text, table, json or yaml"}
+
+// maxTableTitleWidth is the number of columns titles are truncated to in tables
+const maxTableTitleWidth = 40
+
+// outputFormat returns the format given with --output, rejecting unknown ones
+func outputFormat(c *cli.Context) (string, error) {
+	switch format := c.String(outputFlag.Name); format {
+	case outputText, outputTable, outputJSON, outputYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// printNoteTable prints the columns of notes as a table with a header, long titles are truncated.
+// Content is shortened to previewLength characters around keyword, 0 keeps full content
+func printNoteTable(w io.Writer, storage Storage, ids idFormat, notes []entities.Note, columns []string, keyword string, previewLength int) error {
+	rows, err := noteRows(storage, ids, notes, columns, keyword, previewLength)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for i, column := range columns {
+			if column == columnTitle {
+				row[i] = truncateWidth(row[i], maxTableTitleWidth)
+			}
+		}
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(column)
+	}
+
+	renderTable(w, headers, rows)
+
+	return nil
+}
+
+// renderTable prints rows under headers with columns aligned to the widest value and a line under the headers
+func renderTable(w io.Writer, headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = displayWidth(header)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], displayWidth(value))
+		}
+	}
+
+	separators := make([]string, len(headers))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+
+	writeTableRow(w, headers, widths)
+	writeTableRow(w, separators, widths)
+	for _, row := range rows {
+		writeTableRow(w, row, widths)
+	}
+}
+
+// writeTableRow prints values padded to widths and separated by two spaces, the last value is not padded
+func writeTableRow(w io.Writer, values []string, widths []int) {
+	var sb strings.Builder
+	for i, value := range values {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+
+		sb.WriteString(value)
+		if i < len(values)-1 {
+			sb.WriteString(strings.Repeat(" ", widths[i]-displayWidth(value)))
+		}
+	}
+
+	fmt.Fprintln(w, sb.String())
+}
+
+// truncateWidth shortens s to at most width terminal columns, marking the cut with an ellipsis
+func truncateWidth(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+
+	var (
+		sb  strings.Builder
+		col int
+	)
+	for _, r := range s {
+		// leave room for the ellipsis
+		if col+runeWidth(r) > width-1 {
+			break
+		}
+
+		sb.WriteRune(r)
+		col += runeWidth(r)
+	}
+	sb.WriteString("…")
+
+	return sb.String()
+}
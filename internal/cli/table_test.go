@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderTableAlignment(t *testing.T) {
+	var out bytes.Buffer
+	renderTable(&out, []string{"ID", "TITLE", "CREATED"}, [][]string{
+		{"1", "Milk", "2024-01-02"},
+		{"12", "A much longer title", "2024-01-03"},
+		{"3", "日本語", "2024-01-04"},
+	})
+
+	want := strings.Join([]string{
+		"ID  TITLE                CREATED",
+		"--  -------------------  ----------",
+		"1   Milk                 2024-01-02",
+		"12  A much longer title  2024-01-03",
+		"3   日本語               2024-01-04",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("Expected table\n%s\ngot\n%s", want, out.String())
+	}
+}
+
+func TestPrintNoteTableTruncatesTitles(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote(strings.Repeat("x", maxTableTitleWidth+10), "Long title.")
+	_, _ = storage.NewNote("Short", "Short title.")
+
+	var out bytes.Buffer
+	if err := printNoteTable(&out, storage, idFormatDecimal, storage.notes, []string{columnTitle, columnID}, "", 0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header, separator and 2 rows, got %q", out.String())
+	}
+
+	// IDs start in the same column on every line, right after the truncated title
+	truncated := strings.Repeat("x", maxTableTitleWidth-1) + "…"
+	if lines[2] != truncated+"  1" {
+		t.Errorf("Expected truncated title, got %q", lines[2])
+	}
+	if want := "Short" + strings.Repeat(" ", maxTableTitleWidth-5) + "  2"; lines[3] != want {
+		t.Errorf("Expected %q, got %q", want, lines[3])
+	}
+}
+
+func TestListOutputTable(t *testing.T) {
+	storage := &fakeStorage{}
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+
+	var out bytes.Buffer
+	app := NewCLI(storage)
+	app.Writer = &out
+
+	if err := app.Run([]string{"go-notes", "list", "--output", "table"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "ID  TITLE      CREATED") {
+		t.Errorf("Expected a table, got %q", out.String())
+	}
+
+	if err := app.Run([]string{"go-notes", "list", "--output", "xml"}); err == nil {
+		t.Errorf("Expected error for an unknown output format, got nil")
+	}
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+)
+
+// TagStorage is implemented by storages supporting note tags
+type TagStorage interface {
+	// AddTag attaches a tag to the note with the specified ID
+	AddTag(noteID int, tag string) error
+
+	// RemoveTag detaches a tag from the note with the specified ID
+	RemoveTag(noteID int, tag string) error
+
+	// GetNoteTags retrieves tags of the note with the specified ID
+	GetNoteTags(noteID int) ([]string, error)
+
+	// TagNotesByKeyword attaches a tag to every note matching the keyword and returns number of affected notes
+	TagNotesByKeyword(keyword, tag string) (int, error)
+
+	// UntagNotesByKeyword detaches a tag from every note matching the keyword and returns number of affected notes
+	UntagNotesByKeyword(keyword, tag string) (int, error)
+
+	// RenameTag renames a tag on all notes, merging it into the new tag if that already exists
+	RenameTag(oldTag, newTag string) error
+
+	// DeleteTag removes a tag from all notes
+	DeleteTag(tag string) error
+
+	// SearchNotesIncludingTags searches for notes containing the keyword in titles, content or tags
+	SearchNotesIncludingTags(keyword string) ([]entities.Note, error)
+
+	// GetAllTags retrieves every tag with the number of notes having it, from the most used tag
+	GetAllTags() ([]entities.TagCount, error)
+}
+
+// tagCommand creates new CLI command for managing tags of a single note
+func tagCommand(storage TagStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "tag"
+		commandUsage = "Manage note tags"
+	)
+
+	// create a new CLI command configuration with a subcommand per operation
+	tag := cli.Command{
+		Name:  commandName,  // name of command (e.g., "tag")
+		Usage: commandUsage, // description of command
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Add a tag to a note",
+				ArgsUsage: "noteID tag",
+				Action: func(c *cli.Context) error {
+					noteID, tag, ok, err := noteIDAndTagArgs(c)
+					if !ok || err != nil {
+						return err
+					}
+
+					if err = storage.AddTag(noteID, tag); err != nil {
+						return fmt.Errorf("adding tag: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Tagged note with ID %s as '%s'\n", noteIDFormat(c).Format(noteID), tag)
+
+					return nil
+				},
+			},
+			{
+				Name:      "remove",
+				Usage:     "Remove a tag from a note",
+				ArgsUsage: "noteID tag",
+				Action: func(c *cli.Context) error {
+					noteID, tag, ok, err := noteIDAndTagArgs(c)
+					if !ok || err != nil {
+						return err
+					}
+
+					if err = storage.RemoveTag(noteID, tag); err != nil {
+						return fmt.Errorf("removing tag: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Removed tag '%s' from note with ID %s\n", tag, noteIDFormat(c).Format(noteID))
+
+					return nil
+				},
+			},
+			{
+				Name:      "list",
+				Usage:     "List tags of a note",
+				ArgsUsage: "noteID",
+				Action: func(c *cli.Context) error {
+					// retrieve first argument as note ID
+					noteIDStr := c.Args().First()
+					if noteIDStr == "" {
+						return missingArg(c, "Please provide ID of note.")
+					}
+
+					// convert note ID string to an integer
+					noteID, err := noteIDFormat(c).Parse(noteIDStr)
+					if err != nil {
+						return fmt.Errorf("invalid note ID: %w", err)
+					}
+
+					tags, err := storage.GetNoteTags(noteID)
+					if err != nil {
+						return fmt.Errorf("retrieving tags: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Tags of note with ID %s: %s\n", noteIDFormat(c).Format(noteID), strings.Join(tags, ", "))
+
+					return nil
+				},
+			},
+			{
+				Name:      "rename",
+				Usage:     "Rename a tag on all notes, merging it into an existing tag",
+				ArgsUsage: "oldTag newTag",
+				Action: func(c *cli.Context) error {
+					oldTag, newTag := c.Args().Get(0), c.Args().Get(1)
+					if oldTag == "" || newTag == "" {
+						return missingArg(c, "Please provide current and new name of tag.")
+					}
+
+					if err := storage.RenameTag(oldTag, newTag); err != nil {
+						return fmt.Errorf("renaming tag: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Renamed tag '%s' to '%s'\n", oldTag, newTag)
+
+					return nil
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "Remove a tag from all notes",
+				ArgsUsage: "tag",
+				Action: func(c *cli.Context) error {
+					tag := c.Args().First()
+					if tag == "" {
+						return missingArg(c, "Please provide a tag.")
+					}
+
+					if err := storage.DeleteTag(tag); err != nil {
+						return fmt.Errorf("deleting tag: %w", err)
+					}
+
+					fmt.Fprintf(c.App.Writer, "Deleted tag '%s' from all notes\n", tag)
+
+					return nil
+				},
+			},
+		},
+	}
+
+	return tag
+}
+
+// tagsCommand creates new CLI command for listing all tags with their usage
+func tagsCommand(storage TagStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "tags"
+		commandUsage = "List all tags with the number of notes having them"
+	)
+
+	// create a new CLI command configuration
+	tags := cli.Command{
+		Name:  commandName,  // name of command (e.g., "tags")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "sort", Value: "count", Usage: "sort by count or name"},
+		},
+		Action: func(c *cli.Context) error {
+			sortBy := c.String("sort")
+			if sortBy != "count" && sortBy != "name" {
+				return fmt.Errorf("unknown sort order: %s", sortBy)
+			}
+
+			tagCounts, err := storage.GetAllTags()
+			if err != nil {
+				return fmt.Errorf("retrieving tags: %w", err)
+			}
+
+			// storage returns tags ordered by count
+			if sortBy == "name" {
+				sort.Slice(tagCounts, func(i, j int) bool { return tagCounts[i].Tag < tagCounts[j].Tag })
+			}
+
+			for _, tc := range tagCounts {
+				fmt.Fprintf(c.App.Writer, "%s: %d\n", tc.Tag, tc.Count)
+			}
+
+			return nil
+		},
+	}
+
+	return tags
+}
+
+// tagAllCommand creates new CLI command for adding or removing a tag on every note matching a search
+func tagAllCommand(storage TagStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "tag-all"
+		commandUsage = "Add or remove a tag on every note matching a keyword"
+	)
+
+	// create a new CLI command configuration
+	tagAll := cli.Command{
+		Name:  commandName,  // name of command (e.g., "tag-all")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "search", Usage: "keyword to match notes by"},
+			cli.StringFlag{Name: "add", Usage: "tag to add to matching notes"},
+			cli.StringFlag{Name: "remove", Usage: "tag to remove from matching notes"},
+		},
+		Action: func(c *cli.Context) error {
+			keyword := c.String("search")
+			if keyword == "" {
+				return missingArg(c, "Please provide a keyword with --search.")
+			}
+
+			// exactly one of --add and --remove must be set
+			add, remove := c.String("add"), c.String("remove")
+			if (add == "") == (remove == "") {
+				return missingArg(c, "Please provide either --add or --remove tag.")
+			}
+
+			if add != "" {
+				affected, err := storage.TagNotesByKeyword(keyword, add)
+				if err != nil {
+					return fmt.Errorf("tagging notes: %w", err)
+				}
+
+				fmt.Fprintf(c.App.Writer, "Tagged %d note(s) as '%s'\n", affected, add)
+
+				return nil
+			}
+
+			affected, err := storage.UntagNotesByKeyword(keyword, remove)
+			if err != nil {
+				return fmt.Errorf("untagging notes: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Removed tag '%s' from %d note(s)\n", remove, affected)
+
+			return nil
+		},
+	}
+
+	return tagAll
+}
+
+// noteIDAndTagArgs parses "noteID tag" arguments, ok is false if some argument is missing, see missingArg
+func noteIDAndTagArgs(c *cli.Context) (noteID int, tag string, ok bool, err error) {
+	noteIDStr, tag := c.Args().Get(0), c.Args().Get(1)
+	if noteIDStr == "" || tag == "" {
+		return 0, "", false, missingArg(c, "Please provide ID of note and a tag.")
+	}
+
+	// convert note ID string to an integer
+	noteID, err = noteIDFormat(c).Parse(noteIDStr)
+	if err != nil {
+		return 0, "", false, fmt.Errorf("invalid note ID: %w", err)
+	}
+
+	return noteID, tag, true, nil
+}
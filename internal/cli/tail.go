@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// defaultTailInterval is the default time between checks for new notes in list --tail
+const defaultTailInterval = 2 * time.Second
+
+// TailStorage is implemented by storages able to retrieve notes created after a known one
+type TailStorage interface {
+	// GetNotesSinceID retrieves notes with ID greater than the specified one ordered by ID
+	GetNotesSinceID(id int) ([]entities.Note, error)
+}
+
+// fetchNewNotes retrieves notes created after the note with lastID and returns them with the ID of the newest one.
+// The ID is unchanged if there are no new notes
+func fetchNewNotes(storage TailStorage, lastID int) ([]entities.Note, int, error) {
+	notes, err := storage.GetNotesSinceID(lastID)
+	if err != nil {
+		return nil, lastID, err
+	}
+
+	// notes are ordered by ID, so the last one is the newest
+	if len(notes) > 0 {
+		lastID = notes[len(notes)-1].ID
+	}
+
+	return notes, lastID, nil
+}
+
+// tailNotes prints notes created by other processes as they appear, checking for them every interval.
+// Only notes created after the call are printed, it returns once interrupted with Ctrl-C
+func tailNotes(storage TailStorage, interval time.Duration, print func(notes []entities.Note) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval: %s", interval)
+	}
+
+	// start after the newest existing note
+	_, lastID, err := fetchNewNotes(storage, 0)
+	if err != nil {
+		return fmt.Errorf("retrieving notes: %w", err)
+	}
+
+	return pollUntilInterrupted(interval, func() error {
+		var notes []entities.Note
+		notes, lastID, err = fetchNewNotes(storage, lastID)
+		if err != nil {
+			return fmt.Errorf("retrieving new notes: %w", err)
+		}
+
+		return print(notes)
+	})
+}
+
+// pollUntilInterrupted calls check every interval until interrupted with Ctrl-C, which isn't an error,
+// or until check fails
+func pollUntilInterrupted(interval time.Duration, check func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := check(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+// tailStorage adds retrieving notes by ID to fakeStorage
+type tailStorage struct {
+	fakeStorage
+}
+
+func (s *tailStorage) GetNotesSinceID(id int) ([]entities.Note, error) {
+	var notes []entities.Note
+	for _, note := range s.notes {
+		if note.ID > id {
+			notes = append(notes, note)
+		}
+	}
+
+	return notes, nil
+}
+
+func TestFetchNewNotes(t *testing.T) {
+	storage := &tailStorage{}
+	_, _ = storage.NewNote("Existing", "Created before tailing.")
+
+	_, lastID, err := fetchNewNotes(storage, 0)
+	if err != nil || lastID != 1 {
+		t.Fatalf("Expected last ID 1, got %d (%v)", lastID, err)
+	}
+
+	// nothing new yet
+	notes, lastID, _ := fetchNewNotes(storage, lastID)
+	if len(notes) != 0 || lastID != 1 {
+		t.Errorf("Expected no new notes and last ID 1, got %+v and %d", notes, lastID)
+	}
+
+	// notes created in between are returned once
+	_, _ = storage.NewNote("Second", "Created while tailing.")
+	_, _ = storage.NewNote("Third", "Created while tailing.")
+
+	notes, lastID, _ = fetchNewNotes(storage, lastID)
+	if len(notes) != 2 || notes[0].Title != "Second" || notes[1].Title != "Third" || lastID != 3 {
+		t.Errorf("Expected Second and Third with last ID 3, got %+v and %d", notes, lastID)
+	}
+
+	_, _ = storage.NewNote("Fourth", "Created later.")
+
+	notes, lastID, _ = fetchNewNotes(storage, lastID)
+	if len(notes) != 1 || notes[0].Title != "Fourth" || lastID != 4 {
+		t.Errorf("Expected only Fourth with last ID 4, got %+v and %d", notes, lastID)
+	}
+}
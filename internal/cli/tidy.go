@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/storage"
+)
+
+// TidyStorage is implemented by storages able to rewrite content of all notes at once
+type TidyStorage interface {
+	// TidyNotes replaces content of every note with the result of tidy and returns IDs of changed notes
+	TidyNotes(tidy func(content string) string, dryRun bool) ([]int, error)
+}
+
+// tidyCommand creates new CLI command for normalizing whitespace of all notes
+func tidyCommand(tidyStorage TidyStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "tidy"
+		commandUsage = "Strip trailing spaces and collapse blank lines in all notes"
+	)
+
+	// create a new CLI command configuration
+	tidy := cli.Command{
+		Name:  commandName,  // name of command (e.g., "tidy")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.BoolFlag{Name: "dry-run", Usage: "only print the number of notes which would change"},
+		},
+		Action: func(c *cli.Context) error {
+			dryRun := c.Bool("dry-run")
+
+			changed, err := tidyStorage.TidyNotes(storage.TidyWhitespace, dryRun)
+			if err != nil {
+				return fmt.Errorf("tidying notes: %w", err)
+			}
+
+			if dryRun {
+				fmt.Fprintf(c.App.Writer, "Would tidy %d note(s)\n", len(changed))
+				return nil
+			}
+
+			fmt.Fprintf(c.App.Writer, "Tidied %d note(s)\n", len(changed))
+
+			return nil
+		},
+	}
+
+	return tidy
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// TimestampStorage is implemented by storages able to repair broken timestamps
+type TimestampStorage interface {
+	// FixTimestamps backfills missing or malformed creation and edit times and returns number of fixed notes
+	FixTimestamps() (int, error)
+}
+
+// fixTimestampsCommand creates new CLI command for backfilling missing timestamps of notes
+func fixTimestampsCommand(storage TimestampStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "fix-timestamps"
+		commandUsage = "Backfill missing or malformed creation and edit times of notes"
+	)
+
+	// create a new CLI command configuration
+	fixTimestamps := cli.Command{
+		Name:  commandName,  // name of command (e.g., "fix-timestamps")
+		Usage: commandUsage, // description of command
+		Action: func(c *cli.Context) error {
+			fixed, err := storage.FixTimestamps()
+			if err != nil {
+				return fmt.Errorf("fixing timestamps: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Fixed timestamps of %d note(s)\n", fixed)
+
+			return nil
+		},
+	}
+
+	return fixTimestamps
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"strings"
+)
+
+// maxAutoTitleLength is the maximum length of a generated title in runes, excluding the ellipsis
+const maxAutoTitleLength = 60
+
+// autoTitle generates a title from the first non-blank line of content.
+// Leading markdown heading marks are dropped and long lines are cut at a word boundary.
+// It returns an empty string if content has no text
+func autoTitle(content string) string {
+	var line string
+	for _, l := range strings.Split(content, "\n") {
+		l = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l), "#"))
+		if l != "" {
+			line = l
+			break
+		}
+	}
+
+	// collapse inner whitespace, so tabs and repeated spaces don't end up in the title
+	words := strings.Fields(line)
+	title := strings.Join(words, " ")
+	if len([]rune(title)) <= maxAutoTitleLength {
+		return title
+	}
+
+	// take as many whole words as fit, cutting a single overlong word if needed
+	var sb strings.Builder
+	for _, word := range words {
+		if sb.Len() > 0 && len([]rune(sb.String()))+1+len([]rune(word)) > maxAutoTitleLength {
+			break
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(word)
+	}
+
+	truncated := []rune(sb.String())
+	if len(truncated) > maxAutoTitleLength {
+		truncated = truncated[:maxAutoTitleLength]
+	}
+
+	return string(truncated) + "…"
+}
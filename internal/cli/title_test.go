@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAutoTitleFirstLine(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"Buy milk\nand bread", "Buy milk"},
+		{"\n\n   \n  Call mom  \nlater", "Call mom"},
+		{"## Meeting notes\n- agenda", "Meeting notes"},
+		{"spaced\tout   words", "spaced out words"},
+		{"  \n\t\n", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := autoTitle(tt.content); got != tt.want {
+			t.Errorf("Expected title %q for content %q, got %q", tt.want, tt.content, got)
+		}
+	}
+}
+
+func TestAutoTitleTruncation(t *testing.T) {
+	// long lines are cut at a word boundary
+	line := strings.Repeat("word ", 20)
+	got := autoTitle(line)
+	want := strings.TrimSpace(strings.Repeat("word ", 12)) + "…"
+	if got != want {
+		t.Errorf("Expected title %q, got %q", want, got)
+	}
+
+	// a single overlong word is cut at the maximum length
+	got = autoTitle(strings.Repeat("я", 100))
+	want = strings.Repeat("я", maxAutoTitleLength) + "…"
+	if got != want {
+		t.Errorf("Expected title %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// TrashStorage is implemented by storages able to move notes to the trash and back
+type TrashStorage interface {
+	// TrashNote moves a note to the trash, hiding it from other commands
+	TrashNote(noteID int) error
+	// RestoreNote moves a note out of the trash
+	RestoreNote(noteID int) error
+	// GetTrashedNotes retrieves notes in the trash
+	GetTrashedNotes() ([]entities.Note, error)
+}
+
+// TrashMatchingStorage is implemented by storages able to move all notes matching a filter to the trash at once
+type TrashMatchingStorage interface {
+	// TrashMatchingNotes moves notes matching the filter to the trash and returns their IDs,
+	// with dryRun nothing is trashed
+	TrashMatchingNotes(filter storage.NoteFilter, dryRun bool) ([]int, error)
+}
+
+// trashCommand creates new CLI command for moving a note to the trash or listing the trash
+func trashCommand(storage TrashStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "trash"
+		commandUsage = "Move a note to the trash, or list the trash with --list"
+	)
+
+	// create a new CLI command configuration
+	trash := cli.Command{
+		Name:      commandName,  // name of command (e.g., "trash")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "[noteID]",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "list",
+				Usage: "list notes in the trash",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("list") {
+				notes, err := storage.GetTrashedNotes()
+				if err != nil {
+					return fmt.Errorf("listing trashed notes: %w", err)
+				}
+
+				if len(notes) == 0 {
+					fmt.Fprintln(c.App.Writer, "The trash is empty")
+					return nil
+				}
+
+				for _, note := range notes {
+					fmt.Fprintf(c.App.Writer, "ID: %s, Title: %s, DeletedAt: %s\n", noteIDFormat(c).Format(note.ID), note.Title, note.DeletedAt)
+				}
+
+				return nil
+			}
+
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to move to the trash.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if err = storage.TrashNote(noteID); err != nil {
+				return fmt.Errorf("trashing note: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Moved note with ID %s to the trash\n", noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return trash
+}
+
+// restoreCommand creates new CLI command for moving a note out of the trash
+func restoreCommand(storage TrashStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "restore"
+		commandUsage = "Move a note out of the trash"
+	)
+
+	// create a new CLI command configuration
+	restore := cli.Command{
+		Name:      commandName,  // name of command (e.g., "restore")
+		Usage:     commandUsage, // description of command
+		ArgsUsage: "noteID",
+		Action: func(c *cli.Context) error {
+			noteID, ok, err := noteIDArg(c, "Please provide ID of note to restore.")
+			if !ok || err != nil {
+				return err
+			}
+
+			if err = storage.RestoreNote(noteID); err != nil {
+				return fmt.Errorf("restoring note: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "Restored note with ID %s\n", noteIDFormat(c).Format(noteID))
+
+			return nil
+		},
+	}
+
+	return restore
+}
+
+// trashMatchingCommand creates new CLI command for moving all notes matching a filter to the trash
+func trashMatchingCommand(trashStorage TrashMatchingStorage) cli.Command {
+	// constants for command name and usage description
+	const (
+		commandName  = "trash-matching"
+		commandUsage = "Move all notes matching --tag, --search and --older-than-days to the trash"
+	)
+
+	// create a new CLI command configuration
+	trashMatching := cli.Command{
+		Name:  commandName,  // name of command (e.g., "trash-matching")
+		Usage: commandUsage, // description of command
+		Flags: []cli.Flag{
+			cli.StringFlag{Name: "tag", Usage: "trash notes having the tag"},
+			cli.StringFlag{Name: "search", Usage: "trash notes containing the keyword"},
+			cli.IntFlag{Name: "older-than-days", Usage: "trash notes created more than the number of days ago"},
+			cli.BoolFlag{Name: "dry-run", Usage: "only print the number of notes which would be trashed"},
+		},
+		Action: func(c *cli.Context) error {
+			filter := storage.NoteFilter{Tag: c.String("tag"), Keyword: c.String("search")}
+			if days := c.Int("older-than-days"); days > 0 {
+				filter.CreatedBefore = time.Now().AddDate(0, 0, -days)
+			} else if days < 0 {
+				return errors.New("--older-than-days must not be negative")
+			}
+
+			// an empty filter would trash every note
+			if filter == (storage.NoteFilter{}) {
+				return missingArg(c, "Please provide --tag, --search or --older-than-days.")
+			}
+
+			dryRun := c.Bool("dry-run")
+			trashed, err := trashStorage.TrashMatchingNotes(filter, dryRun)
+			if err != nil {
+				return fmt.Errorf("trashing notes: %w", err)
+			}
+
+			if dryRun {
+				fmt.Fprintf(c.App.Writer, "Would move %d note(s) to the trash\n", len(trashed))
+				return nil
+			}
+
+			fmt.Fprintf(c.App.Writer, "Moved %d note(s) to the trash\n", len(trashed))
+
+			return nil
+		},
+	}
+
+	return trashMatching
+}
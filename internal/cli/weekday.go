@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// WeekdayStorage is implemented by storages able to find notes by the day of the week they were created on
+type WeekdayStorage interface {
+	// GetNotesByWeekday retrieves notes created on the specified day of the week in local time
+	GetNotesByWeekday(day time.Weekday) ([]entities.Note, error)
+}
+
+// parseWeekday parses a day of the week given by its English name or its three-letter abbreviation, ignoring case
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if s == name || s == name[:3] {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q, expected e.g. monday or mon", s)
+}
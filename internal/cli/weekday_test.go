@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekday(t *testing.T) {
+	tests := map[string]time.Weekday{
+		"monday":    time.Monday,
+		"Mon":       time.Monday,
+		"SUNDAY":    time.Sunday,
+		" sat ":     time.Saturday,
+		"wednesday": time.Wednesday,
+		"thu":       time.Thursday,
+	}
+	for s, want := range tests {
+		if got, err := parseWeekday(s); err != nil || got != want {
+			t.Errorf("Expected %s for %q, got %s (%v)", want, s, got, err)
+		}
+	}
+
+	for _, s := range []string{"", "m", "mond", "someday"} {
+		if _, err := parseWeekday(s); err == nil {
+			t.Errorf("Expected error for %q, got nil", s)
+		}
+	}
+}
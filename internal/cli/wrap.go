@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli"
+	"golang.org/x/term"
+	"golang.org/x/text/width"
+)
+
+// defaultWrapWidth is the number of columns content is wrapped at when output is not a terminal
+const defaultWrapWidth = 80
+
+// widthFlag overrides the detected terminal width content is wrapped at
+var widthFlag = cli.IntFlag{Name: "width", Usage: "wrap content at this many columns instead of the terminal width"}
+
+// outputWidth returns the width given with --width, the width of the terminal the command writes to,
+// or defaultWrapWidth when output is not a terminal
+func outputWidth(c *cli.Context) int {
+	if w := c.Int(widthFlag.Name); w > 0 {
+		return w
+	}
+
+	if file, ok := c.App.Writer.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		if w, _, err := term.GetSize(int(file.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+
+	return defaultWrapWidth
+}
+
+// wrapText soft-wraps every line of text to at most maxWidth terminal columns, breaking lines between words.
+// Wide characters such as CJK and emoji take two columns, words longer than a line are split between runes
+func wrapText(text string, maxWidth int) string {
+	if maxWidth <= 0 {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, maxWidth)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine wraps a single line without newlines, the space a line is broken at is dropped
+func wrapLine(line string, maxWidth int) string {
+	var sb strings.Builder
+	col := 0
+
+	for i, word := range strings.Split(line, " ") {
+		// put the word on the current line if it fits there together with the separating space
+		if i > 0 {
+			if col+1+displayWidth(word) <= maxWidth {
+				sb.WriteByte(' ')
+				col++
+			} else {
+				sb.WriteByte('\n')
+				col = 0
+			}
+		}
+
+		// a word wider than a whole line continues on the next one
+		for _, r := range word {
+			w := runeWidth(r)
+			if col > 0 && col+w > maxWidth {
+				sb.WriteByte('\n')
+				col = 0
+			}
+
+			sb.WriteRune(r)
+			col += w
+		}
+	}
+
+	return sb.String()
+}
+
+// displayWidth returns the number of terminal columns s takes
+func displayWidth(s string) int {
+	var w int
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+
+	return w
+}
+
+// runeWidth returns the number of terminal columns r takes: 2 for wide characters,
+// 0 for combining marks, format characters like zero width joiners and emoji skin tones, 1 otherwise
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || isEmojiModifier(r) {
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// isEmojiModifier reports whether r is a skin tone modifier, which is drawn together with the preceding emoji
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
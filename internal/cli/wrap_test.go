@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapTextBetweenWords(t *testing.T) {
+	got := wrapText("the quick brown fox jumps over the lazy dog", 10)
+	want := "the quick\nbrown fox\njumps over\nthe lazy\ndog"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWrapTextKeepsLineBreaks(t *testing.T) {
+	got := wrapText("short\n\nanother short line", 8)
+	want := "short\n\nanother\nshort\nline"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWrapTextLongWord(t *testing.T) {
+	got := wrapText("a "+strings.Repeat("x", 12), 5)
+	want := "a\nxxxxx\nxxxxx\nxx"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWrapTextCJK(t *testing.T) {
+	// every CJK character takes two columns, so five of them fill ten columns
+	got := wrapText("日本語のテキストを折り返す", 10)
+	want := "日本語のテ\nキストを折\nり返す"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	for _, line := range strings.Split(got, "\n") {
+		if displayWidth(line) > 10 {
+			t.Errorf("Expected lines of at most 10 columns, got %q", line)
+		}
+	}
+}
+
+func TestWrapTextEmoji(t *testing.T) {
+	// emoji take two columns, a skin tone modifier doesn't take any
+	got := wrapText("party 🎉🎉 time 👍🏽 ok", 9)
+	want := "party\n🎉🎉 time\n👍🏽 ok"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWrapTextZeroWidth(t *testing.T) {
+	text := "no wrapping here"
+	if got := wrapText(text, 0); got != text {
+		t.Errorf("Expected text unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines printed around each change
+const contextLines = 3
+
+// op describes a single line of the edit script
+type op struct {
+	// kind is ' ' for an unchanged line, '-' for a removed line and '+' for an added line
+	kind byte
+	// line holds the text of the line
+	line string
+	// a and b are the 0-based positions in the old and new line slices where the op applies
+	a, b int
+}
+
+// Unified returns a line-based unified diff turning content 'from' into content 'to'.
+// fromName and toName are used in the '---' and '+++' headers.
+// An empty string is returned when both contents are equal
+func Unified(fromName, toName, from, to string) string {
+	ops := editScript(splitLines(from), splitLines(to))
+
+	// collect indexes of changed lines
+	var changes []int
+	for i, o := range ops {
+		if o.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+
+	// nothing changed - nothing to print
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	// group changes into hunks, merging changes separated by less than two contexts
+	for start := 0; start < len(changes); {
+		end := start
+		for end+1 < len(changes) && changes[end+1]-changes[end] <= 2*contextLines {
+			end++
+		}
+
+		first := max(changes[start]-contextLines, 0)
+		last := min(changes[end]+contextLines, len(ops)-1)
+		writeHunk(&sb, ops[first:last+1])
+
+		start = end + 1
+	}
+
+	return sb.String()
+}
+
+// writeHunk writes a single hunk with its '@@' header
+func writeHunk(sb *strings.Builder, ops []op) {
+	var aLen, bLen int
+	for _, o := range ops {
+		if o.kind != '+' {
+			aLen++
+		}
+		if o.kind != '-' {
+			bLen++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(ops[0].a, aLen), hunkRange(ops[0].b, bLen))
+	for _, o := range ops {
+		sb.WriteByte(o.kind)
+		sb.WriteString(o.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange formats the range of a hunk side, an empty range points at the line before it
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// editScript computes the shortest edit script between a and b using the longest common subsequence
+func editScript(a, b []string) []op {
+	// lcs[i][j] holds the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// walk the table from the start, preferring deletions before insertions
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: ' ', line: a[i], a: i, b: j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{kind: '-', line: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, op{kind: '+', line: b[j], a: i, b: j})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// splitLines splits content into lines, ignoring a single trailing newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
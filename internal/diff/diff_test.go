@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	from := "line 1\nline 2\nline 3\nline 4\nline 5\nline 6\nline 7\nline 8\nline 9\nline 10\n"
+	to := "line 1\nline two\nline 3\nline 4\nline 5\nline 6\nline 7\nline 8\nline 9\nline 10\nline 11\n"
+
+	expected := "--- note 1\n+++ note 2\n" +
+		"@@ -1,5 +1,5 @@\n" +
+		" line 1\n" +
+		"-line 2\n" +
+		"+line two\n" +
+		" line 3\n" +
+		" line 4\n" +
+		" line 5\n" +
+		"@@ -8,3 +8,4 @@\n" +
+		" line 8\n" +
+		" line 9\n" +
+		" line 10\n" +
+		"+line 11\n"
+
+	got := Unified("note 1", "note 2", from, to)
+	if got != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestUnifiedEmptySide(t *testing.T) {
+	expected := "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+first\n+second\n"
+
+	got := Unified("a", "b", "", "first\nsecond")
+	if got != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestUnifiedEqual(t *testing.T) {
+	if got := Unified("a", "b", "same\ncontent", "same\ncontent"); got != "" {
+		t.Errorf("Expected empty diff for equal contents, got %q", got)
+	}
+}
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// AuditEntry records a change of a note in the audit log
+type AuditEntry struct {
+	ID     int    `json:"id"`
+	NoteID int    `json:"note_id"`
+	Action string `json:"action"`
+
+	// At is the time the change was made at
+	At time.Time `json:"at"`
+}
@@ -5,9 +5,39 @@ import (
 )
 
 type Note struct {
-	ID           int
-	Title        string
-	Content      string
-	CreatedAt    time.Time
-	LastEditedAt time.Time
+	ID           int       `json:"id" yaml:"id"`
+	Title        string    `json:"title" yaml:"title"`
+	Content      string    `json:"content" yaml:"content"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+	LastEditedAt time.Time `json:"last_edited_at" yaml:"last_edited_at"`
+
+	// PinnedAt is the time the note was pinned at, nil for notes which are not pinned
+	PinnedAt *time.Time `json:"pinned_at,omitempty" yaml:"pinned_at,omitempty"`
+
+	// DueAt is the time the note is due at, nil for notes without a due date
+	DueAt *time.Time `json:"due_at,omitempty" yaml:"due_at,omitempty"`
+
+	// ExpiresAt is the time the note expires at, nil for notes which never expire
+	ExpiresAt *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+
+	// DeletedAt is the time the note was moved to the trash at, nil for notes which are not in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty" yaml:"deleted_at,omitempty"`
+
+	// Revisions holds earlier contents of the note, oldest first. It is only filled for exports with history
+	Revisions []Revision `json:"revisions,omitempty" yaml:"-"`
+}
+
+// Expired reports whether the note has expired by the specified time
+func (n Note) Expired(now time.Time) bool {
+	return n.ExpiresAt != nil && !n.ExpiresAt.After(now)
+}
+
+// GetTitle returns the title of the note
+func (n Note) GetTitle() string {
+	return n.Title
+}
+
+// GetContent returns the content of the note
+func (n Note) GetContent() string {
+	return n.Content
 }
@@ -0,0 +1,13 @@
+package entities
+
+// Page holds a single page of notes together with information about all of them
+type Page struct {
+	// Notes holds notes of the page
+	Notes []Note `json:"notes" yaml:"notes"`
+
+	// Total is the number of notes on all pages
+	Total int `json:"total" yaml:"total"`
+
+	// HasMore reports whether there are notes after this page
+	HasMore bool `json:"has_more" yaml:"has_more"`
+}
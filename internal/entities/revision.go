@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// Revision is an earlier content of a note, replaced by an edit
+type Revision struct {
+	Content string `json:"content"`
+
+	// EditedAt is the time the content was written at
+	EditedAt time.Time `json:"edited_at"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+// TagCount holds a tag with the number of notes having it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// LabelSize holds a tag or a metadata value with the number of notes having it and their total content size
+type LabelSize struct {
+	Label string `json:"label"`
+	Notes int    `json:"notes"`
+	Bytes int    `json:"bytes"`
+}
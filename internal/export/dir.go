@@ -0,0 +1,71 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"go-notes/internal/entities"
+)
+
+// FrontMatter holds note metadata written as YAML at the top of exported markdown files
+type FrontMatter struct {
+	Title        string    `yaml:"title"`
+	CreatedAt    time.Time `yaml:"created_at"`
+	LastEditedAt time.Time `yaml:"last_edited_at"`
+	Tags         []string  `yaml:"tags,omitempty"`
+}
+
+// FileName returns the name of the file a note is exported to, e.g. "42-shopping-list.md".
+// The ID prefix keeps names unique and the slug keeps them path-safe
+func FileName(note entities.Note) string {
+	return fmt.Sprintf("%d-%s.md", note.ID, Slugify(note.Title))
+}
+
+// WriteDir writes every note into its own markdown file with front-matter in dir, creating it if needed.
+// tags maps note IDs to their tags and may be nil
+func WriteDir(dir string, notes []entities.Note, tags map[int][]string) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		var buf bytes.Buffer
+		err = WriteMarkdownWithFrontMatter(&buf, note, tags[note.ID])
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(dir, FileName(note)), buf.Bytes(), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteMarkdownWithFrontMatter writes a note as its content prefixed by YAML front-matter with metadata
+func WriteMarkdownWithFrontMatter(w io.Writer, note entities.Note, tags []string) error {
+	frontMatter, err := yaml.Marshal(FrontMatter{
+		Title:        note.Title,
+		CreatedAt:    note.CreatedAt,
+		LastEditedAt: note.LastEditedAt,
+		Tags:         tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	// title is already in front-matter, so the body is the content only
+	_, err = fmt.Fprintf(w, "---\n%s---\n\n%s\n", frontMatter, strings.TrimRight(note.Content, "\n"))
+
+	return err
+}
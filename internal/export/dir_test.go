@@ -0,0 +1,59 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestWriteDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	created := time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC)
+
+	notes := []entities.Note{
+		{ID: 1, Title: "Shopping list", Content: "Milk", CreatedAt: created, LastEditedAt: created},
+		{ID: 12, Title: "../Secret: plans?", Content: "Line 1\nLine 2\n", CreatedAt: created, LastEditedAt: created},
+	}
+	tags := map[int][]string{1: {"home", "errands"}}
+
+	err := WriteDir(dir, notes, tags)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "1-shopping-list.md,12-secret-plans.md" {
+		t.Fatalf("Expected one path-safe file per note, got %v", names)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "1-shopping-list.md"))
+	expected := "---\n" +
+		"title: Shopping list\n" +
+		"created_at: 2024-01-09T12:00:00Z\n" +
+		"last_edited_at: 2024-01-09T12:00:00Z\n" +
+		"tags:\n" +
+		"    - home\n" +
+		"    - errands\n" +
+		"---\n\n" +
+		"Milk\n"
+	if string(data) != expected {
+		t.Errorf("Expected file:\n%s\ngot:\n%s", expected, data)
+	}
+
+	// titles needing quotes are escaped in front-matter, untagged notes have no tags key
+	data, _ = os.ReadFile(filepath.Join(dir, "12-secret-plans.md"))
+	if !strings.Contains(string(data), "title: '../Secret: plans?'\n") || strings.Contains(string(data), "tags:") {
+		t.Errorf("Expected quoted title and no tags, got:\n%s", data)
+	}
+	if !strings.HasSuffix(string(data), "---\n\nLine 1\nLine 2\n") {
+		t.Errorf("Expected content after front-matter, got:\n%s", data)
+	}
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/links"
+)
+
+// graphNode is a note in the JSON graph
+type graphNode struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// graph is the JSON representation of notes and links between them
+type graph struct {
+	Nodes []graphNode  `json:"nodes"`
+	Edges []links.Edge `json:"edges"`
+}
+
+// WriteDOT writes notes and links between them as a Graphviz digraph, notes without links are included as well
+func WriteDOT(w io.Writer, notes []entities.Note, edges []links.Edge) error {
+	if _, err := fmt.Fprintln(w, "digraph notes {"); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		// quoted DOT strings only need quotes and backslashes escaped, strconv.Quote escapes them the same way
+		if _, err := fmt.Fprintf(w, "  %d [label=%s];\n", note.ID, strconv.Quote(note.Title)); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range edges {
+		if _, err := fmt.Fprintf(w, "  %d -> %d;\n", edge.From, edge.To); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+
+	return err
+}
+
+// WriteGraphJSON writes notes and links between them as a JSON object with nodes and edges arrays
+func WriteGraphJSON(w io.Writer, notes []entities.Note, edges []links.Edge) error {
+	// always write arrays, even when there are no notes or links
+	g := graph{Nodes: []graphNode{}, Edges: []links.Edge{}}
+	for _, note := range notes {
+		g.Nodes = append(g.Nodes, graphNode{ID: note.ID, Title: note.Title})
+	}
+	g.Edges = append(g.Edges, edges...)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(g)
+}
@@ -0,0 +1,46 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/links"
+)
+
+func TestWriteDOT(t *testing.T) {
+	notes := []entities.Note{
+		{ID: 1, Title: "Index", Content: "See [[2]] and [[3]]"},
+		{ID: 2, Title: `Quoted "title"`, Content: "Back to [[1]]"},
+		{ID: 3, Title: "Leaf", Content: "No links"},
+		{ID: 4, Title: "Isolated", Content: "Alone"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, notes, links.Edges(notes)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "digraph notes {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Errorf("Expected a digraph, got:\n%s", out)
+	}
+
+	// every note is a node, including the isolated one
+	for _, want := range []string{`1 [label="Index"];`, `2 [label="Quoted \"title\""];`, `3 [label="Leaf"];`, `4 [label="Isolated"];`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected node %q, got:\n%s", want, out)
+		}
+	}
+
+	// every link is an edge
+	for _, want := range []string{"1 -> 2;", "1 -> 3;", "2 -> 1;"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected edge %q, got:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "->"); n != 3 {
+		t.Errorf("Expected 3 edges, got %d", n)
+	}
+}
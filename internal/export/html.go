@@ -0,0 +1,63 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/russross/blackfriday/v2"
+
+	"go-notes/internal/entities"
+)
+
+// htmlDocument wraps the rendered content of a note, the title is escaped before formatting
+const htmlDocument = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+</head>
+<body>
+<h1>%[1]s</h1>
+%[2]s</body>
+</html>
+`
+
+// WriteHTML renders markdown content of a note as HTML wrapped in a minimal document with its title,
+// or only the rendered content if fragment is set. HTML written in the content is escaped instead of being
+// passed through and links with unsafe protocols like javascript: are not rendered as links,
+// so a note can't inject scripts into the page embedding it
+func WriteHTML(w io.Writer, note entities.Note, fragment bool) error {
+	renderer := escapingRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.CommonHTMLFlags | blackfriday.Safelink,
+	})}
+	body := blackfriday.Run([]byte(note.Content), blackfriday.WithRenderer(renderer))
+
+	if fragment {
+		_, err := w.Write(body)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, htmlDocument, html.EscapeString(note.Title), body)
+
+	return err
+}
+
+// escapingRenderer renders markdown like blackfriday.HTMLRenderer, but writes raw HTML of the content as text
+type escapingRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode escapes raw HTML blocks and spans and renders other nodes as HTMLRenderer does
+func (r escapingRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	switch node.Type {
+	case blackfriday.HTMLBlock:
+		fmt.Fprintf(w, "<p>%s</p>\n", html.EscapeString(string(node.Literal)))
+		return blackfriday.GoToNext
+	case blackfriday.HTMLSpan:
+		_, _ = io.WriteString(w, html.EscapeString(string(node.Literal)))
+		return blackfriday.GoToNext
+	}
+
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestWriteHTML(t *testing.T) {
+	note := entities.Note{
+		Title: "Plans <b>",
+		Content: "## Today\n\nBuy **milk** and [bread](https://example.com).\n\n- one\n- two\n\n" +
+			"<script>alert(1)</script>\n\nInline <img src=x onerror=alert(1)> and [link](javascript:alert(1))",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, note, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	doc := buf.String()
+
+	for _, expected := range []string{
+		"<!DOCTYPE html>",
+		"<title>Plans &lt;b&gt;</title>",
+		"<h2>Today</h2>",
+		"<strong>milk</strong>",
+		`<a href="https://example.com">bread</a>`,
+		"<li>one</li>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		"&lt;img src=x onerror=alert(1)&gt;",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, doc)
+		}
+	}
+	for _, unexpected := range []string{"<script>", "<img", `href="javascript:`} {
+		if strings.Contains(doc, unexpected) {
+			t.Errorf("Expected %q to be escaped in:\n%s", unexpected, doc)
+		}
+	}
+
+	// a fragment is only the rendered content
+	buf.Reset()
+	_ = WriteHTML(&buf, note, true)
+	if fragment := buf.String(); strings.Contains(fragment, "<html>") || !strings.HasPrefix(fragment, "<h2>Today</h2>") {
+		t.Errorf("Expected only rendered content, got:\n%s", fragment)
+	}
+}
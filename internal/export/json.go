@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"go-notes/internal/entities"
+)
+
+// WriteJSON writes notes as an indented JSON array
+func WriteJSON(w io.Writer, notes []entities.Note) error {
+	return WriteJSONWithProgress(w, notes, nil)
+}
+
+// WriteJSONWithProgress writes notes the same way as WriteJSON, encoding them one by one and calling progress,
+// if not nil, with the number of notes written so far after every note
+func WriteJSONWithProgress(w io.Writer, notes []entities.Note, progress func(written int)) error {
+	// always write an array, even when there are no notes
+	if len(notes) == 0 {
+		_, err := io.WriteString(w, "[]\n")
+		return err
+	}
+
+	if _, err := io.WriteString(w, "[\n  "); err != nil {
+		return err
+	}
+
+	for i, note := range notes {
+		// notes are indented as elements of the array, matching json.Encoder with the same indent
+		data, err := json.MarshalIndent(note, "  ", "  ")
+		if err != nil {
+			return err
+		}
+
+		separator := ",\n  "
+		if i == len(notes)-1 {
+			separator = "\n]\n"
+		}
+		if _, err = w.Write(append(data, separator...)); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(i + 1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestWriteJSONWithProgress(t *testing.T) {
+	for _, notes := range [][]entities.Note{
+		nil,
+		{{ID: 1, Title: "Only", Content: "<b>escaped</b>\n"}},
+		{
+			{ID: 1, Title: "First", Content: "Milk", CreatedAt: time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)},
+			{ID: 2, Title: "Second", Content: "Bread & butter"},
+			{ID: 3, Title: "Third"},
+		},
+	} {
+		// notes encoded one by one must match the array encoded at once
+		var expected bytes.Buffer
+		encoder := json.NewEncoder(&expected)
+		encoder.SetIndent("", "  ")
+		if notes == nil {
+			_ = encoder.Encode([]entities.Note{})
+		} else {
+			_ = encoder.Encode(notes)
+		}
+
+		var (
+			out     bytes.Buffer
+			written []int
+		)
+		err := WriteJSONWithProgress(&out, notes, func(n int) { written = append(written, n) })
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if out.String() != expected.String() {
+			t.Errorf("Expected JSON:\n%s\ngot:\n%s", expected.String(), out.String())
+		}
+
+		var expectedWritten []int
+		for i := range notes {
+			expectedWritten = append(expectedWritten, i+1)
+		}
+		if !reflect.DeepEqual(written, expectedWritten) {
+			t.Errorf("Expected progress %v, got %v", expectedWritten, written)
+		}
+	}
+}
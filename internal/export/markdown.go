@@ -0,0 +1,105 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"go-notes/internal/entities"
+)
+
+// WriteMarkdown writes a single note as markdown with its title as a top-level heading
+func WriteMarkdown(w io.Writer, note entities.Note) error {
+	return writeMarkdownSection(w, "#", note)
+}
+
+// WriteMarkdownDocument writes all notes into one markdown document.
+// Every note becomes a second-level section and the document starts with a table of contents linking to them
+func WriteMarkdownDocument(w io.Writer, title string, notes []entities.Note) error {
+	anchors := Anchors(notes)
+
+	// write document title and table of contents
+	_, err := fmt.Fprintf(w, "# %s\n\n## Table of contents\n\n", title)
+	if err != nil {
+		return err
+	}
+	for i, note := range notes {
+		_, err = fmt.Fprintf(w, "- [%s](#%s)\n", note.Title, anchors[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	// write every note with an explicit anchor, so links work regardless of the markdown renderer
+	for i, note := range notes {
+		_, err = fmt.Fprintf(w, "\n<a id=\"%s\"></a>\n\n", anchors[i])
+		if err != nil {
+			return err
+		}
+
+		err = writeMarkdownSection(w, "##", note)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Anchors returns unique anchors for notes based on their titles.
+// Repeated slugs get a numeric suffix, e.g. "todo", "todo-1", "todo-2"
+func Anchors(notes []entities.Note) []string {
+	anchors := make([]string, len(notes))
+	used := make(map[string]bool, len(notes))
+
+	for i, note := range notes {
+		slug := Slugify(note.Title)
+
+		// find the first free suffix for a repeated slug
+		anchor := slug
+		for n := 1; used[anchor]; n++ {
+			anchor = fmt.Sprintf("%s-%d", slug, n)
+		}
+
+		used[anchor] = true
+		anchors[i] = anchor
+	}
+
+	return anchors
+}
+
+// Slugify converts a title into a lowercase string of letters, digits and single dashes.
+// The result is safe to use both as a markdown anchor and as a part of a file name
+func Slugify(title string) string {
+	var sb strings.Builder
+
+	// a dash is written lazily, so separators never lead, trail or repeat
+	pendingDash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingDash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			pendingDash = false
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			pendingDash = true
+		}
+	}
+
+	// titles without letters and digits still need a non-empty slug
+	if sb.Len() == 0 {
+		return "note"
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownSection writes a note as a markdown section with the given heading marker
+func writeMarkdownSection(w io.Writer, heading string, note entities.Note) error {
+	_, err := fmt.Fprintf(w, "%s %s\n\n%s\n", heading, note.Title, strings.TrimRight(note.Content, "\n"))
+
+	return err
+}
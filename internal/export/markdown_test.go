@@ -0,0 +1,62 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestWriteMarkdownDocument(t *testing.T) {
+	notes := []entities.Note{
+		{ID: 1, Title: "Shopping list", Content: "Milk"},
+		{ID: 2, Title: "Ideas & plans", Content: "Write more notes"},
+		{ID: 3, Title: "Shopping List", Content: "Bread"},
+	}
+
+	var sb strings.Builder
+	err := WriteMarkdownDocument(&sb, "Notes", notes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	doc := sb.String()
+
+	// table of contents lists every note with unique anchors
+	for _, entry := range []string{
+		"- [Shopping list](#shopping-list)\n",
+		"- [Ideas & plans](#ideas-plans)\n",
+		"- [Shopping List](#shopping-list-1)\n",
+	} {
+		if !strings.Contains(doc, entry) {
+			t.Errorf("Expected table of contents entry %q, got:\n%s", entry, doc)
+		}
+	}
+
+	// every section has its anchor and a second-level heading
+	for _, section := range []string{
+		"<a id=\"shopping-list\"></a>\n\n## Shopping list\n\nMilk\n",
+		"<a id=\"ideas-plans\"></a>\n\n## Ideas & plans\n\nWrite more notes\n",
+		"<a id=\"shopping-list-1\"></a>\n\n## Shopping List\n\nBread\n",
+	} {
+		if !strings.Contains(doc, section) {
+			t.Errorf("Expected section %q, got:\n%s", section, doc)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Hello, World!":       "hello-world",
+		"  spaced   out  ":    "spaced-out",
+		"Заметка о Go":        "заметка-о-go",
+		"../../etc/passwd":    "etcpasswd",
+		"!!!":                 "note",
+		"snake_case-and-dash": "snake-case-and-dash",
+	}
+
+	for title, expected := range cases {
+		if got := Slugify(title); got != expected {
+			t.Errorf("Expected slug of %q to be %q, got %q", title, expected, got)
+		}
+	}
+}
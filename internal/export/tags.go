@@ -0,0 +1,106 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go-notes/internal/entities"
+)
+
+// untaggedName names the file of notes without tags written by WriteTagDir
+const untaggedName = "untagged"
+
+// WriteTagDir writes a markdown document per tag into dir, creating it if needed. Every document is named
+// after the slug of its tag, e.g. "home.md", and holds all notes having the tag as sections,
+// so a note with several tags is written into several documents. Notes without tags are written into "untagged.md".
+// tags maps note IDs to their tags. It returns names of written files sorted by name
+func WriteTagDir(dir string, notes []entities.Note, tags map[int][]string) ([]string, error) {
+	// group notes by tag keeping their order, a note is listed once even if a tag repeats
+	byTag := make(map[string][]entities.Note)
+	var untagged []entities.Note
+	for _, note := range notes {
+		if len(tags[note.ID]) == 0 {
+			untagged = append(untagged, note)
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, tag := range tags[note.ID] {
+			if !seen[tag] {
+				seen[tag] = true
+				byTag[tag] = append(byTag[tag], note)
+			}
+		}
+	}
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	names := tagFileNames(byTag)
+	if len(untagged) > 0 {
+		err = writeDocument(filepath.Join(dir, untaggedName+".md"), untaggedName, untagged)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for tag, tagNotes := range byTag {
+		err = writeDocument(filepath.Join(dir, names[tag]), tag, tagNotes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	written := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		written = append(written, name)
+	}
+	if len(untagged) > 0 {
+		written = append(written, untaggedName+".md")
+	}
+	sort.Strings(written)
+
+	return written, nil
+}
+
+// tagFileNames returns unique path-safe file names for tags. Tags with the same slug, e.g. "c" and "c++",
+// get a numeric suffix in tag order, and no tag is named like the file of untagged notes
+func tagFileNames(byTag map[string][]entities.Note) map[string]string {
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	names := make(map[string]string, len(tags))
+	used := map[string]bool{untaggedName: true}
+	for _, tag := range tags {
+		slug := Slugify(tag)
+
+		// find the first free suffix for a repeated slug
+		name := slug
+		for n := 1; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d", slug, n)
+		}
+
+		used[name] = true
+		names[tag] = name + ".md"
+	}
+
+	return names
+}
+
+// writeDocument writes notes into a markdown document with a table of contents at path
+func writeDocument(path, title string, notes []entities.Note) error {
+	var buf bytes.Buffer
+	err := WriteMarkdownDocument(&buf, title, notes)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
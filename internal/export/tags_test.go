@@ -0,0 +1,60 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestWriteTagDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+
+	notes := []entities.Note{
+		{ID: 1, Title: "Shopping list", Content: "Milk"},
+		{ID: 2, Title: "Paint fence", Content: "Buy paint"},
+		{ID: 3, Title: "Loose thought", Content: "Untagged"},
+		{ID: 4, Title: "C++ tips", Content: "Use RAII"},
+	}
+	tags := map[int][]string{
+		1: {"home", "errands"},
+		2: {"home"},
+		4: {"c++", "untagged"},
+	}
+
+	written, err := WriteTagDir(dir, notes, tags)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// the tag named like the file of untagged notes gets a suffix
+	expected := "c.md,errands.md,home.md,untagged-1.md,untagged.md"
+	if strings.Join(written, ",") != expected {
+		t.Fatalf("Expected files %s, got %v", expected, written)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(written) {
+		t.Errorf("Expected %d files in the directory, got %d", len(written), len(entries))
+	}
+
+	// the note with two tags appears in both files
+	home, _ := os.ReadFile(filepath.Join(dir, "home.md"))
+	errands, _ := os.ReadFile(filepath.Join(dir, "errands.md"))
+	if !strings.HasPrefix(string(home), "# home\n") {
+		t.Errorf("Expected tag as document title, got:\n%s", home)
+	}
+	if !strings.Contains(string(home), "## Shopping list\n\nMilk\n") || !strings.Contains(string(errands), "## Shopping list\n\nMilk\n") {
+		t.Errorf("Expected multi-tagged note in home.md and errands.md, got:\n%s\n%s", home, errands)
+	}
+	if !strings.Contains(string(home), "## Paint fence\n") || strings.Contains(string(errands), "Paint fence") {
+		t.Errorf("Expected single-tagged note in home.md only, got:\n%s\n%s", home, errands)
+	}
+
+	untagged, _ := os.ReadFile(filepath.Join(dir, "untagged.md"))
+	if !strings.Contains(string(untagged), "## Loose thought\n") || strings.Contains(string(untagged), "C++ tips") {
+		t.Errorf("Expected only the untagged note in untagged.md, got:\n%s", untagged)
+	}
+}
@@ -0,0 +1,30 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go-notes/internal/entities"
+)
+
+// ReadJSON reads notes from a file written by a JSON export, revisions included if it was exported with history.
+// Path of every read note names the file and the exported ID of the note, e.g. "notes.json#3"
+func ReadJSON(path string) ([]File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []entities.Note
+	if err = json.Unmarshal(data, &notes); err != nil {
+		return nil, &FileError{Path: path, Err: err}
+	}
+
+	files := make([]File, len(notes))
+	for i, note := range notes {
+		files[i] = File{Path: fmt.Sprintf("%s#%d", path, note.ID), Note: note}
+	}
+
+	return files, nil
+}
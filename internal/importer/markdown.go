@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"go-notes/internal/entities"
+)
+
+// frontMatterDelimiter opens and closes YAML front-matter
+const frontMatterDelimiter = "---"
+
+// errUnterminatedFrontMatter is returned for files opening front-matter without closing it
+var errUnterminatedFrontMatter = errors.New("front-matter is not terminated with ---")
+
+// frontMatter holds metadata read from the top of a markdown file
+type frontMatter struct {
+	Title     string    `yaml:"title"`
+	CreatedAt time.Time `yaml:"created_at"`
+	Tags      []string  `yaml:"tags"`
+}
+
+// File is a note read from a markdown file
+type File struct {
+	// Path is the path of the file the note was read from
+	Path string
+
+	// Note holds title, content and creation time of the note, its ID is not used by storages
+	Note entities.Note
+
+	// Tags lists tags from front-matter
+	Tags []string
+}
+
+// FileError describes a file which couldn't be read
+type FileError struct {
+	Path string
+	Err  error
+}
+
+// Error prefixes the error with the file path
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// ReadDir reads all markdown files in dir sorted by name.
+// A file which can't be read doesn't stop reading others, its error is collected instead
+func ReadDir(dir string) ([]File, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	// read files in a stable order
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var (
+		files []File
+		errs  []error
+	)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".md" && ext != ".markdown") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			continue
+		}
+
+		file, err := ParseMarkdown(path, data)
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	return files, errs
+}
+
+// ParseMarkdown reads a note from markdown with optional YAML front-matter (title, created_at, tags).
+// Without front-matter or its title, the file name without extension is used as the title
+func ParseMarkdown(path string, data []byte) (File, error) {
+	file := File{Path: path}
+	body := string(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")))
+
+	// front-matter starts at the very first line
+	if rest, ok := strings.CutPrefix(body, frontMatterDelimiter+"\n"); ok {
+		header, content, found := cutFrontMatter(rest)
+		if !found {
+			return File{}, errUnterminatedFrontMatter
+		}
+
+		var meta frontMatter
+		if err := yaml.Unmarshal([]byte(header), &meta); err != nil {
+			return File{}, fmt.Errorf("invalid front-matter: %w", err)
+		}
+
+		file.Note.Title = meta.Title
+		file.Note.CreatedAt = meta.CreatedAt
+		file.Tags = meta.Tags
+		body = content
+	}
+
+	if file.Note.Title == "" {
+		file.Note.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	file.Note.Content = strings.Trim(body, "\n")
+
+	return file, nil
+}
+
+// cutFrontMatter splits text following the opening delimiter into front-matter and the rest of the file
+func cutFrontMatter(text string) (header, rest string, found bool) {
+	// closing delimiter may be the first line when front-matter is empty
+	if after, ok := strings.CutPrefix(text, frontMatterDelimiter+"\n"); ok {
+		return "", after, true
+	}
+
+	header, rest, found = strings.Cut(text, "\n"+frontMatterDelimiter+"\n")
+	if !found && strings.HasSuffix(text, "\n"+frontMatterDelimiter) {
+		return strings.TrimSuffix(text, "\n"+frontMatterDelimiter), "", true
+	}
+
+	return header, rest, found
+}
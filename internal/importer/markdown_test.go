@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"1-valid.md": "---\ntitle: Shopping list\ncreated_at: 2024-01-09T12:00:00Z\ntags:\n  - home\n---\n\nMilk\nBread\n",
+		"plain.md":   "Just some text\n",
+		"broken.md":  "---\ntitle: [unclosed\n---\nBody\n",
+		"open.md":    "---\ntitle: Never closed\nBody\n",
+		"notes.txt":  "not markdown",
+	}
+	for name, content := range files {
+		_ = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+	}
+
+	imported, errs := ReadDir(dir)
+
+	if len(imported) != 2 {
+		t.Fatalf("Expected 2 imported files, got %d", len(imported))
+	}
+
+	valid := imported[0]
+	if valid.Note.Title != "Shopping list" || valid.Note.Content != "Milk\nBread" {
+		t.Errorf("Expected title and content from front-matter file, got %+v", valid.Note)
+	}
+	if !valid.Note.CreatedAt.Equal(time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected creation time from front-matter, got %s", valid.Note.CreatedAt)
+	}
+	if len(valid.Tags) != 1 || valid.Tags[0] != "home" {
+		t.Errorf("Expected tags from front-matter, got %v", valid.Tags)
+	}
+
+	// files without front-matter use the file name as title and the whole file as content
+	plain := imported[1]
+	if plain.Note.Title != "plain" || plain.Note.Content != "Just some text" {
+		t.Errorf("Expected file name as title, got %+v", plain.Note)
+	}
+
+	// malformed files are reported one by one
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+
+	var fileErr *FileError
+	if !errors.As(errs[0], &fileErr) || filepath.Base(fileErr.Path) != "broken.md" {
+		t.Errorf("Expected error for broken.md, got %v", errs[0])
+	}
+	if !errors.Is(errs[1], errUnterminatedFrontMatter) {
+		t.Errorf("Expected unterminated front-matter error for open.md, got %v", errs[1])
+	}
+}
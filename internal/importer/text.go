@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// textExtension is the extension of files read by ReadTextDir
+const textExtension = ".txt"
+
+// ErrNotText is wrapped by FileError for files ReadTextDir skips as they don't hold plain text
+var ErrNotText = errors.New("not a text file")
+
+// ReadTextDir reads all text files in dir sorted by name, using the file name without extension as the title
+// and the whole file as content. Files without the .txt extension or with binary content are skipped,
+// each with a FileError wrapping ErrNotText in skipped. A file which can't be read doesn't stop reading others,
+// its error is collected in errs instead
+func ReadTextDir(dir string) (files []File, skipped, errs []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, []error{err}
+	}
+
+	// read files in a stable order
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if strings.ToLower(filepath.Ext(entry.Name())) != textExtension {
+			skipped = append(skipped, &FileError{Path: path, Err: ErrNotText})
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, &FileError{Path: path, Err: err})
+			continue
+		}
+
+		// a .txt extension doesn't guarantee text, binary content would be stored garbled
+		if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+			skipped = append(skipped, &FileError{Path: path, Err: ErrNotText})
+			continue
+		}
+
+		files = append(files, ParseText(path, data))
+	}
+
+	return files, skipped, errs
+}
+
+// ParseText reads a note from a plain text file, its name without extension is used as the title
+func ParseText(path string, data []byte) File {
+	file := File{Path: path}
+	file.Note.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	file.Note.Content = strings.Trim(string(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))), "\n")
+
+	return file
+}
@@ -0,0 +1,208 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// ErrClosed is returned when adding notes to a closed BatchWriter
+var ErrClosed = errors.New("batch writer is closed")
+
+// FlushError reports notes a flush couldn't create, they are dropped from the buffer,
+// so they can be added again once the cause is fixed without creating other notes twice
+type FlushError struct {
+	// Notes are the notes which were not created
+	Notes []entities.Note
+
+	// Err is the error of creating the first of them
+	Err error
+}
+
+// Error describes the failed notes
+func (e *FlushError) Error() string {
+	return fmt.Sprintf("creating %d note(s): %v", len(e.Notes), e.Err)
+}
+
+// Unwrap returns the error of creating notes
+func (e *FlushError) Unwrap() error {
+	return e.Err
+}
+
+// Storage is implemented by storages able to create many notes at once
+type Storage interface {
+	// NewNotes creates notes in a single transaction and returns their IDs
+	NewNotes(notes []entities.Note) ([]int, error)
+}
+
+// BatchWriter buffers notes and creates them in a single transaction every size notes or interval,
+// whichever comes first. It's safe for concurrent use
+type BatchWriter struct {
+	storage Storage
+	size    int
+
+	// mu guards pending, err and closed
+	mu      sync.Mutex
+	pending []entities.Note
+	err     error
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBatchWriter creates a writer flushing every size notes and every interval, a zero interval disables periodic flushes
+func NewBatchWriter(storage Storage, size int, interval time.Duration) *BatchWriter {
+	w := &BatchWriter{
+		storage: storage,
+		size:    max(size, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go w.flushPeriodically(interval)
+
+	return w
+}
+
+// Add buffers a note, flushing the buffer if it's full.
+// An error of a failed periodic flush is returned by the next call without buffering the note.
+// A failed flush returns a *FlushError, the note is not buffered afterwards either:
+// it was created unless it's listed in the error
+func (w *BatchWriter) Add(note entities.Note) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	if err := w.takeErr(); err != nil {
+		return err
+	}
+
+	w.pending = append(w.pending, note)
+	if len(w.pending) >= w.size {
+		return w.flush()
+	}
+
+	return nil
+}
+
+// Flush creates all buffered notes
+func (w *BatchWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.takeErr(); err != nil {
+		return err
+	}
+
+	return w.flush()
+}
+
+// Close stops periodic flushes and creates remaining notes
+func (w *BatchWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	// wait for a running periodic flush to finish
+	close(w.stop)
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// remaining notes are flushed even if an earlier periodic flush failed
+	return joinFlushErrors(w.takeErr(), w.flush())
+}
+
+// flushPeriodically flushes the buffer every interval until the writer is closed
+func (w *BatchWriter) flushPeriodically(interval time.Duration) {
+	defer close(w.done)
+
+	if interval <= 0 {
+		<-w.stop
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.mu.Lock()
+			w.err = joinFlushErrors(w.err, w.flush())
+			w.mu.Unlock()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// flush creates buffered notes and empties the buffer, the caller must hold mu.
+// If the batch fails, its notes are created one by one, so only the failing ones are reported in a *FlushError
+func (w *BatchWriter) flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	pending := w.pending
+	w.pending = nil
+
+	_, err := w.storage.NewNotes(pending)
+	if err == nil {
+		return nil
+	}
+	if len(pending) == 1 {
+		return &FlushError{Notes: pending, Err: err}
+	}
+
+	// the batch was rolled back as a whole, so none of its notes were created yet
+	var failed *FlushError
+	for _, note := range pending {
+		if _, err = w.storage.NewNotes([]entities.Note{note}); err == nil {
+			continue
+		}
+
+		if failed == nil {
+			failed = &FlushError{Err: err}
+		}
+		failed.Notes = append(failed.Notes, note)
+	}
+	if failed == nil {
+		return nil
+	}
+
+	return failed
+}
+
+// joinFlushErrors combines errors of two flushes, keeping failed notes of both
+func joinFlushErrors(first, second error) error {
+	var firstFlush, secondFlush *FlushError
+	if !errors.As(first, &firstFlush) || !errors.As(second, &secondFlush) {
+		if first != nil {
+			return first
+		}
+
+		return second
+	}
+
+	return &FlushError{Notes: append(firstFlush.Notes, secondFlush.Notes...), Err: firstFlush.Err}
+}
+
+// takeErr returns and clears the error of a failed periodic flush, the caller must hold mu
+func (w *BatchWriter) takeErr() error {
+	err := w.err
+	w.err = nil
+
+	return err
+}
@@ -0,0 +1,153 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+var errRejected = errors.New("rejected")
+
+// recordingStorage remembers sizes of created batches, batches containing a note titled reject fail as a whole
+type recordingStorage struct {
+	mu      sync.Mutex
+	notes   []entities.Note
+	batches []int
+	reject  string
+}
+
+func (s *recordingStorage) NewNotes(notes []entities.Note) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, note := range notes {
+		if s.reject != "" && note.Title == s.reject {
+			return nil, errRejected
+		}
+	}
+
+	ids := make([]int, len(notes))
+	for i, note := range notes {
+		s.notes = append(s.notes, note)
+		ids[i] = len(s.notes)
+	}
+	s.batches = append(s.batches, len(notes))
+
+	return ids, nil
+}
+
+func TestBatchWriterThreshold(t *testing.T) {
+	storage := &recordingStorage{}
+	w := NewBatchWriter(storage, 3, 0)
+
+	// 7 notes make two full batches and leave one note buffered
+	for i := 0; i < 7; i++ {
+		if err := w.Add(entities.Note{Title: fmt.Sprintf("Note %d", i), Content: "Content."}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	storage.mu.Lock()
+	if len(storage.notes) != 6 {
+		t.Errorf("Expected 6 notes before close, got %d", len(storage.notes))
+	}
+	storage.mu.Unlock()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(storage.notes) != 7 || storage.notes[6].Title != "Note 6" {
+		t.Errorf("Expected all 7 notes after close, got %d", len(storage.notes))
+	}
+	if fmt.Sprint(storage.batches) != "[3 3 1]" {
+		t.Errorf("Expected batches [3 3 1], got %v", storage.batches)
+	}
+
+	if err := w.Add(entities.Note{Title: "Late", Content: "Content."}); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestBatchWriterBelowThreshold(t *testing.T) {
+	storage := &recordingStorage{}
+	w := NewBatchWriter(storage, 100, 10*time.Millisecond)
+	defer w.Close()
+
+	_ = w.Add(entities.Note{Title: "First", Content: "Content."})
+	_ = w.Add(entities.Note{Title: "Second", Content: "Content."})
+
+	// a batch below the threshold is flushed by the timer
+	deadline := time.Now().Add(time.Second)
+	for {
+		storage.mu.Lock()
+		n := len(storage.notes)
+		storage.mu.Unlock()
+
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 notes flushed by the timer, got %d", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBatchWriterFailedFlush(t *testing.T) {
+	storage := &recordingStorage{reject: "Bad"}
+	w := NewBatchWriter(storage, 3, 0)
+
+	_ = w.Add(entities.Note{Title: "First", Content: "Content."})
+	_ = w.Add(entities.Note{Title: "Bad", Content: "Content."})
+
+	// the failing batch is isolated, only the bad note is reported and dropped
+	err := w.Add(entities.Note{Title: "Third", Content: "Content."})
+	var flushErr *FlushError
+	if !errors.As(err, &flushErr) || !errors.Is(err, errRejected) {
+		t.Fatalf("Expected a FlushError wrapping errRejected, got %v", err)
+	}
+	if len(flushErr.Notes) != 1 || flushErr.Notes[0].Title != "Bad" {
+		t.Errorf("Expected only the bad note to fail, got %v", flushErr.Notes)
+	}
+
+	// later calls succeed and retrying creates no duplicates
+	if err := w.Add(entities.Note{Title: "Fourth", Content: "Content."}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var titles []string
+	for _, note := range storage.notes {
+		titles = append(titles, note.Title)
+	}
+	if fmt.Sprint(titles) != "[First Third Fourth]" {
+		t.Errorf("Expected notes [First Third Fourth], got %v", titles)
+	}
+}
+
+func TestBatchWriterFailedNoteNotBuffered(t *testing.T) {
+	storage := &recordingStorage{reject: "Bad"}
+	w := NewBatchWriter(storage, 1, 0)
+
+	if err := w.Add(entities.Note{Title: "Bad", Content: "Content."}); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected errRejected, got %v", err)
+	}
+
+	// the failed note isn't flushed again
+	if err := w.Flush(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(storage.notes) != 0 {
+		t.Errorf("Expected no notes, got %d", len(storage.notes))
+	}
+}
@@ -0,0 +1,103 @@
+package links
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"go-notes/internal/entities"
+)
+
+// linkPattern matches links to other notes written as [[ID]] in content
+var linkPattern = regexp.MustCompile(`\[\[(\d+)\]\]`)
+
+// Edge is a link from one note to another
+type Edge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Extract returns IDs of notes linked from content in order of first appearance, without duplicates
+func Extract(content string) []int {
+	var (
+		ids  []int
+		seen = make(map[int]bool)
+	)
+	for _, match := range linkPattern.FindAllStringSubmatch(content, -1) {
+		id, err := strconv.Atoi(match[1])
+		if err != nil || seen[id] {
+			// IDs too big for int can't belong to a note
+			continue
+		}
+
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Replace rewrites links to the note with ID from in content into links to the note with ID to
+func Replace(content string, from, to int) string {
+	return linkPattern.ReplaceAllStringFunc(content, func(link string) string {
+		id, err := strconv.Atoi(linkPattern.FindStringSubmatch(link)[1])
+		if err != nil || id != from {
+			return link
+		}
+
+		return "[[" + strconv.Itoa(to) + "]]"
+	})
+}
+
+// Edges returns links between the given notes ordered by source and target.
+// Links to notes which are not among them and links of a note to itself are skipped
+func Edges(notes []entities.Note) []Edge {
+	exists := make(map[int]bool, len(notes))
+	for _, note := range notes {
+		exists[note.ID] = true
+	}
+
+	var edges []Edge
+	for _, note := range notes {
+		for _, to := range Extract(note.Content) {
+			if to != note.ID && exists[to] {
+				edges = append(edges, Edge{From: note.ID, To: to})
+			}
+		}
+	}
+
+	sortEdges(edges)
+
+	return edges
+}
+
+// Broken returns links of the given notes to notes which are not among them, ordered by source and target
+func Broken(notes []entities.Note) []Edge {
+	exists := make(map[int]bool, len(notes))
+	for _, note := range notes {
+		exists[note.ID] = true
+	}
+
+	var broken []Edge
+	for _, note := range notes {
+		for _, to := range Extract(note.Content) {
+			if !exists[to] {
+				broken = append(broken, Edge{From: note.ID, To: to})
+			}
+		}
+	}
+
+	sortEdges(broken)
+
+	return broken
+}
+
+// sortEdges orders edges by source and target
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+}
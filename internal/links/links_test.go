@@ -0,0 +1,55 @@
+package links
+
+import (
+	"reflect"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestExtract(t *testing.T) {
+	got := Extract("See [[3]] and [[12]], again [[3]]; not a link: [3] [[x]] [[99999999999999999999]]")
+	want := []int{3, 12}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	got := Replace("See [[3]], [[03]] and [[13]]; not a link: [3]", 3, 42)
+	want := "See [[42]], [[42]] and [[13]]; not a link: [3]"
+
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestEdges(t *testing.T) {
+	notes := []entities.Note{
+		{ID: 2, Content: "Links to [[1]] and a missing [[7]]"},
+		{ID: 1, Content: "Links to itself [[1]] and to [[3]] and [[2]]"},
+		{ID: 3, Content: "No links"},
+	}
+
+	got := Edges(notes)
+	want := []Edge{{From: 1, To: 2}, {From: 1, To: 3}, {From: 2, To: 1}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestBroken(t *testing.T) {
+	notes := []entities.Note{
+		{ID: 2, Content: "Links to [[1]] and a missing [[7]]"},
+		{ID: 1, Content: "Links to itself [[1]], to [[2]] and to missing [[9]] and [[8]]"},
+	}
+
+	got := Broken(notes)
+	want := []Edge{{From: 1, To: 8}, {From: 1, To: 9}, {From: 2, To: 7}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"go-notes/internal/storage"
+)
+
+// error codes of the error envelope, so clients can handle errors without parsing messages
+const (
+	codeNotFound         = "not_found"
+	codeValidation       = "validation"
+	codeConflict         = "conflict"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeInvalidToken     = "invalid_token"
+	codeTokenExpired     = "token_expired"
+	codeInternal         = "internal"
+)
+
+// errorEnvelope is the body of every error response, e.g. {"error":{"code":"not_found","message":"note not found"}}
+type errorEnvelope struct {
+	Error apiError `json:"error"`
+}
+
+// apiError describes an error by a stable code and a human readable message
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeErrorCode writes the error envelope with the given status
+func writeErrorCode(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, errorEnvelope{Error: apiError{Code: code, Message: message}})
+}
+
+// writeError maps storage errors to HTTP statuses and codes of the error envelope
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeErrorCode(w, http.StatusNotFound, codeNotFound, "note not found")
+	case errors.Is(err, storage.ErrConflict), errors.Is(err, storage.ErrIDTaken):
+		writeErrorCode(w, http.StatusConflict, codeConflict, "note conflicts with an existing one")
+	case errors.Is(err, storage.ErrContentTooLong), errors.Is(err, storage.ErrInvalidContent),
+		errors.Is(err, storage.ErrInvalidParam):
+		writeErrorCode(w, http.StatusBadRequest, codeValidation, err.Error())
+	default:
+		// other errors are failures of the storage, e.g. of reading the database, their details are not exposed
+		writeErrorCode(w, http.StatusInternalServerError, codeInternal, "internal error")
+	}
+}
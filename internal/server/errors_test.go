@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+func TestErrorEnvelope(t *testing.T) {
+	handler := New(notesStorage{notes: map[int]entities.Note{}})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{name: "missing note", method: http.MethodGet, path: "/notes/7", status: http.StatusNotFound, code: codeNotFound},
+		{name: "invalid create body", method: http.MethodPost, path: "/notes", body: "{not json", status: http.StatusBadRequest, code: codeValidation},
+		{name: "invalid ID", method: http.MethodGet, path: "/notes/x", status: http.StatusBadRequest, code: codeValidation},
+		{name: "unknown method", method: http.MethodDelete, path: "/notes", status: http.StatusMethodNotAllowed, code: codeMethodNotAllowed},
+		{name: "unknown path", method: http.MethodGet, path: "/other", status: http.StatusNotFound, code: codeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Expected a JSON response, got %q", contentType)
+			}
+
+			var envelope errorEnvelope
+			if err := json.NewDecoder(rec.Body).Decode(&envelope); err != nil {
+				t.Fatalf("Expected the error envelope, got %v", err)
+			}
+			if envelope.Error.Code != tt.code || envelope.Error.Message == "" {
+				t.Errorf("Expected code %q with a message, got %+v", tt.code, envelope.Error)
+			}
+		})
+	}
+}
+
+// failingStorage fails every read with readErr and every creation with createErr
+type failingStorage struct {
+	readErr, createErr error
+}
+
+func (s failingStorage) NewNote(string, string) (int, error) { return 0, s.createErr }
+
+func (s failingStorage) GetNoteByID(int) (entities.Note, error) { return entities.Note{}, s.readErr }
+
+func (s failingStorage) GetNotesSinceID(int) ([]entities.Note, error) { return nil, s.readErr }
+
+func (s failingStorage) ImportNote(entities.Note) (bool, error) { return false, s.createErr }
+
+func TestErrorStatusOfStorageErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "closed database", err: errors.New("sql: database is closed"), status: http.StatusInternalServerError, code: codeInternal},
+		{name: "content too long", err: &storage.ContentTooLongError{Length: 10, Max: 5}, status: http.StatusBadRequest, code: codeValidation},
+		{name: "invalid content", err: storage.ErrInvalidContent, status: http.StatusBadRequest, code: codeValidation},
+		{name: "invalid param", err: fmt.Errorf("%w: invalid param length", storage.ErrInvalidParam), status: http.StatusBadRequest, code: codeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(failingStorage{readErr: errors.New("disk I/O error"), createErr: tt.err})
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"title":"T","content":"C"}`)))
+
+			var envelope errorEnvelope
+			_ = json.NewDecoder(rec.Body).Decode(&envelope)
+			if rec.Code != tt.status || envelope.Error.Code != tt.code {
+				t.Errorf("Expected status %d with code %q, got %d with %+v", tt.status, tt.code, rec.Code, envelope.Error)
+			}
+		})
+	}
+
+	// failures of reading are not blamed on the client and don't expose details
+	rec := httptest.NewRecorder()
+	New(failingStorage{readErr: errors.New("disk I/O error")}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/notes/1", nil))
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "disk") {
+		t.Errorf("Expected an internal error without details, got %d %s", rec.Code, rec.Body.String())
+	}
+}
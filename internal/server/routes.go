@@ -0,0 +1,133 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// route describes a single endpoint of the REST API.
+// Routes are used both for dispatching requests and for generating the OpenAPI document
+type route struct {
+	// method is the HTTP method of the endpoint
+	method string
+
+	// path is the endpoint path, segments like {id} match any value and are passed to the handler
+	path string
+
+	// summary is a short description of the endpoint
+	summary string
+
+	// params lists path and query parameters
+	params []param
+
+	// body is the schema of the request body, empty if the endpoint doesn't accept one
+	body string
+
+	// responses lists possible responses of the endpoint
+	responses []response
+
+	// handle serves the request with path parameters extracted from the URL
+	handle func(s *server, w http.ResponseWriter, r *http.Request, pathParams map[string]string)
+}
+
+// param describes a path or a query parameter
+type param struct {
+	name        string
+	in          string
+	description string
+
+	// typ is the schema type of the parameter, integer if empty
+	typ string
+}
+
+// response describes a response of an endpoint
+type response struct {
+	status      int
+	description string
+
+	// schema of the response body: "Note", "[]Note" for an array of notes, "Error" for the error envelope
+	// or empty for a plain text body
+	schema string
+}
+
+// routes lists all endpoints of the REST API
+var routes = []route{
+	{
+		method:  http.MethodGet,
+		path:    "/notes",
+		summary: "List notes with ID greater than since_id",
+		params: []param{
+			{name: "since_id", in: "query", description: "return only notes with greater ID, all notes by default"},
+		},
+		responses: []response{
+			{status: http.StatusOK, description: "notes ordered by ID", schema: "[]Note"},
+			{status: http.StatusBadRequest, description: "invalid since_id", schema: "Error"},
+		},
+		handle: (*server).listNotes,
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/notes",
+		summary: "Create a note, a note with an ID is imported keeping it",
+		body:    "Note",
+		responses: []response{
+			{status: http.StatusCreated, description: "created note", schema: "Note"},
+			{status: http.StatusOK, description: "identical note with the same ID already exists", schema: "Note"},
+			{status: http.StatusBadRequest, description: "invalid note", schema: "Error"},
+			{status: http.StatusConflict, description: "different note with the same ID already exists", schema: "Error"},
+		},
+		handle: (*server).createNote,
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/notes/{id}",
+		summary: "Get a note by ID",
+		params: []param{
+			{name: "id", in: "path", description: "note ID"},
+		},
+		responses: []response{
+			{status: http.StatusOK, description: "note", schema: "Note"},
+			{status: http.StatusBadRequest, description: "invalid note ID", schema: "Error"},
+			{status: http.StatusNotFound, description: "note not found", schema: "Error"},
+		},
+		handle: (*server).getNote,
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/shared/{token}",
+		summary: "Get a note shared read-only by a token of 'share'",
+		params: []param{
+			{name: "token", in: "path", description: "share token", typ: "string"},
+		},
+		responses: []response{
+			{status: http.StatusOK, description: "shared note", schema: "Note"},
+			{status: http.StatusForbidden, description: "invalid or tampered token", schema: "Error"},
+			{status: http.StatusNotFound, description: "note not found or sharing is disabled", schema: "Error"},
+			{status: http.StatusGone, description: "token expired", schema: "Error"},
+		},
+		handle: (*server).getSharedNote,
+	},
+}
+
+// match checks whether the URL path matches the route path and extracts path parameters
+func (rt route) match(urlPath string) (map[string]string, bool) {
+	routeSegments := strings.Split(strings.Trim(rt.path, "/"), "/")
+	urlSegments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(routeSegments) != len(urlSegments) {
+		return nil, false
+	}
+
+	pathParams := make(map[string]string)
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			pathParams[strings.Trim(segment, "{}")] = urlSegments[i]
+			continue
+		}
+
+		if segment != urlSegments[i] {
+			return nil, false
+		}
+	}
+
+	return pathParams, true
+}
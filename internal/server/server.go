@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-notes/internal/entities"
+)
+
+// Storage is the part of notes storage served over HTTP
+type Storage interface {
+	// NewNote creates a new note with the given title and content and returns its ID
+	NewNote(noteTitle, content string) (int, error)
+
+	// GetNoteByID retrieves a note by its ID
+	GetNoteByID(noteID int) (entities.Note, error)
+
+	// GetNotesSinceID retrieves notes with ID greater than the specified one
+	GetNotesSinceID(id int) ([]entities.Note, error)
+
+	// ImportNote inserts a note keeping its ID, returns false if an identical note already exists
+	ImportNote(note entities.Note) (bool, error)
+}
+
+// server holds handlers of the REST API
+type server struct {
+	storage Storage
+
+	// shareSecret signs share tokens, sharing is disabled if it's empty
+	shareSecret []byte
+}
+
+// New creates an HTTP handler exposing notes from the storage as a REST API described by routes
+func New(storage Storage, opts ...Option) http.Handler {
+	s := &server{storage: storage}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ServeHTTP dispatches the request to the first route matching its path and method
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var allowed []string
+	for _, rt := range routes {
+		pathParams, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		if rt.method != r.Method {
+			allowed = append(allowed, rt.method)
+			continue
+		}
+
+		rt.handle(s, w, r, pathParams)
+		return
+	}
+
+	// path is known, but not with this method
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeErrorCode(w, http.StatusMethodNotAllowed, codeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeErrorCode(w, http.StatusNotFound, codeNotFound, "path not found")
+}
+
+// getNote writes a single note addressed by ID
+func (s *server) getNote(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	id, err := strconv.Atoi(pathParams["id"])
+	if err != nil {
+		writeErrorCode(w, http.StatusBadRequest, codeValidation, "invalid note ID")
+		return
+	}
+
+	note, err := s.storage.GetNoteByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, note)
+}
+
+// listNotes writes notes with ID greater than the optional since_id query parameter
+func (s *server) listNotes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	sinceID := 0
+	if v := r.URL.Query().Get("since_id"); v != "" {
+		var err error
+		sinceID, err = strconv.Atoi(v)
+		if err != nil {
+			writeErrorCode(w, http.StatusBadRequest, codeValidation, "invalid since_id")
+			return
+		}
+	}
+
+	notes, err := s.storage.GetNotesSinceID(sinceID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// always respond with an array, even when there are no notes
+	if notes == nil {
+		notes = []entities.Note{}
+	}
+
+	writeJSON(w, http.StatusOK, notes)
+}
+
+// createNote creates a note from the request body.
+// Notes with an ID are imported as is, which responds 200 if an identical note already exists and 409 if a different one does
+func (s *server) createNote(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var note entities.Note
+	if err := json.NewDecoder(r.Body).Decode(&note); err != nil {
+		writeErrorCode(w, http.StatusBadRequest, codeValidation, "invalid note: "+err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if note.ID > 0 {
+		created, err := s.storage.ImportNote(note)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if !created {
+			status = http.StatusOK
+		}
+	} else {
+		id, err := s.storage.NewNote(note.Title, note.Content)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		note.ID = id
+	}
+
+	// respond with the stored note including generated fields
+	stored, err := s.storage.GetNoteByID(note.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, stored)
+}
+
+// writeJSON writes value as a JSON response with the given status
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
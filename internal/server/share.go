@@ -0,0 +1,119 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned for share tokens which are malformed or not signed with the secret
+	ErrInvalidToken = errors.New("invalid share token")
+
+	// ErrTokenExpired is returned for correctly signed share tokens past their expiry
+	ErrTokenExpired = errors.New("share token expired")
+)
+
+// Option configures the server created by New
+type Option func(*server)
+
+// WithShareSecret enables GET /shared/{token} serving notes shared by tokens signed with the secret
+func WithShareSecret(secret []byte) Option {
+	return func(s *server) {
+		s.shareSecret = secret
+	}
+}
+
+// SignShareToken creates a token granting read-only access to the note until expiresAt, zero time never expires.
+// The token is the note ID and expiry signed with HMAC-SHA256 of the secret, both parts encoded as base64url
+func SignShareToken(secret []byte, noteID int, expiresAt time.Time) string {
+	var expires int64
+	if !expiresAt.IsZero() {
+		expires = expiresAt.Unix()
+	}
+
+	payload := []byte(fmt.Sprintf("%d:%d", noteID, expires))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(secret, payload))
+}
+
+// ParseShareToken validates the signature and expiry of a token created by SignShareToken and returns the note ID
+func ParseShareToken(secret []byte, token string, now time.Time) (int, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return 0, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	// signature is checked before the payload is trusted
+	if !hmac.Equal(signature, sign(secret, payload)) {
+		return 0, ErrInvalidToken
+	}
+
+	idStr, expiresStr, found := strings.Cut(string(payload), ":")
+	if !found {
+		return 0, ErrInvalidToken
+	}
+	noteID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	if expires != 0 && !now.Before(time.Unix(expires, 0)) {
+		return 0, ErrTokenExpired
+	}
+
+	return noteID, nil
+}
+
+// sign computes HMAC-SHA256 of the payload with the secret
+func sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// getSharedNote writes the note a share token grants access to
+func (s *server) getSharedNote(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	// without a secret no token can be valid, so sharing looks the same as an unknown path
+	if len(s.shareSecret) == 0 {
+		writeErrorCode(w, http.StatusNotFound, codeNotFound, "path not found")
+		return
+	}
+
+	noteID, err := ParseShareToken(s.shareSecret, pathParams["token"], time.Now())
+	switch {
+	case errors.Is(err, ErrTokenExpired):
+		writeErrorCode(w, http.StatusGone, codeTokenExpired, err.Error())
+		return
+	case err != nil:
+		writeErrorCode(w, http.StatusForbidden, codeInvalidToken, err.Error())
+		return
+	}
+
+	note, err := s.storage.GetNoteByID(noteID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, note)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// notesStorage serves notes from a map, only reading is supported
+type notesStorage struct {
+	notes map[int]entities.Note
+}
+
+func (s notesStorage) NewNote(string, string) (int, error) { return 0, errors.New("read-only") }
+
+func (s notesStorage) GetNoteByID(noteID int) (entities.Note, error) {
+	note, ok := s.notes[noteID]
+	if !ok {
+		return entities.Note{}, sql.ErrNoRows
+	}
+
+	return note, nil
+}
+
+func (s notesStorage) GetNotesSinceID(int) ([]entities.Note, error) { return nil, nil }
+
+func (s notesStorage) ImportNote(entities.Note) (bool, error) { return false, errors.New("read-only") }
+
+func TestShareToken(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	now := time.Now()
+
+	token := SignShareToken(secret, 42, now.Add(time.Hour))
+	if noteID, err := ParseShareToken(secret, token, now); err != nil || noteID != 42 {
+		t.Errorf("Expected note 42, got %d (%v)", noteID, err)
+	}
+
+	if _, err := ParseShareToken(secret, token, now.Add(2*time.Hour)); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired, got %v", err)
+	}
+
+	// tokens without expiry stay valid
+	forever := SignShareToken(secret, 42, time.Time{})
+	if _, err := ParseShareToken(secret, forever, now.AddDate(10, 0, 0)); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	// another secret or a changed payload invalidates the signature
+	if _, err := ParseShareToken([]byte("another secret!!"), token, now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for another secret, got %v", err)
+	}
+	_, signature, _ := strings.Cut(token, ".")
+	tampered := SignShareToken([]byte("another secret!!"), 43, time.Time{})
+	tampered, _, _ = strings.Cut(tampered, ".")
+	if _, err := ParseShareToken(secret, tampered+"."+signature, now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for a changed payload, got %v", err)
+	}
+	if _, err := ParseShareToken(secret, "garbage", now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected ErrInvalidToken for garbage, got %v", err)
+	}
+}
+
+func TestGetSharedNote(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	storage := notesStorage{notes: map[int]entities.Note{1: {ID: 1, Title: "Shared", Content: "Read me"}}}
+	handler := New(storage, WithShareSecret(secret))
+
+	valid := SignShareToken(secret, 1, time.Now().Add(time.Hour))
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{name: "valid", token: valid, status: http.StatusOK},
+		{name: "expired", token: SignShareToken(secret, 1, time.Now().Add(-time.Minute)), status: http.StatusGone},
+		{name: "tampered", token: "x" + valid, status: http.StatusForbidden},
+		{name: "missing note", token: SignShareToken(secret, 2, time.Time{}), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shared/"+tt.token, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d: %s", tt.status, rec.Code, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var note entities.Note
+			if err := json.NewDecoder(rec.Body).Decode(&note); err != nil || note.Content != "Read me" {
+				t.Errorf("Expected the shared note, got %+v (%v)", note, err)
+			}
+		})
+	}
+
+	// links don't work while sharing is disabled
+	rec := httptest.NewRecorder()
+	New(storage).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shared/"+valid, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d without a secret, got %d", http.StatusNotFound, rec.Code)
+	}
+}
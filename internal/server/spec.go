@@ -0,0 +1,147 @@
+package server
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// object is a JSON object of the OpenAPI document
+type object = map[string]interface{}
+
+// Spec generates an OpenAPI 3 document describing routes of the REST API
+func Spec() ([]byte, error) {
+	paths := object{}
+	for _, rt := range routes {
+		// several methods may share the same path
+		item, ok := paths[rt.path].(object)
+		if !ok {
+			item = object{}
+			paths[rt.path] = item
+		}
+
+		item[strings.ToLower(rt.method)] = operation(rt)
+	}
+
+	doc := object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "go-notes",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": object{
+				"Note":  schemaOf(reflect.TypeOf(entities.Note{})),
+				"Error": schemaOf(reflect.TypeOf(errorEnvelope{})),
+			},
+		},
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// operation describes a single route as an OpenAPI operation
+func operation(rt route) object {
+	op := object{"summary": rt.summary}
+
+	if len(rt.params) > 0 {
+		var params []object
+		for _, p := range rt.params {
+			typ := p.typ
+			if typ == "" {
+				typ = "integer"
+			}
+
+			params = append(params, object{
+				"name":        p.name,
+				"in":          p.in,
+				"description": p.description,
+				"required":    p.in == "path",
+				"schema":      object{"type": typ},
+			})
+		}
+		op["parameters"] = params
+	}
+
+	if rt.body != "" {
+		op["requestBody"] = object{
+			"required": true,
+			"content":  object{"application/json": object{"schema": schemaRef(rt.body)}},
+		}
+	}
+
+	responses := object{}
+	for _, resp := range rt.responses {
+		r := object{"description": resp.description}
+		if resp.schema != "" {
+			r["content"] = object{"application/json": object{"schema": schemaRef(resp.schema)}}
+		} else {
+			r["content"] = object{"text/plain": object{"schema": object{"type": "string"}}}
+		}
+
+		responses[strconv.Itoa(resp.status)] = r
+	}
+	op["responses"] = responses
+
+	return op
+}
+
+// schemaRef references a component schema, "[]Name" references an array of it
+func schemaRef(name string) object {
+	if strings.HasPrefix(name, "[]") {
+		return object{"type": "array", "items": schemaRef(strings.TrimPrefix(name, "[]"))}
+	}
+
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+// schemaOf describes a struct type using JSON names of its fields
+func schemaOf(t reflect.Type) object {
+	properties := object{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		// pointer fields are optional and may be null
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		} else if options != "omitempty" {
+			required = append(required, name)
+		}
+
+		properties[name] = typeSchema(fieldType)
+	}
+
+	return object{"type": "object", "properties": properties, "required": required}
+}
+
+// typeSchema describes a field type
+func typeSchema(t reflect.Type) object {
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return object{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		return object{"type": "string"}
+	case t.Kind() == reflect.Int:
+		return object{"type": "integer"}
+	case t.Kind() == reflect.Bool:
+		return object{"type": "boolean"}
+	case t.Kind() == reflect.Slice:
+		return object{"type": "array", "items": typeSchema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		return schemaOf(t)
+	default:
+		return object{}
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpec(t *testing.T) {
+	data, err := Spec()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Type string `json:"type"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	if doc.OpenAPI == "" {
+		t.Error("Expected openapi version to be set")
+	}
+
+	// every route is described under its path and method
+	if _, ok := doc.Paths["/notes"]["get"]; !ok {
+		t.Error("Expected GET /notes in spec")
+	}
+	if _, ok := doc.Paths["/notes"]["post"]; !ok {
+		t.Error("Expected POST /notes in spec")
+	}
+	if _, ok := doc.Paths["/notes/{id}"]["get"]; !ok {
+		t.Error("Expected GET /notes/{id} in spec")
+	}
+
+	if _, ok := doc.Components.Schemas["Error"].Properties["error"]; !ok {
+		t.Error("Expected Error schema with the error envelope in spec")
+	}
+
+	note, ok := doc.Components.Schemas["Note"]
+	if !ok {
+		t.Fatal("Expected Note schema in spec")
+	}
+
+	expected := map[string]string{
+		"id":             "integer",
+		"title":          "string",
+		"content":        "string",
+		"created_at":     "string",
+		"last_edited_at": "string",
+		"pinned_at":      "string",
+	}
+	for name, typ := range expected {
+		if got := note.Properties[name].Type; got != typ {
+			t.Errorf("Expected Note field %s of type %s, got %q", name, typ, got)
+		}
+	}
+}
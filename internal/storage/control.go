@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// isDisallowedControl reports whether r is a control character which doesn't belong into note content:
+// C0 controls like NUL and ESC and DEL, except tabs, newlines and carriage returns
+func isDisallowedControl(r rune) bool {
+	return (r < 0x20 && r != '\t' && r != '\n' && r != '\r') || r == 0x7f
+}
+
+// CheckControlChars returns ErrInvalidContent naming the first disallowed control character of content,
+// tabs and line breaks are allowed
+func CheckControlChars(content string) error {
+	if i := strings.IndexFunc(content, isDisallowedControl); i >= 0 {
+		return fmt.Errorf("%w: control character %U at byte %d", ErrInvalidContent, content[i], i)
+	}
+
+	return nil
+}
+
+// StripControlChars removes disallowed control characters from content, see CheckControlChars
+func StripControlChars(content string) string {
+	return strings.Map(func(r rune) rune {
+		if isDisallowedControl(r) {
+			return -1
+		}
+		return r
+	}, content)
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckControlChars(t *testing.T) {
+	if err := CheckControlChars("Line\tone\r\nLine two\n"); err != nil {
+		t.Errorf("Expected tabs and newlines to be allowed, got %v", err)
+	}
+
+	for _, content := range []string{"before\x00after", "\x1b[31mred", "del\x7f"} {
+		if err := CheckControlChars(content); !errors.Is(err, ErrInvalidContent) {
+			t.Errorf("Expected ErrInvalidContent for %q, got %v", content, err)
+		}
+	}
+}
+
+func TestStripControlChars(t *testing.T) {
+	if stripped := StripControlChars("a\x00b\tc\n\x1bd"); stripped != "ab\tc\nd" {
+		t.Errorf("Expected %q, got %q", "ab\tc\nd", stripped)
+	}
+}
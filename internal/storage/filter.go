@@ -0,0 +1,16 @@
+package storage
+
+import "time"
+
+// NoteFilter selects notes matching all of its set conditions, a zero filter matches every note
+type NoteFilter struct {
+	// Keyword matches notes containing it in title or content, like a keyword search
+	Keyword string
+
+	// Tag matches notes having the tag
+	Tag string
+
+	// CreatedBefore matches notes created before the time, unless it's zero.
+	// Notes without a known creation time don't match
+	CreatedBefore time.Time
+}
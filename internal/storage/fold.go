@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldCase converts s to NFC and lowercases ASCII letters only, leaving other characters intact.
+// Keywords match notes the same way LIKE does when both are folded
+func FoldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+
+		return r
+	}, norm.NFC.String(s))
+}
@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"testing"
+
+	"go-notes/internal/storage/storagetest"
+)
+
+func TestStorageConformance(t *testing.T) {
+	storagetest.RunStorageTests(t, func() storagetest.Storage {
+		return New()
+	})
+}
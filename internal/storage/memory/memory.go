@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// maxStringLength is the maximum allowed length of titles, keywords and note content in bytes, the same as in sqlite
+const maxStringLength = 256000
+
+// errors of rejected params, they match storage.ErrInvalidParam
+var (
+	invalidNum         = fmt.Errorf("%w: invalid number", storage.ErrInvalidParam)
+	invalidParamLength = fmt.Errorf("%w: invalid param length", storage.ErrInvalidParam)
+)
+
+// Storage keeps notes in memory, it's safe for concurrent use.
+// It follows the same rules as the sqlite storage, so it can replace it in tests
+type Storage struct {
+	// mu guards notes
+	mu    sync.RWMutex
+	notes map[int]entities.Note
+
+	// now returns the current time, timestamps have millisecond precision like in sqlite
+	now func() time.Time
+}
+
+// New creates an empty Storage
+func New() *Storage {
+	return &Storage{
+		notes: make(map[int]entities.Note),
+		now:   func() time.Time { return time.Now().UTC().Truncate(time.Millisecond) },
+	}
+}
+
+// Close does nothing, it's here so Storage can replace storages holding resources
+func (s *Storage) Close() error {
+	return nil
+}
+
+// NewNote creates a new note with the given title and content and returns its ID.
+// The title is stored normalized to NFC
+func (s *Storage) NewNote(noteTitle, content string) (int, error) {
+	noteTitle = norm.NFC.String(noteTitle)
+	if err := validateString(noteTitle); err != nil {
+		return 0, err
+	}
+	if err := validateContent(content); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// like an sqlite INTEGER PRIMARY KEY, a new note gets ID following the biggest one
+	id := 1
+	for existing := range s.notes {
+		id = max(id, existing+1)
+	}
+
+	now := s.now()
+	s.notes[id] = entities.Note{ID: id, Title: noteTitle, Content: content, CreatedAt: now, LastEditedAt: now}
+
+	return id, nil
+}
+
+// DeleteNote deletes a note by its ID
+func (s *Storage) DeleteNote(id int) (int, error) {
+	if err := validateID(id); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.notes[id]; !ok {
+		return 0, sql.ErrNoRows
+	}
+	delete(s.notes, id)
+
+	return id, nil
+}
+
+// SetNoteContent updates the content of a note with the specified ID
+func (s *Storage) SetNoteContent(noteID int, content string) error {
+	if err := validateID(noteID); err != nil {
+		return err
+	}
+	if err := validateContent(content); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	note, ok := s.notes[noteID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	// unchanged content is left as is, so its last edit time is kept like in sqlite
+	if note.Content == content {
+		return nil
+	}
+
+	note.Content = content
+	note.LastEditedAt = s.now()
+	s.notes[noteID] = note
+
+	return nil
+}
+
+// SearchNotesByKeyword searches for notes containing the specified keyword in titles or content.
+// Like sqlite LIKE, only ASCII letters are matched case-insensitively
+func (s *Storage) SearchNotesByKeyword(keyword string) ([]entities.Note, error) {
+	if err := validateString(keyword); err != nil {
+		return nil, err
+	}
+	keyword = storage.FoldCase(keyword)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found []entities.Note
+	for _, note := range s.sortedNotes() {
+		if strings.Contains(storage.FoldCase(note.Title), keyword) ||
+			strings.Contains(storage.FoldCase(note.Content), keyword) {
+			found = append(found, note)
+		}
+	}
+
+	return found, nil
+}
+
+// GetNoteByID retrieves a note by its ID
+func (s *Storage) GetNoteByID(noteID int) (entities.Note, error) {
+	if err := validateID(noteID); err != nil {
+		return entities.Note{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	note, ok := s.notes[noteID]
+	if !ok {
+		return entities.Note{}, sql.ErrNoRows
+	}
+
+	return note, nil
+}
+
+// GetAllNotes retrieves all notes ordered by ID
+func (s *Storage) GetAllNotes() ([]entities.Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sortedNotes(), nil
+}
+
+// sortedNotes returns copies of all notes ordered by ID, the caller must hold mu
+func (s *Storage) sortedNotes() []entities.Note {
+	if len(s.notes) == 0 {
+		return nil
+	}
+
+	notes := make([]entities.Note, 0, len(s.notes))
+	for _, note := range s.notes {
+		notes = append(notes, note)
+	}
+	sort.Slice(notes, func(i, j int) bool { return notes[i].ID < notes[j].ID })
+
+	return notes
+}
+
+// validateID checks that a note ID is within the range sqlite accepts
+func validateID(id int) error {
+	if id < 1 || id > math.MaxInt32 {
+		return invalidNum
+	}
+
+	return nil
+}
+
+// validateString checks that a title or keyword is not empty and not too long
+func validateString(s string) error {
+	if len(s) < 1 || len(s) > maxStringLength {
+		return invalidParamLength
+	}
+
+	return nil
+}
+
+// validateContent checks that note content is not empty, fits the maximum length and has no control characters
+// except tabs and line breaks
+func validateContent(content string) error {
+	if err := storage.CheckControlChars(content); err != nil {
+		return err
+	}
+
+	if len(content) < 1 {
+		return invalidParamLength
+	}
+
+	if len(content) > maxStringLength {
+		return &storage.ContentTooLongError{Length: len(content), Max: maxStringLength}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentNewNote(t *testing.T) {
+	storage := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = storage.NewNote("Note", "Content.")
+		}()
+	}
+	wg.Wait()
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 50 {
+		t.Fatalf("Expected 50 notes, got %d", len(notes))
+	}
+
+	// every note got a unique ID
+	for i, note := range notes {
+		if note.ID != i+1 {
+			t.Errorf("Expected IDs 1..50 in order, got %d at position %d", note.ID, i)
+		}
+	}
+}
+
+func TestReturnedNotesAreCopies(t *testing.T) {
+	storage := New()
+	id, _ := storage.NewNote("Original", "Content.")
+
+	notes, _ := storage.GetAllNotes()
+	notes[0].Title = "Changed"
+
+	note, _ := storage.GetNoteByID(id)
+	if note.Title != "Original" {
+		t.Errorf("Expected stored note to stay unchanged, got %q", note.Title)
+	}
+}
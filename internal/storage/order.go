@@ -0,0 +1,25 @@
+package storage
+
+// NoteOrder is an order notes can be sorted in
+type NoteOrder string
+
+// orders of notes, time orders start from the most recent note
+const (
+	OrderEdited  NoteOrder = "edited"  // from the most recently edited
+	OrderCreated NoteOrder = "created" // from the most recently created
+	OrderTitle   NoteOrder = "title"   // by title alphabetically
+	OrderID      NoteOrder = "id"      // by ID ascending
+)
+
+// NoteOrders lists all orders of notes
+var NoteOrders = []NoteOrder{OrderEdited, OrderCreated, OrderTitle, OrderID}
+
+// SearchScope is the part of notes a keyword is searched in
+type SearchScope string
+
+// scopes of search
+const (
+	ScopeText  SearchScope = "text"  // titles and content
+	ScopeTags  SearchScope = "tags"  // titles, content and tags
+	ScopeTitle SearchScope = "title" // titles only
+)
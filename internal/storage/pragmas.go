@@ -0,0 +1,21 @@
+package storage
+
+// Pragmas holds effective settings and size of a database, useful to verify how it is configured
+type Pragmas struct {
+	// JournalMode is the journal mode of the connection, e.g. "delete" or "wal"
+	JournalMode string
+
+	// ForeignKeys reports whether foreign key constraints are enforced
+	ForeignKeys bool
+
+	// PageSize is the size of a database page in bytes
+	PageSize int64
+
+	// PageCount is the number of pages in the database file
+	PageCount int64
+}
+
+// Size returns the size of the database in bytes
+func (p Pragmas) Size() int64 {
+	return p.PageSize * p.PageCount
+}
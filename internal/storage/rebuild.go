@@ -0,0 +1,13 @@
+package storage
+
+// RebuildReport describes derived data regenerated from the stored notes
+type RebuildReport struct {
+	// Notes is the number of notes, including trashed ones, the data was regenerated from
+	Notes int
+
+	// ContentHashes is the number of notes whose content hash was missing or wrong
+	ContentHashes int
+
+	// Indexes are names of the rebuilt indexes of notes
+	Indexes []string
+}
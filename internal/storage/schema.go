@@ -0,0 +1,22 @@
+package storage
+
+// Schema describes the database schema of a storage
+type Schema struct {
+	// Version is the number of migrations applied to the database
+	Version int
+
+	// Objects are tables, indexes and triggers ordered by their type and name
+	Objects []SchemaObject
+}
+
+// SchemaObject is a table, an index or a trigger of the schema
+type SchemaObject struct {
+	// Type is "table", "index" or "trigger"
+	Type string
+
+	// Name is the name of the object
+	Name string
+
+	// SQL is the statement creating the object
+	SQL string
+}
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// profileNamePattern matches names of profiles which are safe to use as file names
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// ArchiveToProfile moves notes created before the time into the database of the named profile,
+// see MoveNotesBefore. A profile is a separate database "<profile>.db" next to the database of this storage,
+// it is created if it doesn't exist and uses the same table prefix
+func (s *Storage) ArchiveToProfile(profile string, before time.Time) (int, error) {
+	if !profileNamePattern.MatchString(profile) {
+		return 0, fmt.Errorf("invalid profile %q, expected letters, digits, dashes or underscores", profile)
+	}
+
+	path := filepath.Join(filepath.Dir(s.path), profile+".db")
+	if filepath.Clean(path) == filepath.Clean(s.path) {
+		return 0, fmt.Errorf("profile %q is the current database", profile)
+	}
+
+	var opts []Option
+	if s.tablePrefix != "" {
+		opts = append(opts, WithTablePrefix(s.tablePrefix))
+	}
+	dest, err := New(path, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("opening profile %q: %w", profile, err)
+	}
+	// ensure the profile database is closed when done moving
+	defer dest.Close()
+
+	return s.MoveNotesBefore(dest, before)
+}
+
+// MoveNotesBefore moves notes created before the time with their tags, metadata, revisions, pin, due and expiry times
+// into dest, where they get new IDs, and deletes them from this storage. It returns the number of moved notes.
+// Each database is changed in a single transaction and dest is committed first, so if deleting fails afterwards
+// the notes are kept in both databases rather than lost
+func (s *Storage) MoveNotesBefore(dest *Storage, before time.Time) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + ` ORDER BY note_id`)
+	if err != nil {
+		return 0, err
+	}
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	destTx, err := dest.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer destTx.Rollback()
+
+	var moved []int
+	for _, note := range notes {
+		if !createdBefore(note, before) {
+			continue
+		}
+
+		tags, err := noteTags(tx, note.ID)
+		if err != nil {
+			return 0, err
+		}
+		if note.Revisions, err = noteRevisions(tx, note.ID); err != nil {
+			return 0, err
+		}
+
+		// content is stored as configured for dest
+		storedContent, uncompressedLength, err := dest.encodeContent(note.Content)
+		if err != nil {
+			return 0, err
+		}
+		// pin, due and expiry times are copied with the note, metadata is copied separately,
+		// as it is deleted from this storage together with the note
+		destID, err := insertNote(destTx, note, storedContent, uncompressedLength, tags)
+		if err != nil {
+			return 0, fmt.Errorf("copying note %d: %w", note.ID, err)
+		}
+		if err = copyNoteMeta(tx, destTx, note.ID, destID); err != nil {
+			return 0, fmt.Errorf("copying metadata of note %d: %w", note.ID, err)
+		}
+
+		moved = append(moved, note.ID)
+	}
+
+	if err = destTx.Commit(); err != nil {
+		return 0, err
+	}
+
+	// tags and metadata of deleted notes are removed by cascade
+	for _, id := range moved {
+		if _, err = tx.Exec(`DELETE FROM notes WHERE note_id = ?`, id); err != nil {
+			return 0, fmt.Errorf("notes were copied, but deleting note %d failed: %w", id, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("notes were copied, but deleting them failed: %w", err)
+	}
+
+	return len(moved), nil
+}
+
+// copyNoteMeta copies all metadata of the note srcID read with src to the note destID of the dest transaction
+func copyNoteMeta(src querier, dest *prefixedTx, srcID, destID int) error {
+	rows, err := src.Query(`SELECT key, value FROM note_meta WHERE note_id = ? ORDER BY key`, srcID)
+	if err != nil {
+		return err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err = rows.Scan(&key, &value); err != nil {
+			return err
+		}
+
+		if _, err = dest.Exec(`INSERT INTO note_meta (note_id, key, value) VALUES (?, ?, ?)`, destID, key, value); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestMoveNotesBefore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath, archivePath := filepath.Join(dir, "test.db"), filepath.Join(dir, "archive.db")
+
+	// setting pins, due dates and metadata edits the old note, which is moved as it was created long ago
+	storage, _ := New(dbPath)
+	defer storage.Close()
+	archive, _ := New(archivePath)
+	defer archive.Close()
+
+	cutoff := time.Now().AddDate(-1, 0, 0).UTC().Truncate(time.Second)
+	oldCreated := cutoff.AddDate(0, -1, 0)
+	old, _ := storage.CreateNote(entities.Note{Title: "Old", Content: "Last year", CreatedAt: oldCreated}, []string{"work"})
+	due, expires := oldCreated.AddDate(0, 0, 7), time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Second)
+	_ = storage.PinNote(old)
+	_ = storage.SetDueDate(old, due)
+	_ = storage.SetExpiry(old, expires)
+	_ = storage.SetMeta(old, "category", "ideas")
+	recent, _ := storage.NewNote("Recent", "This week")
+	// a note without a creation time is not taken as old
+	undated, _ := storage.NewNote("Undated", "Unknown")
+	_, _ = storage.db.Exec(`UPDATE notes SET created_at = NULL WHERE note_id = ?`, undated)
+	_, _ = archive.NewNote("Archived", "Already there")
+
+	moved, err := storage.MoveNotesBefore(archive, cutoff)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if moved != 1 {
+		t.Errorf("Expected 1 moved note, got %d", moved)
+	}
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 2 || notes[0].ID != recent || notes[1].ID != undated {
+		t.Errorf("Expected the recent and undated notes to stay, got %v", notes)
+	}
+
+	// the moved note keeps its times and tags under a new ID
+	archived, _ := archive.GetAllNotes()
+	if len(archived) != 2 {
+		t.Fatalf("Expected 2 archived notes, got %v", archived)
+	}
+	note := archived[1]
+	if note.Title != "Old" || note.Content != "Last year" || !note.CreatedAt.Equal(oldCreated) {
+		t.Errorf("Expected the old note with its creation time, got %+v", note)
+	}
+	if tags, _ := archive.GetNoteTags(note.ID); !reflect.DeepEqual(tags, []string{"work"}) {
+		t.Errorf("Expected tag work, got %v", tags)
+	}
+
+	// pin, due and expiry times and metadata survive the move
+	if note.PinnedAt == nil || note.DueAt == nil || !note.DueAt.Equal(due) || note.ExpiresAt == nil || !note.ExpiresAt.Equal(expires) {
+		t.Errorf("Expected pin, due date %v and expiry %v to be kept, got %+v", due, expires, note)
+	}
+	if meta, _ := archive.GetMeta(note.ID); !reflect.DeepEqual(meta, map[string]string{"category": "ideas"}) {
+		t.Errorf("Expected category metadata, got %v", meta)
+	}
+}
+
+func TestArchiveToProfile(t *testing.T) {
+	dir := t.TempDir()
+	dbPath, profilePath := filepath.Join(dir, "test.db"), filepath.Join(dir, "archive.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+	_, _ = storage.CreateNote(entities.Note{Title: "Old", Content: "Last year", CreatedAt: time.Now().AddDate(-2, 0, 0)}, nil)
+
+	if moved, err := storage.ArchiveToProfile("archive", time.Now().AddDate(-1, 0, 0)); err != nil || moved != 1 {
+		t.Fatalf("Expected 1 moved note, got %d (%v)", moved, err)
+	}
+
+	profile, _ := New(profilePath)
+	defer profile.Close()
+	if notes, _ := profile.GetAllNotes(); len(notes) != 1 {
+		t.Errorf("Expected the note in the profile, got %v", notes)
+	}
+
+	for _, name := range []string{"../elsewhere", "", "test"} {
+		if _, err := storage.ArchiveToProfile(name, time.Now()); err == nil {
+			t.Errorf("Expected an error for profile %q", name)
+		}
+	}
+}
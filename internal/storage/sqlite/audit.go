@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"go-notes/internal/entities"
+)
+
+// auditColumns lists columns scanned by scanAuditEntries
+const auditColumns = `audit_id, note_id, action, at`
+
+// GetAuditEntriesSinceID retrieves audit log entries with ID greater than the specified one ordered by ID.
+// Entries are written by triggers whenever a note is created, edited, retitled, trashed, restored or deleted
+func (s *Storage) GetAuditEntriesSinceID(id int) ([]entities.AuditEntry, error) {
+	// 0 is a valid starting point here, so only negative IDs are rejected
+	if id < 0 {
+		return nil, invalidNum
+	}
+
+	rows, err := s.db.Query(`SELECT `+auditColumns+` FROM audit_log WHERE audit_id > ? ORDER BY audit_id`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAuditEntries(rows)
+}
+
+// GetLatestAuditEntries retrieves up to n newest audit log entries ordered by ID
+func (s *Storage) GetLatestAuditEntries(n int) ([]entities.AuditEntry, error) {
+	err := validateSQLParam(n)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`SELECT * FROM (SELECT `+auditColumns+` FROM audit_log ORDER BY audit_id DESC LIMIT ?)
+		ORDER BY audit_id`, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanAuditEntries(rows)
+}
+
+// scanAuditEntries reads all audit log entries selected with auditColumns from rows and closes them
+func scanAuditEntries(rows *sql.Rows) ([]entities.AuditEntry, error) {
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var entries []entities.AuditEntry
+	for rows.Next() {
+		var entry entities.AuditEntry
+		if err := rows.Scan(&entry.ID, &entry.NoteID, &entry.Action, timestamp{&entry.At}); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
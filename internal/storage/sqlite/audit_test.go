@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+// auditActions returns actions of entries with IDs of their notes
+func auditActions(entries []entities.AuditEntry) []string {
+	actions := make([]string, len(entries))
+	for i, entry := range entries {
+		actions[i] = fmt.Sprintf("%s %d", entry.Action, entry.NoteID)
+	}
+
+	return actions
+}
+
+func TestAuditLog(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "notes.db"), WithCompression(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Plan", "first")
+	_ = storage.SetNoteContent(id, "second")
+	// unchanged content and pins are not logged
+	_ = storage.SetNoteContent(id, "second")
+	_ = storage.PinNote(id)
+
+	entries, err := storage.GetAuditEntriesSinceID(0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{fmt.Sprintf("created %d", id), fmt.Sprintf("edited %d", id)}
+	if got := auditActions(entries); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected entries %v, got %v", expected, got)
+	}
+	if entries[0].At.IsZero() {
+		t.Errorf("Expected the time of the change, got %+v", entries[0])
+	}
+
+	// only entries written after the last seen one are fetched
+	lastID := entries[len(entries)-1].ID
+	_, _ = storage.RetitleNotes("Plan", func(string) string { return "Renamed plan" })
+	_ = storage.TrashNote(id)
+	_ = storage.RestoreNote(id)
+	_, _ = storage.DeleteNote(id)
+
+	entries, _ = storage.GetAuditEntriesSinceID(lastID)
+	expected = []string{fmt.Sprintf("retitled %d", id), fmt.Sprintf("trashed %d", id), fmt.Sprintf("restored %d", id),
+		fmt.Sprintf("deleted %d", id)}
+	if got := auditActions(entries); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected entries %v, got %v", expected, got)
+	}
+
+	entries, _ = storage.GetLatestAuditEntries(2)
+	expected = []string{fmt.Sprintf("restored %d", id), fmt.Sprintf("deleted %d", id)}
+	if got := auditActions(entries); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected the latest entries %v, got %v", expected, got)
+	}
+}
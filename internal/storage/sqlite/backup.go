@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"os"
+	"time"
+)
+
+// Backup writes a consistent copy of the database to path, which must not exist yet,
+// and records the time of the backup, see LastBackup
+func (s *Storage) Backup(path string) error {
+	// VACUUM INTO refuses to overwrite a file, but reports it with an unclear message
+	if _, err := os.Stat(path); err == nil {
+		return os.ErrExist
+	}
+
+	if _, err := s.db.Exec(`VACUUM INTO ?`, path); err != nil {
+		return err
+	}
+
+	return s.SetSetting(settingLastBackup, time.Now().UTC().Format(timeLayout))
+}
+
+// LastBackup retrieves the time of the last successful backup, ok is false if there was none
+func (s *Storage) LastBackup() (at time.Time, ok bool, err error) {
+	value, ok, err := getSetting(s.db, settingLastBackup)
+	if err != nil || !ok {
+		return time.Time{}, false, err
+	}
+
+	at, err = time.Parse(timeLayout, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return at, true, nil
+}
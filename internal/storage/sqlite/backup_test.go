@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackup(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	if _, ok, err := storage.LastBackup(); ok || err != nil {
+		t.Fatalf("Expected no backup yet, got %v (%v)", ok, err)
+	}
+
+	id, _ := storage.NewNote("Backed up", "Content")
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := storage.Backup(backupPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	at, ok, err := storage.LastBackup()
+	if err != nil || !ok || time.Since(at) > time.Minute {
+		t.Errorf("Expected a recent backup time, got %s, %v (%v)", at, ok, err)
+	}
+
+	// the copy is a working database
+	backup, err := New(backupPath)
+	if err != nil {
+		t.Fatalf("Expected no error opening backup, got %v", err)
+	}
+	defer backup.Close()
+	if note, err := backup.GetNoteByID(id); err != nil || note.Title != "Backed up" {
+		t.Errorf("Expected the note in the backup, got %+v (%v)", note, err)
+	}
+
+	// an existing backup is never overwritten
+	if err = storage.Backup(backupPath); !errors.Is(err, os.ErrExist) {
+		t.Errorf("Expected os.ErrExist, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// NewNotes creates notes with titles and contents of the given ones in a single transaction and returns their IDs.
+// If any note is invalid, none of them are created
+func (s *Storage) NewNotes(notes []entities.Note) ([]int, error) {
+	// notes are copied, as validation may change their content
+	notes = append([]entities.Note(nil), notes...)
+	for i, note := range notes {
+		if err := validateSQLParam(normalizeTitle(note.Title)); err != nil {
+			return nil, err
+		}
+
+		content, err := s.validateContent(note.Content)
+		if err != nil {
+			return nil, err
+		}
+		notes[i].Content = content
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// prepare statement once for all notes
+	stmt, err := tx.Prepare(`INSERT INTO notes (title, content, uncompressed_length, content_hash, created_at, last_edited_at)
+		VALUES (?, ?, ?, ?, ` + currentTime + `, ` + currentTime + `)`)
+	if err != nil {
+		return nil, err
+	}
+	// ensure statement are closed when done processing
+	defer stmt.Close()
+
+	ids := make([]int, 0, len(notes))
+	for _, note := range notes {
+		// compress content if enabled
+		storedContent, uncompressedLength, err := s.encodeContent(note.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := stmt.Exec(normalizeTitle(note.Title), storedContent, uncompressedLength, contentHash(note.Content))
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, int(id))
+	}
+
+	return ids, tx.Commit()
+}
+
+// SetNotesContent updates contents of notes keyed by their IDs in a single transaction and returns the result
+// of every update keyed by note ID, nil for applied ones. Without continueOnError, an update failing for a note,
+// e.g. a missing note, too long content or content with control characters, rolls back all updates and is returned as the error.
+// With continueOnError, such updates are only reported and the rest is applied. Database errors always roll back
+func (s *Storage) SetNotesContent(contents map[int]string, continueOnError bool) (map[int]error, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// update in ID order, so results don't depend on map iteration
+	ids := make([]int, 0, len(contents))
+	for id := range contents {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	results := make(map[int]error, len(contents))
+	for _, id := range ids {
+		err = s.setNoteContent(tx, id, contents[id])
+		if err != nil && !isNoteError(err) {
+			return results, err
+		}
+
+		results[id] = err
+		if err != nil && !continueOnError {
+			return results, fmt.Errorf("note %d: %w", id, err)
+		}
+	}
+
+	return results, tx.Commit()
+}
+
+// isNoteError reports whether the error is caused by the updated note rather than by the database
+func isNoteError(err error) bool {
+	return errors.Is(err, sql.ErrNoRows) || errors.Is(err, storage.ErrContentTooLong) ||
+		errors.Is(err, storage.ErrInvalidContent) || errors.Is(err, invalidNum) || errors.Is(err, invalidParamLength)
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"go-notes/internal/entities"
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestNewNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	ids, err := storage.NewNotes([]entities.Note{
+		{Title: "First", Content: "One."},
+		{Title: "Second", Content: "Two."},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected IDs [1 2], got %v", ids)
+	}
+
+	// a single invalid note rejects the whole batch
+	_, err = storage.NewNotes([]entities.Note{
+		{Title: "Third", Content: "Three."},
+		{Title: "Empty"},
+	})
+	if err == nil {
+		t.Errorf("Expected error for empty content, got nil")
+	}
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 2 {
+		t.Errorf("Expected 2 notes, got %d", len(notes))
+	}
+}
+
+func TestSetNotesContent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNotes([]entities.Note{
+		{Title: "First", Content: "One."},
+		{Title: "Second", Content: "Two."},
+		{Title: "Third", Content: "Three."},
+	})
+
+	results, err := storage.SetNotesContent(map[int]string{1: "Uno.", 3: "Tres."}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 2 || results[1] != nil || results[3] != nil {
+		t.Errorf("Expected both updates to succeed, got %v", results)
+	}
+
+	for id, expected := range map[int]string{1: "Uno.", 2: "Two.", 3: "Tres."} {
+		note, _ := storage.GetNoteByID(id)
+		if note.Content != expected {
+			t.Errorf("Expected note %d to have content %q, got %q", id, expected, note.Content)
+		}
+	}
+
+	// a missing note rolls back the whole patch
+	results, err = storage.SetNotesContent(map[int]string{1: "Eins.", 9: "Missing."}, false)
+	if !errors.Is(err, sql.ErrNoRows) || !errors.Is(results[9], sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for the missing note, got %v and %v", err, results)
+	}
+	if note, _ := storage.GetNoteByID(1); note.Content != "Uno." {
+		t.Errorf("Expected the patch to be rolled back, got %q", note.Content)
+	}
+
+	// unless failures are allowed
+	results, err = storage.SetNotesContent(map[int]string{1: "Eins.", 9: "Missing."}, true)
+	if err != nil || results[1] != nil || !errors.Is(results[9], sql.ErrNoRows) {
+		t.Errorf("Expected only the missing note to fail, got %v and %v", err, results)
+	}
+	if note, _ := storage.GetNoteByID(1); note.Content != "Eins." {
+		t.Errorf("Expected the rest of the patch to be applied, got %q", note.Content)
+	}
+}
+
+func TestSetNotesContentInvalidContent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNotes([]entities.Note{
+		{Title: "First", Content: "One."},
+		{Title: "Second", Content: "Two."},
+	})
+
+	// content with a NUL byte fails only its own note
+	results, err := storage.SetNotesContent(map[int]string{1: "Uno.", 2: "Dos\x00."}, true)
+	if err != nil || results[1] != nil || !errors.Is(results[2], notesstorage.ErrInvalidContent) {
+		t.Errorf("Expected only the invalid content to fail, got %v and %v", err, results)
+	}
+
+	for id, expected := range map[int]string{1: "Uno.", 2: "Two."} {
+		note, _ := storage.GetNoteByID(id)
+		if note.Content != expected {
+			t.Errorf("Expected note %d to have content %q, got %q", id, expected, note.Content)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// noteSizes selects IDs of notes with their content length in bytes, compressed content has its length stored
+// and NULL content counts as empty. LENGTH() counts characters of text, so content is cast to a blob
+const noteSizes = `SELECT note_id, COALESCE(uncompressed_length, LENGTH(CAST(content AS BLOB)), 0) AS size FROM `
+
+// GetTagBreakdown retrieves every tag with the number of notes having it and their total content size in bytes,
+// from the tag taking the most space
+func (s *Storage) GetTagBreakdown() ([]entities.LabelSize, error) {
+	return s.labelSizes(`SELECT tag, COUNT(*), SUM(size) FROM note_tags
+		JOIN (` + noteSizes + s.notes() + `) USING (note_id)
+		GROUP BY tag ORDER BY SUM(size) DESC, tag`)
+}
+
+// GetMetaBreakdown retrieves every value of the metadata key with the number of notes having it
+// and their total content size in bytes, from the value taking the most space
+func (s *Storage) GetMetaBreakdown(key string) ([]entities.LabelSize, error) {
+	key = normalizeMetaKey(key)
+	err := validateSQLParam(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.labelSizes(`SELECT value, COUNT(*), SUM(size) FROM note_meta
+		JOIN (`+noteSizes+s.notes()+`) USING (note_id)
+		WHERE key = ? GROUP BY value ORDER BY SUM(size) DESC, value`, key)
+}
+
+// labelSizes runs a query selecting labels with their note counts and sizes
+func (s *Storage) labelSizes(query string, args ...interface{}) ([]entities.LabelSize, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var labels []entities.LabelSize
+	for rows.Next() {
+		var label entities.LabelSize
+		if err = rows.Scan(&label.Label, &label.Notes, &label.Bytes); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
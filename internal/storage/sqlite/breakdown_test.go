@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-notes/internal/entities"
+)
+
+func TestGetTagBreakdown(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	short, _ := storage.NewNote("Short", "abc")
+	medium, _ := storage.NewNote("Medium", "twelve chars")
+	// long content is stored compressed, its original length is counted
+	long, _ := storage.NewNote("Long", strings.Repeat("a", 2000))
+	trashed, _ := storage.NewNote("Trashed", "trashed notes are not counted")
+	_, _ = storage.NewNote("Untagged", "untagged notes are not counted")
+
+	_ = storage.AddTag(short, "work")
+	_ = storage.AddTag(medium, "work")
+	_ = storage.AddTag(medium, "home")
+	_ = storage.AddTag(long, "archive")
+	_ = storage.AddTag(trashed, "work")
+	_ = storage.TrashNote(trashed)
+
+	breakdown, err := storage.GetTagBreakdown()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []entities.LabelSize{
+		{Label: "archive", Notes: 1, Bytes: 2000},
+		{Label: "work", Notes: 2, Bytes: 15},
+		{Label: "home", Notes: 1, Bytes: 12},
+	}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected breakdown %v, got %v", expected, breakdown)
+	}
+}
+
+func TestGetMetaBreakdown(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("First", "abc")
+	second, _ := storage.NewNote("Second", "abcdef")
+	third, _ := storage.NewNote("Third", "abcdefghi")
+
+	_ = storage.SetMeta(first, "category", "ideas")
+	_ = storage.SetMeta(second, "category", "ideas")
+	_ = storage.SetMeta(third, "category", "recipes")
+	_ = storage.SetMeta(third, "source", "book")
+
+	breakdown, err := storage.GetMetaBreakdown("Category")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []entities.LabelSize{
+		{Label: "ideas", Notes: 2, Bytes: 9},
+		{Label: "recipes", Notes: 1, Bytes: 9},
+	}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected breakdown %v, got %v", expected, breakdown)
+	}
+}
+
+func TestGetTagBreakdownCountsBytes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	// both content stored as is and compressed content is counted in bytes, not characters
+	short, _ := storage.NewNote("Short", "café")
+	long, _ := storage.NewNote("Long", strings.Repeat("é", 1000))
+	_ = storage.AddTag(short, "short")
+	_ = storage.AddTag(long, "long")
+
+	expected := []entities.LabelSize{
+		{Label: "long", Notes: 1, Bytes: 2000},
+		{Label: "short", Notes: 1, Bytes: 5},
+	}
+	breakdown, err := storage.GetTagBreakdown()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected breakdown %v, got %v", expected, breakdown)
+	}
+
+	// a length counted in characters by earlier versions is corrected by rebuilding
+	_, _ = storage.db.Exec(`UPDATE notes SET uncompressed_length = 1000 WHERE note_id = ?`, long)
+	if _, err = storage.Rebuild(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if breakdown, _ = storage.GetTagBreakdown(); !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected breakdown %v after rebuilding, got %v", expected, breakdown)
+	}
+}
@@ -0,0 +1,11 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// CaptureNote creates a note, attaches tags to it and optionally pins it in a single transaction.
+// If tagging or pinning fails (e.g. the pin limit is reached), the note is not created
+func (s *Storage) CaptureNote(noteTitle, content string, tags []string, pin bool) (int, error) {
+	return s.createNote(entities.Note{Title: noteTitle, Content: content}, tags, pin)
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestCaptureNote(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, err := storage.CaptureNote("Standup", "Discuss the release.", []string{"Work", "meetings"}, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(id)
+	if note.Title != "Standup" || note.PinnedAt == nil {
+		t.Errorf("Expected a pinned note, got %+v", note)
+	}
+
+	tags, _ := storage.GetNoteTags(id)
+	if len(tags) != 2 || tags[0] != "meetings" || tags[1] != "work" {
+		t.Errorf("Expected tags [meetings work], got %v", tags)
+	}
+}
+
+func TestCaptureNoteAtomic(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithPinLimit(1))
+	defer storage.Close()
+
+	_, _ = storage.CaptureNote("Pinned", "Takes the only pin slot.", nil, true)
+
+	// pinning fails, so neither the note nor its tags are stored
+	_, err := storage.CaptureNote("Second", "Can't be pinned.", []string{"work"}, true)
+	if !errors.Is(err, notesstorage.ErrPinLimitReached) {
+		t.Errorf("Expected ErrPinLimitReached, got %v", err)
+	}
+
+	// an invalid tag fails as well
+	_, err = storage.CaptureNote("Third", "Has an empty tag.", []string{"  "}, false)
+	if err == nil {
+		t.Errorf("Expected error for an empty tag, got nil")
+	}
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 1 {
+		t.Errorf("Expected only the first note, got %+v", notes)
+	}
+
+	tags, _ := storage.GetAllTags()
+	if len(tags) != 0 {
+		t.Errorf("Expected no tags, got %v", tags)
+	}
+}
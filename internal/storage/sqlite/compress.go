@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+// compressionThreshold is the content length in bytes below which content is stored uncompressed,
+// as gzip overhead outweighs savings for short texts
+const compressionThreshold = 1024
+
+// WithCompression enables or disables gzip compression of newly written content,
+// overriding the setting stored by SetCompression
+func WithCompression(enabled bool) Option {
+	return func(s *Storage) {
+		s.compress = enabled
+	}
+}
+
+// SetCompression enables or disables compression of newly written content and stores the choice in the database,
+// so it applies to every following New. Already stored contents are left as is
+func (s *Storage) SetCompression(enabled bool) error {
+	value := "false"
+	if enabled {
+		value = "true"
+	}
+
+	err := s.SetSetting(settingCompress, value)
+	if err != nil {
+		return err
+	}
+
+	s.compress = enabled
+
+	return nil
+}
+
+// encodeContent prepares content for storing. It returns the value for the content column
+// and the value for the uncompressed_length column, which is NULL for content stored as is
+func (s *Storage) encodeContent(content string) (interface{}, interface{}, error) {
+	// short content is not worth compressing
+	if !s.compress || len(content) < compressionThreshold {
+		return content, nil, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		return nil, nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	// incompressible content is kept as is
+	if buf.Len() >= len(content) {
+		return content, nil, nil
+	}
+
+	// length is counted in bytes, the same way LENGTH() does for content cast to a blob
+	return buf.Bytes(), len(content), nil
+}
+
+// decodeContent decompresses content stored by encodeContent
+func decodeContent(stored string, compressed bool) (string, error) {
+	if !compressed {
+		return stored, nil
+	}
+
+	zr, err := gzip.NewReader(strings.NewReader(stored))
+	if err != nil {
+		return "", err
+	}
+	// ensure reader is closed when done reading
+	defer zr.Close()
+
+	content, err := io.ReadAll(zr)
+
+	return string(content), err
+}
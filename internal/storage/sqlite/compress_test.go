@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	// repetitive log-like content compresses well
+	content := strings.Repeat("2024-01-01 INFO request handled in 12ms\n", 100) + "needle"
+	noteID, err := storage.NewNote("Logs", content)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var storedLength int
+	var uncompressedLength *int
+	_ = storage.db.QueryRow(`SELECT LENGTH(CAST(content AS BLOB)), uncompressed_length FROM notes WHERE note_id = ?`, noteID).
+		Scan(&storedLength, &uncompressedLength)
+	if uncompressedLength == nil || *uncompressedLength != len(content) || storedLength >= len(content) {
+		t.Fatalf("Expected content to be stored compressed, got %d stored bytes", storedLength)
+	}
+
+	note, err := storage.GetNoteByID(noteID)
+	if err != nil || note.Content != content {
+		t.Errorf("Expected decompressed content from GetNoteByID, got error %v", err)
+	}
+
+	notes, err := storage.GetAllNotes()
+	if err != nil || len(notes) != 1 || notes[0].Content != content {
+		t.Errorf("Expected decompressed content from GetAllNotes, got error %v", err)
+	}
+
+	// search looks into compressed content too
+	notes, err = storage.SearchNotesByKeyword("NEEDLE")
+	if err != nil || len(notes) != 1 || notes[0].Content != content {
+		t.Errorf("Expected compressed note to be found by content, got %d notes and error %v", len(notes), err)
+	}
+
+	notes, _ = storage.SearchNotesByKeyword("missing")
+	if len(notes) != 0 {
+		t.Errorf("Expected no notes for a missing keyword, got %d", len(notes))
+	}
+
+	// updates are compressed as well
+	updated := strings.Repeat("updated line\n", 200)
+	_ = storage.SetNoteContent(noteID, updated)
+	note, _ = storage.GetNoteByID(noteID)
+	if note.Content != updated {
+		t.Error("Expected updated content to round-trip")
+	}
+}
+
+func TestCompressionSmallContentBypass(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Short", "Too short to compress.")
+
+	var content string
+	var uncompressedLength *int
+	_ = storage.db.QueryRow(`SELECT content, uncompressed_length FROM notes WHERE note_id = ?`, noteID).
+		Scan(&content, &uncompressedLength)
+	if uncompressedLength != nil || content != "Too short to compress." {
+		t.Errorf("Expected short content to be stored as is, got %q", content)
+	}
+}
+
+func TestSetCompressionPersists(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	if err := storage.SetCompression(true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = storage.Close()
+
+	storage, _ = New(dbPath)
+	defer storage.Close()
+
+	if !storage.compress {
+		t.Error("Expected compression setting to be restored by New")
+	}
+}
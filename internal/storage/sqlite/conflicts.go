@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"strings"
+
+	"go-notes/internal/entities"
+)
+
+// FindTitleConflicts retrieves groups of notes having the same title, ignoring case and surrounding spaces,
+// but different content. Contents are compared by their hashes like FindDuplicatesByHash does,
+// a group includes every note with the title. Groups are ordered by their first note, notes by ID
+func (s *Storage) FindTitleConflicts() ([][]entities.Note, error) {
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + ` ORDER BY note_id`)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// groups keep the order of their first note
+	var (
+		keys   []string
+		groups = make(map[string][]entities.Note)
+	)
+	for _, note := range notes {
+		key := titleKey(note.Title)
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], note)
+	}
+
+	var conflicts [][]entities.Note
+	for _, key := range keys {
+		hashes := make(map[string]bool)
+		for _, note := range groups[key] {
+			hashes[contentHash(note.Content)] = true
+		}
+
+		if len(hashes) > 1 {
+			conflicts = append(conflicts, groups[key])
+		}
+	}
+
+	return conflicts, nil
+}
+
+// titleKey normalizes a title for comparison, so titles differing only in case, surrounding spaces
+// or Unicode normalization form are the same
+func titleKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(normalizeTitle(title)))
+}
@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFindTitleConflicts(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	// identical copies are duplicates, not conflicts
+	_, _ = storage.NewNote("Recipe", "Flour and water")
+	_, _ = storage.NewNote("Recipe", "Flour and water")
+	first, _ := storage.NewNote("Plans", "Go hiking")
+	_, _ = storage.NewNote("Unique", "Nothing to compare")
+	second, _ := storage.NewNote(" plans ", "Stay home")
+
+	conflicts, err := storage.FindTitleConflicts()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(conflicts) != 1 || len(conflicts[0]) != 2 || conflicts[0][0].ID != first || conflicts[0][1].ID != second {
+		t.Errorf("Expected notes %d and %d to conflict, got %+v", first, second, conflicts)
+	}
+}
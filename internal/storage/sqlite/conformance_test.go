@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-notes/internal/storage/storagetest"
+)
+
+func TestStorageConformance(t *testing.T) {
+	storagetest.RunStorageTests(t, func() storagetest.Storage {
+		storage, err := New(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		t.Cleanup(func() { _ = storage.Close() })
+
+		return storage
+	})
+}
+
+func TestPrefixedStorageConformance(t *testing.T) {
+	storagetest.RunStorageTests(t, func() storagetest.Storage {
+		// notes of an unprefixed storage in the same file must not leak into the prefixed one
+		path := filepath.Join(t.TempDir(), "test.db")
+		plain, err := New(path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, _ = plain.NewNote("Plain", "Not prefixed")
+		_ = plain.Close()
+
+		storage, err := New(path, WithTablePrefix("work_"))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		t.Cleanup(func() { _ = storage.Close() })
+
+		return storage
+	})
+}
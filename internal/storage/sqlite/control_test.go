@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestContentControlChars(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	if _, err := storage.NewNote("Binary", "before\x00after"); !errors.Is(err, notesstorage.ErrInvalidContent) {
+		t.Errorf("Expected ErrInvalidContent for a NUL byte, got %v", err)
+	}
+
+	content := "column\tcolumn\r\nnext line\n"
+	noteID, err := storage.NewNote("Text", content)
+	if err != nil {
+		t.Fatalf("Expected tabs and line breaks to be accepted, got %v", err)
+	}
+
+	if err = storage.SetNoteContent(noteID, "escape \x1b[31mred"); !errors.Is(err, notesstorage.ErrInvalidContent) {
+		t.Errorf("Expected ErrInvalidContent on update, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.Content != content {
+		t.Errorf("Expected content %q to be kept, got %q", content, note.Content)
+	}
+}
+
+func TestContentControlCharsStripped(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithStripControlChars(true))
+	defer storage.Close()
+
+	noteID, err := storage.NewNote("Binary", "before\x00\tafter\x7f\n")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.Content != "before\tafter\n" {
+		t.Errorf("Expected control characters to be stripped, got %q", note.Content)
+	}
+
+	if _, err = storage.NewNote("Empty", "\x00\x01"); err == nil {
+		t.Error("Expected error for content left empty by stripping")
+	}
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestNewGarbageFile(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	_ = os.WriteFile(dbPath, []byte("this is definitely not an sqlite database, just some garbage bytes"), 0o644)
+
+	_, err := New(dbPath)
+	if !errors.Is(err, notesstorage.ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, got %v", err)
+	}
+}
+
+func TestNewTruncatedFile(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// fill several pages, so truncating the file cuts tables in the middle
+	storage, _ := New(dbPath)
+	for i := 0; i < 200; i++ {
+		_, _ = storage.NewNote("Note", "Content long enough to take up some space in the database file.")
+	}
+	_ = storage.Close()
+
+	info, _ := os.Stat(dbPath)
+	_ = os.Truncate(dbPath, info.Size()/2)
+
+	_, err := New(dbPath)
+	if !errors.Is(err, notesstorage.ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, got %v", err)
+	}
+}
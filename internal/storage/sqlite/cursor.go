@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// NoteCursor pages through all notes in ID order.
+// It continues after the last seen ID instead of using offsets, so notes inserted meanwhile neither shift nor repeat pages
+type NoteCursor struct {
+	s        *Storage
+	pageSize int
+	lastID   int
+	done     bool
+}
+
+// NewNoteCursor creates a cursor returning up to pageSize notes per page
+func (s *Storage) NewNoteCursor(pageSize int) *NoteCursor {
+	return &NoteCursor{s: s, pageSize: pageSize}
+}
+
+// Next retrieves the next page of notes, ok is false when there are no more notes
+func (c *NoteCursor) Next() (notes []entities.Note, ok bool, err error) {
+	err = validateSQLParam(c.pageSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// a short page means the end was reached, so there is no need to query again
+	if c.done {
+		return nil, false, nil
+	}
+
+	rows, err := c.s.db.Query(`SELECT `+noteColumns+` FROM `+c.s.notes()+` WHERE note_id > ? ORDER BY note_id LIMIT ?`,
+		c.lastID, c.pageSize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	notes, err = scanNotes(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(notes) < c.pageSize {
+		c.done = true
+	}
+	if len(notes) == 0 {
+		return nil, false, nil
+	}
+
+	c.lastID = notes[len(notes)-1].ID
+
+	return notes, true, nil
+}
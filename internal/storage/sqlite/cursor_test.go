@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNoteCursor(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	for i := 0; i < 7; i++ {
+		_, _ = storage.NewNote("Note", "Content.")
+	}
+
+	cursor := storage.NewNoteCursor(3)
+
+	var (
+		pages int
+		ids   []int
+	)
+	for {
+		notes, ok, err := cursor.Next()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !ok {
+			break
+		}
+
+		pages++
+		for _, note := range notes {
+			ids = append(ids, note.ID)
+		}
+
+		// a note inserted while paging is picked up by a later page, not repeated
+		if pages == 1 {
+			_, _ = storage.NewNote("Late", "Inserted while paging.")
+		}
+	}
+
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+
+	if len(ids) != 8 {
+		t.Fatalf("Expected 8 notes, got %v", ids)
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Errorf("Expected notes in ID order without gaps or repeats, got %v", ids)
+			break
+		}
+	}
+
+	// an exhausted cursor keeps reporting the end
+	if _, ok, _ := cursor.Next(); ok {
+		t.Errorf("Expected no more pages")
+	}
+}
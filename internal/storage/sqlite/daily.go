@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"time"
+)
+
+// dayLayout formats days as keys of daily counts
+const dayLayout = time.DateOnly
+
+// GetDailyCounts returns numbers of notes created on every local day from the day of from to the day of to,
+// both included, keyed by the day in YYYY-MM-DD format. Days without notes are present with a zero count
+func (s *Storage) GetDailyCounts(from, to time.Time) (map[string]int, error) {
+	from, to = from.Local(), to.Local()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, time.Local)
+
+	counts := make(map[string]int)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		counts[day.Format(dayLayout)] = 0
+	}
+
+	// creation times are stored in UTC in CURRENT_TIMESTAMP format, so the range compares as strings
+	rows, err := s.db.Query(`SELECT date(created_at, 'localtime'), COUNT(*) FROM `+s.notes()+`
+		WHERE created_at >= ? AND created_at < ? GROUP BY 1`, dbTime(start), dbTime(end))
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			day   string
+			count int
+		)
+		if err = rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+
+		counts[day] = count
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestGetDailyCounts(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	day := func(d, hour int) time.Time {
+		return time.Date(2024, 3, d, hour, 30, 0, 0, time.Local)
+	}
+	for _, created := range []time.Time{day(4, 9), day(4, 23), day(4, 0), day(6, 12), day(9, 8), day(1, 10)} {
+		_, _ = storage.CreateNote(entities.Note{Title: "Note", Content: "Content", CreatedAt: created}, nil)
+	}
+
+	counts, err := storage.GetDailyCounts(day(3, 15), day(7, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// days outside the range are left out, days without notes have zero counts
+	expected := map[string]int{"2024-03-03": 0, "2024-03-04": 3, "2024-03-05": 0, "2024-03-06": 1, "2024-03-07": 0}
+	if len(counts) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, counts)
+	}
+	for day, count := range expected {
+		if counts[day] != count {
+			t.Errorf("Expected %d notes on %s, got %d", count, day, counts[day])
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// SetDueDate sets the time the note with the specified ID is due at, zero time removes the due date
+func (s *Storage) SetDueDate(noteID int, due time.Time) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`UPDATE notes SET due_at = ? WHERE note_id = ?`, dbTime(due), noteID)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - return an error
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDueNotes retrieves notes due before the specified time, from the most overdue
+func (s *Storage) GetDueNotes(before time.Time) ([]entities.Note, error) {
+	// due times are stored in UTC in CURRENT_TIMESTAMP format, so they compare as strings
+	rows, err := s.db.Query(`SELECT `+noteColumns+` FROM `+s.notes()+` WHERE due_at < ? ORDER BY due_at, note_id`, dbTime(before))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
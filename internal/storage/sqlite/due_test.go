@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetDueNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	overdue, _ := storage.NewNote("Taxes", "File the return.")
+	today, _ := storage.NewNote("Call", "Call the plumber.")
+	later, _ := storage.NewNote("Trip", "Pack bags.")
+	cleared, _ := storage.NewNote("Old", "No longer due.")
+	_, _ = storage.NewNote("Idea", "No due date.")
+
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	_ = storage.SetDueDate(overdue, now.AddDate(0, 0, -3))
+	_ = storage.SetDueDate(today, now.Add(2*time.Hour))
+	_ = storage.SetDueDate(later, now.AddDate(0, 0, 5))
+	_ = storage.SetDueDate(cleared, now.AddDate(0, 0, -1))
+	_ = storage.SetDueDate(cleared, time.Time{})
+
+	notes, err := storage.GetDueNotes(time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notes) != 2 || notes[0].ID != overdue || notes[1].ID != today {
+		t.Fatalf("Expected overdue note %d and note %d due today, got %+v", overdue, today, notes)
+	}
+
+	if notes[1].DueAt == nil || !notes[1].DueAt.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("Expected due time %s, got %v", now.Add(2*time.Hour), notes[1].DueAt)
+	}
+}
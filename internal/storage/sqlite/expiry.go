@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NewNoteWithExpiry creates a new note which expires at the specified time and returns its ID.
+// The note is created with its expiry at once, so it's never left without it
+func (s *Storage) NewNoteWithExpiry(noteTitle, content string, expires time.Time) (int, error) {
+	noteTitle = normalizeTitle(noteTitle)
+	err := validateSQLParam(noteTitle)
+	if err != nil {
+		return 0, err
+	}
+	content, err = s.validateContent(content)
+	if err != nil {
+		return 0, err
+	}
+	storedContent, uncompressedLength, err := s.encodeContent(content)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := s.db.Exec(`INSERT INTO notes (title, content, uncompressed_length, content_hash, created_at, last_edited_at, expires_at)
+		VALUES (?, ?, ?, ?, `+currentTime+`, `+currentTime+`, ?)`, noteTitle, storedContent, uncompressedLength, contentHash(content), dbTime(expires))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+
+	return int(id), err
+}
+
+// SetIncludeExpired makes read methods return notes which have expired too, they are hidden by default
+func (s *Storage) SetIncludeExpired(include bool) {
+	s.includeExpired = include
+}
+
+// SetExpiry sets the time the note with the specified ID expires at, zero time makes the note never expire
+func (s *Storage) SetExpiry(noteID int, expires time.Time) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`UPDATE notes SET expires_at = ? WHERE note_id = ?`, dbTime(expires), noteID)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - return an error
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteExpiredNotes deletes notes which expired by the specified time together with their tags and metadata.
+// It returns number of deleted notes
+func (s *Storage) DeleteExpiredNotes(now time.Time) (int, error) {
+	// expiry times are stored in UTC in CURRENT_TIMESTAMP format, so they compare as strings
+	res, err := s.db.Exec(`DELETE FROM notes WHERE expires_at <= ?`, dbTime(now))
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+
+	return int(rowsAffected), err
+}
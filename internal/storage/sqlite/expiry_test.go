@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetExpiry(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Code", "123456")
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	if err := storage.SetExpiry(id, expires); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(id)
+	if note.ExpiresAt == nil || !note.ExpiresAt.Equal(expires) {
+		t.Errorf("Expected note to expire at %v, got %v", expires, note.ExpiresAt)
+	}
+
+	// zero time removes the expiry
+	_ = storage.SetExpiry(id, time.Time{})
+	note, _ = storage.GetNoteByID(id)
+	if note.ExpiresAt != nil {
+		t.Errorf("Expected note to never expire, got %v", note.ExpiresAt)
+	}
+
+	if err := storage.SetExpiry(id+1, expires); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing note, got %v", err)
+	}
+}
+
+func TestDeleteExpiredNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	now := time.Now()
+	expired, _ := storage.NewNote("Expired", "Old code")
+	valid, _ := storage.NewNote("Valid", "New code")
+	permanent, _ := storage.NewNote("Permanent", "Never expires")
+	_ = storage.SetExpiry(expired, now.Add(-time.Minute))
+	_ = storage.SetExpiry(valid, now.Add(time.Hour))
+	_ = storage.AddTag(expired, "codes")
+
+	deleted, err := storage.DeleteExpiredNotes(now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted note, got %d", deleted)
+	}
+
+	if _, err = storage.GetNoteByID(expired); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected expired note to be deleted, got %v", err)
+	}
+	for _, id := range []int{valid, permanent} {
+		if _, err = storage.GetNoteByID(id); err != nil {
+			t.Errorf("Expected note %d to be kept, got %v", id, err)
+		}
+	}
+
+	tags, _ := storage.GetAllTags()
+	if len(tags) != 0 {
+		t.Errorf("Expected tags of deleted note to be removed, got %v", tags)
+	}
+}
+
+func TestNewNoteWithExpiry(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	id, err := storage.NewNoteWithExpiry("Code", "123456", expires)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(id)
+	if note.Title != "Code" || note.Content != "123456" || note.ExpiresAt == nil || !note.ExpiresAt.Equal(expires) {
+		t.Errorf("Expected note expiring at %v, got %+v", expires, note)
+	}
+
+	if _, err = storage.NewNoteWithExpiry("", "123456", expires); err == nil {
+		t.Errorf("Expected error for an empty title, got nil")
+	}
+}
+
+func TestExpiredNotesHiddenFromReads(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	expired, _ := storage.NewNoteWithExpiry("Expired", "Old code", time.Now().Add(-time.Minute))
+	_, _ = storage.NewNote("Valid", "New code")
+	_ = storage.AddTag(expired, "codes")
+
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 1 || notes[0].Title != "Valid" {
+		t.Errorf("Expected only the unexpired note, got %v", notes)
+	}
+	if _, err := storage.GetNoteByID(expired); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for an expired note, got %v", err)
+	}
+	if tags, _ := storage.GetAllTags(); len(tags) != 0 {
+		t.Errorf("Expected no tags of expired notes, got %v", tags)
+	}
+
+	storage.SetIncludeExpired(true)
+	notes, _ = storage.GetAllNotes()
+	if len(notes) != 2 {
+		t.Errorf("Expected 2 notes including the expired one, got %d", len(notes))
+	}
+	if tags, _ := storage.GetAllTags(); len(tags) != 1 {
+		t.Errorf("Expected tags of the expired note, got %v", tags)
+	}
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go-notes/internal/entities"
+)
+
+// queryExecer is implemented by both *prefixedDB and *prefixedTx
+type queryExecer interface {
+	execer
+	querier
+}
+
+// contentHash returns hex encoded SHA-256 of uncompressed content, stored in content_hash
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// backfillContentHashes fills content_hash of notes stored before it was added.
+// The edit trigger is dropped meanwhile and restored with triggerStatement, so last edit times are kept
+func backfillContentHashes(db *prefixedDB, triggerStatement string) error {
+	var missing int
+	err := db.QueryRow(`SELECT COUNT(*) FROM notes WHERE content_hash IS NULL`).Scan(&missing)
+	if err != nil || missing == 0 {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	if _, err = rehashNotes(tx, `WHERE content_hash IS NULL`, triggerStatement); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rehashNotes sets content_hash of notes selected by the where clause from their content
+// and returns the number of notes whose hash was missing or wrong. Stored lengths of compressed content
+// are corrected as well, they were counted in characters before. The edit trigger is dropped meanwhile
+// and restored with triggerStatement, so last edit times are kept
+func rehashNotes(tx *prefixedTx, where, triggerStatement string) (int, error) {
+	if _, err := tx.Exec(`DROP TRIGGER IF EXISTS update_last_edited_at`); err != nil {
+		return 0, err
+	}
+
+	// content is hashed uncompressed, so notes are read with scanNotes decoding it
+	rows, err := tx.Query(`SELECT ` + noteColumns + ` FROM notes ` + where)
+	if err != nil {
+		return 0, err
+	}
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, note := range notes {
+		hash := contentHash(note.Content)
+		res, err := tx.Exec(`UPDATE notes SET content_hash = ? WHERE note_id = ? AND content_hash IS NOT ?`, hash, note.ID, hash)
+		if err != nil {
+			return 0, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		changed += int(affected)
+
+		// content stored as is has no stored length, NULL never differs
+		_, err = tx.Exec(`UPDATE notes SET uncompressed_length = ?1 WHERE note_id = ?2 AND uncompressed_length != ?1`,
+			len(note.Content), note.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = tx.Exec(triggerStatement); err != nil {
+		return 0, err
+	}
+
+	return changed, nil
+}
+
+// FindDuplicatesByHash retrieves groups of notes with identical content, ordered by note ID within a group.
+// Notes with unique content are left out
+func (s *Storage) FindDuplicatesByHash() ([][]entities.Note, error) {
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + `
+		WHERE content_hash IN (SELECT content_hash FROM ` + s.notes() + ` GROUP BY content_hash HAVING COUNT(*) > 1)
+		ORDER BY content_hash, note_id`)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// notes are ordered by hash, so each group is a run of equal content
+	var groups [][]entities.Note
+	for i, note := range notes {
+		if i == 0 || note.Content != notes[i-1].Content {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], note)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestSetNoteContentUnchanged(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	lastEdited := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	id, _ := storage.CreateNote(entities.Note{Title: "Note", Content: "Same", CreatedAt: lastEdited}, nil)
+
+	// saving identical content succeeds without touching the note
+	if err := storage.SetNoteContent(id, "Same"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	note, _ := storage.GetNoteByID(id)
+	if !note.LastEditedAt.Equal(lastEdited) {
+		t.Errorf("Expected last edit time %s, got %s", lastEdited, note.LastEditedAt)
+	}
+
+	if err := storage.SetNoteContent(id, "Changed"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	note, _ = storage.GetNoteByID(id)
+	if note.Content != "Changed" || !note.LastEditedAt.After(lastEdited) {
+		t.Errorf("Expected changed content with a new edit time, got %+v", note)
+	}
+
+	// a missing note is still reported
+	if err := storage.SetNoteContent(id+1, "Changed"); err == nil {
+		t.Errorf("Expected an error for a missing note")
+	}
+}
+
+func TestFindDuplicatesByHash(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("First", "Shared")
+	_, _ = storage.NewNote("Unique", "Only once")
+
+	// hashes are of uncompressed content, so compressed copies match too
+	_ = storage.SetCompression(true)
+	second, _ := storage.NewNote("Second", "Shared")
+
+	groups, err := storage.FindDuplicatesByHash()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(groups) != 1 || len(groups[0]) != 2 || groups[0][0].ID != first || groups[0][1].ID != second {
+		t.Errorf("Expected notes %d and %d as duplicates, got %+v", first, second, groups)
+	}
+
+	// editing a copy removes it from duplicates
+	_ = storage.SetNoteContent(second, "Different")
+	if groups, _ = storage.FindDuplicatesByHash(); len(groups) != 0 {
+		t.Errorf("Expected no duplicates, got %+v", groups)
+	}
+}
+
+func TestBackfillContentHashes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	lastEdited := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	first, _ := storage.CreateNote(entities.Note{Title: "First", Content: "Shared", CreatedAt: lastEdited}, nil)
+	second, _ := storage.CreateNote(entities.Note{Title: "Second", Content: "Shared", CreatedAt: lastEdited}, nil)
+
+	// simulating notes stored before content hashes existed, with the edit trigger dropped to keep times
+	_, _ = storage.db.Exec(`DROP TRIGGER update_last_edited_at`)
+	_, _ = storage.db.Exec(`UPDATE notes SET content_hash = NULL`)
+	_ = storage.Close()
+
+	storage, _ = New(dbPath)
+	defer storage.Close()
+
+	groups, _ := storage.FindDuplicatesByHash()
+	if len(groups) != 1 || groups[0][0].ID != first || groups[0][1].ID != second {
+		t.Errorf("Expected backfilled hashes to match, got %+v", groups)
+	}
+	note, _ := storage.GetNoteByID(first)
+	if !note.LastEditedAt.Equal(lastEdited) {
+		t.Errorf("Expected last edit time %s, got %s", lastEdited, note.LastEditedAt)
+	}
+}
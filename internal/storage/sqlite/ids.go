@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go-notes/internal/links"
+	"go-notes/internal/storage"
+)
+
+// ChangeNoteID moves the note to a new ID together with its tags and metadata.
+// Links to the note in contents of notes, trashed ones included, are rewritten to the new ID.
+// If the new ID belongs to another note, storage.ErrIDTaken is returned
+func (s *Storage) ChangeNoteID(oldID, newID int) error {
+	err := validateSQLParam(oldID, newID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// check the new ID is free in the same transaction, so it can't be taken concurrently
+	var id int
+	err = tx.QueryRow(`SELECT note_id FROM notes WHERE note_id = ?`, newID).Scan(&id)
+	switch {
+	case err == nil:
+		return storage.ErrIDTaken
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	// child tables reference notes with ON UPDATE CASCADE, so their rows follow the note
+	res, err := tx.Exec(`UPDATE notes SET note_id = ? WHERE note_id = ?`, newID, oldID)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - there is no note with the old ID
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err = s.replaceLinks(tx, oldID, newID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replaceLinks rewrites links to the note with ID from into links to the note with ID to with db,
+// which may be a transaction
+func (s *Storage) replaceLinks(db queryExecer, from, to int) error {
+	rows, err := db.Query(`SELECT ` + noteColumns + ` FROM notes`)
+	if err != nil {
+		return err
+	}
+
+	// content can be compressed, so links are looked for in decoded notes
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		content := links.Replace(note.Content, from, to)
+		if content == note.Content {
+			continue
+		}
+
+		if err = s.setNoteContent(db, note.ID, content); err != nil {
+			return fmt.Errorf("rewriting links of note %d: %w", note.ID, err)
+		}
+	}
+
+	return nil
+}
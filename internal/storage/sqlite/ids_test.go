@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestChangeNoteID(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Moving", "This note changes its ID.")
+	_ = storage.AddTag(id, "work")
+	_ = storage.SetMeta(id, "status", "open")
+
+	err := storage.ChangeNoteID(id, 42)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, err := storage.GetNoteByID(42)
+	if err != nil || note.Title != "Moving" {
+		t.Errorf("Expected note under the new ID, got %+v, %v", note, err)
+	}
+
+	if _, err = storage.GetNoteByID(id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for the old ID, got %v", err)
+	}
+
+	// related rows follow the note
+	tags, _ := storage.GetNoteTags(42)
+	if len(tags) != 1 || tags[0] != "work" {
+		t.Errorf("Expected tag 'work' under the new ID, got %v", tags)
+	}
+	meta, _ := storage.GetMeta(42)
+	if meta["status"] != "open" {
+		t.Errorf("Expected metadata under the new ID, got %v", meta)
+	}
+
+	tags, _ = storage.GetNoteTags(id)
+	if len(tags) != 0 {
+		t.Errorf("Expected no tags under the old ID, got %v", tags)
+	}
+}
+
+func TestChangeNoteIDTaken(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("First", "Content.")
+	second, _ := storage.NewNote("Second", "Content.")
+
+	if err := storage.ChangeNoteID(first, second); !errors.Is(err, notesstorage.ErrIDTaken) {
+		t.Errorf("Expected ErrIDTaken, got %v", err)
+	}
+
+	if err := storage.ChangeNoteID(100, 200); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing note, got %v", err)
+	}
+}
+
+func TestChangeNoteIDRewritesLinks(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "notes.db"), WithCompression(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	target, _ := storage.NewNote("Target", "Links to itself [[1]].")
+	linking, _ := storage.NewNote("Linking", "See [[1]] and [[10]].")
+	// compressed content is rewritten too
+	compressed, _ := storage.NewNote("Compressed", "Long note linking [[1]]. "+strings.Repeat("padding ", 200))
+	trashed, _ := storage.NewNote("Trashed", "Also [[1]].")
+	_ = storage.TrashNote(trashed)
+
+	if err = storage.ChangeNoteID(target, 42); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[int]string{
+		42:         "Links to itself [[42]].",
+		linking:    "See [[42]] and [[10]].",
+		compressed: "Long note linking [[42]]. " + strings.Repeat("padding ", 200),
+	}
+	for id, content := range expected {
+		note, err := storage.GetNoteByID(id)
+		if err != nil || note.Content != content {
+			t.Errorf("Expected content %q of note %d, got %q, %v", content, id, note.Content, err)
+		}
+	}
+
+	note, _ := storage.WithTrashed().GetNoteByID(trashed)
+	if note.Content != "Also [[42]]." {
+		t.Errorf("Expected link of the trashed note rewritten, got %q", note.Content)
+	}
+}
@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// CreateNote inserts a note with a new ID together with its tags in a single transaction and returns the ID.
+// Creation time and revisions of the note are kept, missing timestamps fall back to the current time
+func (s *Storage) CreateNote(note entities.Note, tags []string) (int, error) {
+	return s.createNote(note, tags, false)
+}
+
+// createNote inserts a note with its tags and optionally pins it in a single transaction, so nothing is stored if any step fails
+func (s *Storage) createNote(note entities.Note, tags []string, pin bool) (int, error) {
+	note.Title = normalizeTitle(note.Title)
+	err := validateSQLParam(note.Title)
+	if err != nil {
+		return 0, err
+	}
+	note.Content, err = s.validateContent(note.Content)
+	if err != nil {
+		return 0, err
+	}
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if err = validateSQLParam(tag); err != nil {
+			return 0, err
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	// only times of creation and edit are kept, pins, due dates and expiry are set by their own methods
+	note.PinnedAt, note.DueAt, note.ExpiresAt = nil, nil, nil
+
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(note.Content)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	id, err := insertNote(tx, note, storedContent, uncompressedLength, normalized)
+	if err != nil {
+		return 0, err
+	}
+
+	if pin {
+		if err = s.checkPinLimit(tx, id); err != nil {
+			return 0, err
+		}
+		if err = setPinnedAt(tx, id, `CURRENT_TIMESTAMP`); err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit()
+}
+
+// insertNote inserts a validated note with content already encoded for storing and its normalized tags,
+// keeping its creation and edit times, pin, due and expiry times and revisions if they are set
+func insertNote(tx *prefixedTx, note entities.Note, storedContent, uncompressedLength interface{}, tags []string) (int, error) {
+	// a note without an edit time was last edited when it was created
+	res, err := tx.Exec(`INSERT INTO notes (title, content, uncompressed_length, content_hash, created_at, last_edited_at,
+			pinned_at, due_at, expires_at)
+		VALUES (?, ?, ?, ?, COALESCE(?, `+currentTime+`), COALESCE(?, ?, `+currentTime+`), ?, ?, ?)`,
+		note.Title, storedContent, uncompressedLength, contentHash(note.Content),
+		dbTime(note.CreatedAt), dbTime(note.LastEditedAt), dbTime(note.CreatedAt),
+		optionalDBTime(note.PinnedAt), optionalDBTime(note.DueAt), optionalDBTime(note.ExpiresAt))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	// attach tags, ignoring duplicates
+	for _, tag := range tags {
+		_, err = tx.Exec(`INSERT OR IGNORE INTO note_tags (note_id, tag) VALUES (?, ?)`, id, tag)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = insertRevisions(tx, int(id), note.Revisions); err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// optionalDBTime converts an optional time to its stored form, nil is stored as NULL
+func optionalDBTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return dbTime(*t)
+}
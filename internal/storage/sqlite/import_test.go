@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestCreateNote(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	createdAt := time.Date(2023, 5, 1, 8, 30, 0, 0, time.UTC)
+	id, err := storage.CreateNote(entities.Note{Title: "Imported", Content: "From a file.", CreatedAt: createdAt},
+		[]string{"Home", "home", "ideas"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, err := storage.GetNoteByID(id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !note.CreatedAt.Equal(createdAt) || !note.LastEditedAt.Equal(createdAt) {
+		t.Errorf("Expected timestamps %s, got %s and %s", createdAt, note.CreatedAt, note.LastEditedAt)
+	}
+
+	tags, _ := storage.GetNoteTags(id)
+	if len(tags) != 2 || tags[0] != "home" || tags[1] != "ideas" {
+		t.Errorf("Expected tags [home ideas], got %v", tags)
+	}
+
+	// invalid notes are rejected without leaving anything behind
+	_, err = storage.CreateNote(entities.Note{Title: "Empty"}, nil)
+	if err == nil {
+		t.Errorf("Expected error for empty content, got nil")
+	}
+}
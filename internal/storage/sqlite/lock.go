@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go-notes/internal/storage"
+)
+
+// lockPollInterval is the time between attempts to take a lock held by another process
+const lockPollInterval = 50 * time.Millisecond
+
+// errLocked is returned by tryLock when another process holds the lock
+var errLocked = errors.New("locked")
+
+// WithFileLock makes New take an exclusive advisory lock for the lifetime of the storage, so another process
+// opening the same database with a lock waits for it up to timeout and then fails with storage.ErrInUse,
+// a timeout of 0 fails at once. The lock is held on a "<database>.lock" file next to the database and released by Close.
+// Storages opened without the option, e.g. for reading only, neither take nor respect the lock
+func WithFileLock(timeout time.Duration) Option {
+	return func(s *Storage) {
+		s.fileLock = true
+		s.lockTimeout = timeout
+	}
+}
+
+// acquireLock takes the lock of the database at path, waiting up to timeout while another process holds it
+func acquireLock(path string, timeout time.Duration) (*os.File, error) {
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err = tryLock(file)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, errLocked) {
+			_ = file.Close()
+			return nil, fmt.Errorf("locking %s: %w", path, err)
+		}
+		if !time.Now().Before(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("%s: %w by another process", path, storage.ErrInUse)
+		}
+
+		time.Sleep(lockPollInterval)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package sqlite
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLock fails, as advisory locks are supported on unix systems only
+func tryLock(*os.File) error {
+	return errors.New("file locking is not supported on this platform")
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestFileLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	first, err := New(dbPath, WithFileLock(0))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// a second storage waits for the lock in another goroutine and gives up after the timeout
+	timeout := 150 * time.Millisecond
+	type result struct {
+		storage *Storage
+		err     error
+		waited  time.Duration
+	}
+	done := make(chan result)
+	go func() {
+		start := time.Now()
+		s, err := New(dbPath, WithFileLock(timeout))
+		done <- result{s, err, time.Since(start)}
+	}()
+
+	second := <-done
+	if !errors.Is(second.err, notesstorage.ErrInUse) {
+		_ = second.storage.Close()
+		t.Fatalf("Expected ErrInUse, got %v", second.err)
+	}
+	if second.waited < timeout {
+		t.Errorf("Expected to wait at least %s, waited %s", timeout, second.waited)
+	}
+
+	// storages without the lock open as before
+	reader, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("Expected no error opening without a lock, got %v", err)
+	}
+	_ = reader.Close()
+
+	// closing releases the lock
+	_ = first.Close()
+	third, err := New(dbPath, WithFileLock(0))
+	if err != nil {
+		t.Fatalf("Expected the lock after closing, got %v", err)
+	}
+	_ = third.Close()
+}
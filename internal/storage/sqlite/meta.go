@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"database/sql"
+	"strings"
+
+	"go-notes/internal/entities"
+)
+
+// SetMeta sets a metadata value of the note with the specified ID, replacing the value of an existing key
+func (s *Storage) SetMeta(noteID int, key, value string) error {
+	key = normalizeMetaKey(key)
+	err := validateSQLParam(noteID, key, value)
+	if err != nil {
+		return err
+	}
+
+	// make sure the note exists, so missing notes are reported the same way as in other methods
+	err = s.noteExists(noteID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO note_meta (note_id, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (note_id, key) DO UPDATE SET value = excluded.value`, noteID, key, value)
+
+	return err
+}
+
+// GetMeta retrieves all metadata of the note with the specified ID
+func (s *Storage) GetMeta(noteID int) (map[string]string, error) {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`SELECT key, value FROM note_meta WHERE note_id = ?`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	meta := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err = rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+
+		meta[key] = value
+	}
+
+	return meta, rows.Err()
+}
+
+// DeleteMeta removes a metadata key from the note with the specified ID
+func (s *Storage) DeleteMeta(noteID int, key string) error {
+	key = normalizeMetaKey(key)
+	err := validateSQLParam(noteID, key)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`DELETE FROM note_meta WHERE note_id = ? AND key = ?`, noteID, key)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - note doesn't have this key
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// normalizeMetaKey trims spaces around a key and lowercases it, so "URL" and "url" are the same key
+func normalizeMetaKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// GetNotesByMeta retrieves notes having the metadata key set to value ordered by ID.
+// An empty value matches every note having the key
+func (s *Storage) GetNotesByMeta(key, value string) ([]entities.Note, error) {
+	key = normalizeMetaKey(key)
+	err := validateSQLParam(key)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + noteColumns + ` FROM ` + s.notes() + `
+		WHERE note_id IN (SELECT note_id FROM note_meta WHERE key = ? AND (? = '' OR value = ?))
+		ORDER BY note_id`
+
+	rows, err := s.db.Query(query, key, value, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetMetaUpsert(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Article", "Worth reading.")
+
+	_ = storage.SetMeta(id, "URL", "https://example.com")
+	_ = storage.SetMeta(id, "status", "todo")
+
+	// keys are normalized, so this updates the existing status
+	err := storage.SetMeta(id, " Status ", "done")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	meta, err := storage.GetMeta(id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(meta) != 2 || meta["url"] != "https://example.com" || meta["status"] != "done" {
+		t.Errorf("Expected url and updated status, got %v", meta)
+	}
+}
+
+func TestDeleteMeta(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Article", "Worth reading.")
+	_ = storage.SetMeta(id, "status", "todo")
+
+	if err := storage.DeleteMeta(id, "STATUS"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	meta, _ := storage.GetMeta(id)
+	if len(meta) != 0 {
+		t.Errorf("Expected no metadata, got %v", meta)
+	}
+
+	if err := storage.DeleteMeta(id, "status"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for missing key, got %v", err)
+	}
+
+	// metadata of missing notes can't be set
+	if err := storage.SetMeta(id+1, "status", "todo"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for missing note, got %v", err)
+	}
+}
+
+func TestGetNotesByMeta(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	open1, _ := storage.NewNote("Bug", "Crash on start.")
+	closed, _ := storage.NewNote("Feature", "Dark mode.")
+	open2, _ := storage.NewNote("Chore", "Update deps.")
+	_, _ = storage.NewNote("Plain", "No fields.")
+
+	_ = storage.SetMeta(open1, "status", "open")
+	_ = storage.SetMeta(closed, "status", "closed")
+	_ = storage.SetMeta(open2, "Status", "open")
+
+	notes, err := storage.GetNotesByMeta("STATUS", "open")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 2 || notes[0].ID != open1 || notes[1].ID != open2 {
+		t.Errorf("Expected open notes %d and %d, got %+v", open1, open2, notes)
+	}
+
+	// an empty value matches any note having the key
+	notes, err = storage.GetNotesByMeta("status", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 3 {
+		t.Errorf("Expected 3 notes with status, got %d", len(notes))
+	}
+}
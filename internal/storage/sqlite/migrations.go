@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"fmt"
+)
+
+// migrations holds schema changes applied in order on top of the base notes table.
+// Number of applied migrations is tracked in PRAGMA user_version, or in the schema_version table of storages
+// with a table prefix, as they share the file. New migrations must only be appended
+var migrations = []string{
+	// 1: tags attached to notes
+	`CREATE TABLE IF NOT EXISTS note_tags (
+		note_id INTEGER NOT NULL REFERENCES notes(note_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		tag TEXT NOT NULL,
+		PRIMARY KEY (note_id, tag));`,
+
+	// 2: last synced note IDs per remote
+	`CREATE TABLE IF NOT EXISTS sync_state (
+		remote TEXT PRIMARY KEY,
+		last_pushed_id INTEGER NOT NULL DEFAULT 0,
+		last_pulled_id INTEGER NOT NULL DEFAULT 0);`,
+
+	// 3: time a note was pinned at, NULL for unpinned notes
+	`ALTER TABLE notes ADD COLUMN pinned_at TIMESTAMP;`,
+
+	// 4: length in bytes of gzip-compressed content before compression, NULL for content stored as is
+	`ALTER TABLE notes ADD COLUMN uncompressed_length INTEGER;`,
+
+	// 5: key/value settings of the storage
+	`CREATE TABLE IF NOT EXISTS settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL);`,
+
+	// 6: arbitrary key/value metadata of notes
+	`CREATE TABLE IF NOT EXISTS note_meta (
+		note_id INTEGER NOT NULL REFERENCES notes(note_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (note_id, key));`,
+
+	// 7: time a note is due at, NULL for notes without a due date
+	`ALTER TABLE notes ADD COLUMN due_at TIMESTAMP;`,
+
+	// 8: time a note expires at, NULL for notes which never expire
+	`ALTER TABLE notes ADD COLUMN expires_at TIMESTAMP;`,
+
+	// 9: time a note was moved to the trash at, NULL for notes which are not in the trash
+	`ALTER TABLE notes ADD COLUMN deleted_at TIMESTAMP;`,
+
+	// 10: index of lowercased titles, so search matches titles without reading content, see searchQuery.
+	// Titles are stored in NFC and lower() folds ASCII case only, the same way LIKE does
+	`CREATE INDEX IF NOT EXISTS notes_title_lower ON notes (lower(title));`,
+
+	// 11: SHA-256 of uncompressed content in hex, filled for existing notes by backfillContentHashes
+	`ALTER TABLE notes ADD COLUMN content_hash TEXT;`,
+
+	// 12: index of content hashes, so duplicates and notes missing a hash are found without reading content
+	`CREATE INDEX IF NOT EXISTS notes_content_hash ON notes (content_hash);`,
+
+	// 13: earlier contents of notes, content is stored the same way as in notes
+	`CREATE TABLE IF NOT EXISTS note_revisions (
+		revision_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		note_id INTEGER NOT NULL REFERENCES notes(note_id) ON DELETE CASCADE ON UPDATE CASCADE,
+		content TEXT,
+		uncompressed_length INTEGER,
+		edited_at TIMESTAMP);`,
+
+	// 14: index of revisions by note, so revisions of a note are found without scanning all of them
+	`CREATE INDEX IF NOT EXISTS note_revisions_note ON note_revisions (note_id, edited_at);`,
+
+	// 15: trigger keeping the replaced content whenever content of a note changes. Content is compared by hash,
+	// so storing the same text compressed or as is doesn't make a revision
+	`CREATE TRIGGER IF NOT EXISTS save_note_revision
+	AFTER UPDATE OF content ON notes
+	FOR EACH ROW WHEN OLD.content_hash IS NOT NEW.content_hash
+	BEGIN
+		INSERT INTO note_revisions (note_id, content, uncompressed_length, edited_at)
+		VALUES (OLD.note_id, OLD.content, OLD.uncompressed_length, OLD.last_edited_at);
+	END;`,
+
+	// 16: audit log of changes to notes. Entries outlive their notes, so note IDs don't reference notes
+	`CREATE TABLE IF NOT EXISTS audit_log (
+		audit_id INTEGER PRIMARY KEY AUTOINCREMENT,
+		note_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		at TIMESTAMP NOT NULL);`,
+
+	// 17: trigger logging created notes
+	`CREATE TRIGGER IF NOT EXISTS audit_note_created
+	AFTER INSERT ON notes
+	FOR EACH ROW
+	BEGIN
+		INSERT INTO audit_log (note_id, action, at) VALUES (NEW.note_id, 'created', ` + currentTime + `);
+	END;`,
+
+	// 18: trigger logging edits of titles and content and moves to and out of the trash. Content is compared
+	// both as stored and by hash, so neither recompressing it nor fixing its hash is logged as an edit
+	`CREATE TRIGGER IF NOT EXISTS audit_note_changed
+	AFTER UPDATE ON notes
+	FOR EACH ROW
+	BEGIN
+		INSERT INTO audit_log (note_id, action, at) SELECT NEW.note_id, 'edited', ` + currentTime + `
+			WHERE OLD.content IS NOT NEW.content AND OLD.content_hash IS NOT NEW.content_hash;
+		INSERT INTO audit_log (note_id, action, at) SELECT NEW.note_id, 'retitled', ` + currentTime + `
+			WHERE OLD.title IS NOT NEW.title;
+		INSERT INTO audit_log (note_id, action, at) SELECT NEW.note_id, 'trashed', ` + currentTime + `
+			WHERE OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL;
+		INSERT INTO audit_log (note_id, action, at) SELECT NEW.note_id, 'restored', ` + currentTime + `
+			WHERE OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL;
+	END;`,
+
+	// 19: trigger logging deleted notes
+	`CREATE TRIGGER IF NOT EXISTS audit_note_deleted
+	AFTER DELETE ON notes
+	FOR EACH ROW
+	BEGIN
+		INSERT INTO audit_log (note_id, action, at) VALUES (OLD.note_id, 'deleted', ` + currentTime + `);
+	END;`,
+}
+
+// migrate applies all migrations which were not applied to the database yet
+func migrate(db *prefixedDB) error {
+	// get number of already applied migrations
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+
+	// apply each pending migration in its own transaction together with the version bump
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(migrations[i]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+
+		if err = setSchemaVersion(tx, i+1); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaVersion returns the number of migrations applied to the database
+func schemaVersion(db *prefixedDB) (int, error) {
+	var version int
+	if db.prefix == "" {
+		err := db.QueryRow(`PRAGMA user_version`).Scan(&version)
+		return version, err
+	}
+
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`)
+	if err != nil {
+		return 0, err
+	}
+
+	// the table is empty until the first migration is applied
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+
+	return version, err
+}
+
+// setSchemaVersion stores the number of migrations applied to the database
+func setSchemaVersion(tx *prefixedTx, version int) error {
+	if tx.prefix == "" {
+		// PRAGMA does not support placeholders, version is always an integer
+		_, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, version))
+		return err
+	}
+
+	_, err := tx.Exec(`DELETE FROM schema_version`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO schema_version (version) VALUES (?)`, version)
+
+	return err
+}
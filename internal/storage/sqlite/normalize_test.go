@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchUnicodeNormalization(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	const (
+		composed   = "café"  // é as a single code point
+		decomposed = "café" // e followed by a combining acute accent
+	)
+
+	decomposedTitle, _ := storage.NewNote("Best "+decomposed, "Near the station.")
+	composedContent, _ := storage.NewNote("Coffee", "A "+composed+" with a view.")
+	decomposedContent, _ := storage.NewNote("Breakfast", "Another "+decomposed+" downtown.")
+
+	// stored titles are normalized
+	note, _ := storage.GetNoteByID(decomposedTitle)
+	if note.Title != "Best "+composed {
+		t.Errorf("Expected title in NFC %q, got %q", "Best "+composed, note.Title)
+	}
+
+	// both forms of the keyword find all notes regardless of the form they were written in
+	for _, keyword := range []string{composed, decomposed} {
+		notes, err := storage.SearchNotesByKeyword(keyword)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(notes) != 3 || notes[0].ID != decomposedTitle || notes[1].ID != composedContent || notes[2].ID != decomposedContent {
+			t.Errorf("Expected 3 notes for keyword %q, got %+v", keyword, notes)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// orderClauses maps orders of notes to ORDER BY clauses, ties are broken by ID so the order is stable
+var orderClauses = map[storage.NoteOrder]string{
+	storage.OrderEdited:  `last_edited_at DESC, note_id DESC`,
+	storage.OrderCreated: `created_at DESC, note_id DESC`,
+	storage.OrderTitle:   `title, note_id`,
+	storage.OrderID:      `note_id`,
+}
+
+// GetNthNote retrieves the note at the 1-based position n of notes sorted in the order.
+// If there are fewer than n notes, sql.ErrNoRows is returned
+func (s *Storage) GetNthNote(order storage.NoteOrder, n int) (entities.Note, error) {
+	clause, ok := orderClauses[order]
+	if !ok {
+		return entities.Note{}, fmt.Errorf("unknown order: %s", order)
+	}
+	if n < 1 {
+		return entities.Note{}, sql.ErrNoRows
+	}
+
+	// only the wanted note is read, the database skips the preceding ones
+	row := s.db.QueryRow(`SELECT `+noteColumns+` FROM `+s.notes()+` ORDER BY `+clause+` LIMIT 1 OFFSET ?`, n-1)
+
+	return scanNote(row)
+}
+
+// GetNoteOfTheDay retrieves a note picked by the calendar day of date in its location, so the same note is returned
+// all day and usually another one the next day. The pick is a hash of the day modulo the number of notes ordered by ID,
+// so it changes when notes are added or deleted. If there are no notes, sql.ErrNoRows is returned
+func (s *Storage) GetNoteOfTheDay(date time.Time) (entities.Note, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM ` + s.notes()).Scan(&count); err != nil {
+		return entities.Note{}, err
+	}
+	if count == 0 {
+		return entities.Note{}, sql.ErrNoRows
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(date.Format(time.DateOnly)))
+
+	return s.GetNthNote(storage.OrderID, int(h.Sum64()%uint64(count))+1)
+}
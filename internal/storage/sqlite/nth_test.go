@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+func TestGetNthNote(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	s, _ := New(dbPath)
+	defer s.Close()
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, note := range []entities.Note{
+		{Title: "Banana", Content: "1", CreatedAt: base, LastEditedAt: base.Add(3 * time.Hour)},
+		{Title: "Cherry", Content: "2", CreatedAt: base.Add(time.Hour), LastEditedAt: base.Add(time.Hour)},
+		{Title: "Apple", Content: "3", CreatedAt: base.Add(2 * time.Hour), LastEditedAt: base.Add(5 * time.Hour)},
+		{Title: "Date", Content: "4", CreatedAt: base.Add(4 * time.Hour), LastEditedAt: base.Add(4 * time.Hour)},
+	} {
+		_, _ = s.CreateNote(note, nil)
+	}
+
+	tests := []struct {
+		order    storage.NoteOrder
+		n        int
+		expected string
+	}{
+		{storage.OrderEdited, 1, "Apple"},
+		{storage.OrderEdited, 3, "Banana"},
+		{storage.OrderCreated, 1, "Date"},
+		{storage.OrderCreated, 4, "Banana"},
+		{storage.OrderTitle, 2, "Banana"},
+		{storage.OrderID, 3, "Apple"},
+	}
+	for _, tt := range tests {
+		note, err := s.GetNthNote(tt.order, tt.n)
+		if err != nil || note.Title != tt.expected {
+			t.Errorf("Expected note %d by %s to be %s, got %q (%v)", tt.n, tt.order, tt.expected, note.Title, err)
+		}
+	}
+
+	for _, n := range []int{0, 5} {
+		if _, err := s.GetNthNote(storage.OrderEdited, n); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("Expected sql.ErrNoRows for index %d, got %v", n, err)
+		}
+	}
+	if _, err := s.GetNthNote("size", 1); err == nil {
+		t.Errorf("Expected an error for an unknown order")
+	}
+}
+
+func TestGetNoteOfTheDay(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	s, _ := New(dbPath)
+	defer s.Close()
+
+	day := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	if _, err := s.GetNoteOfTheDay(day); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows without notes, got %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		_, _ = s.NewNote("Note", "Content")
+	}
+
+	// the pick stays the same all day
+	morning, err := s.GetNoteOfTheDay(day)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if evening, _ := s.GetNoteOfTheDay(day.Add(14 * time.Hour)); evening.ID != morning.ID {
+		t.Errorf("Expected note %d all day, got %d in the evening", morning.ID, evening.ID)
+	}
+
+	// other days pick other notes, though any single day may repeat one
+	picked := map[int]bool{}
+	for i := 0; i < 30; i++ {
+		note, _ := s.GetNoteOfTheDay(day.AddDate(0, 0, i))
+		picked[note.ID] = true
+	}
+	if len(picked) < 2 {
+		t.Errorf("Expected different notes over a month, got %v", picked)
+	}
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+	"go-notes/internal/links"
+)
+
+// GetOrphanNotes retrieves notes which have no tags and neither link to nor are linked from other notes, ordered by ID
+func (s *Storage) GetOrphanNotes() ([]entities.Note, error) {
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + `
+		WHERE note_id NOT IN (SELECT note_id FROM note_tags) ORDER BY note_id`)
+	if err != nil {
+		return nil, err
+	}
+
+	untagged, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// links live in content which may be compressed, so they are resolved after scanning all notes
+	notes, err := s.GetAllNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	linked := make(map[int]bool)
+	for _, edge := range links.Edges(notes) {
+		linked[edge.From] = true
+		linked[edge.To] = true
+	}
+
+	var orphans []entities.Note
+	for _, note := range untagged {
+		if !linked[note.ID] {
+			orphans = append(orphans, note)
+		}
+	}
+
+	return orphans, nil
+}
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetOrphanNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	isolated1, _ := storage.NewNote("Isolated", "Nothing here.")
+	tagged, _ := storage.NewNote("Tagged", "Has a tag.")
+	target, _ := storage.NewNote("Target", "Only linked from another note.")
+	_, _ = storage.NewNote("Source", fmt.Sprintf("Links to [[%d]].", target))
+	isolated2, _ := storage.NewNote("Broken link", "Links to a missing [[100]] and itself [[5]].")
+
+	_ = storage.AddTag(tagged, "work")
+
+	notes, err := storage.GetOrphanNotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notes) != 2 || notes[0].ID != isolated1 || notes[1].ID != isolated2 {
+		t.Errorf("Expected orphans %d and %d, got %+v", isolated1, isolated2, notes)
+	}
+}
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// GetNotesPageMeta retrieves up to limit notes in ID order starting after the first offset ones,
+// together with the total number of notes and whether more notes follow the page
+func (s *Storage) GetNotesPageMeta(limit, offset int) (entities.Page, error) {
+	err := validateSQLParam(limit)
+	if err != nil {
+		return entities.Page{}, err
+	}
+	// 0 is a valid offset here, so only negative offsets are rejected
+	if offset < 0 {
+		return entities.Page{}, invalidNum
+	}
+
+	// count and page are read in one transaction, so they agree even if notes are written meanwhile
+	tx, err := s.db.Begin()
+	if err != nil {
+		return entities.Page{}, err
+	}
+	// nothing is written, so the transaction is always rolled back
+	defer tx.Rollback()
+
+	var page entities.Page
+	err = tx.QueryRow(`SELECT COUNT(*) FROM ` + s.notes()).Scan(&page.Total)
+	if err != nil {
+		return entities.Page{}, err
+	}
+
+	rows, err := tx.Query(`SELECT `+noteColumns+` FROM `+s.notes()+` ORDER BY note_id LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return entities.Page{}, err
+	}
+
+	page.Notes, err = scanNotes(rows)
+	if err != nil {
+		return entities.Page{}, err
+	}
+	page.HasMore = offset+len(page.Notes) < page.Total
+
+	return page, nil
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetNotesPageMeta(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	for i := 1; i <= 5; i++ {
+		_, _ = storage.NewNote(fmt.Sprintf("Note %d", i), "Content")
+	}
+
+	tests := []struct {
+		limit, offset int
+		firstID       int
+		count         int
+		hasMore       bool
+	}{
+		{limit: 2, offset: 0, firstID: 1, count: 2, hasMore: true},
+		{limit: 2, offset: 2, firstID: 3, count: 2, hasMore: true},
+		{limit: 2, offset: 4, firstID: 5, count: 1, hasMore: false},
+		{limit: 5, offset: 0, firstID: 1, count: 5, hasMore: false},
+		{limit: 2, offset: 5, count: 0, hasMore: false},
+	}
+	for _, tt := range tests {
+		page, err := storage.GetNotesPageMeta(tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if page.Total != 5 {
+			t.Errorf("Expected total 5 for limit %d offset %d, got %d", tt.limit, tt.offset, page.Total)
+		}
+		if page.HasMore != tt.hasMore {
+			t.Errorf("Expected has more %t for limit %d offset %d, got %t", tt.hasMore, tt.limit, tt.offset, page.HasMore)
+		}
+		if len(page.Notes) != tt.count {
+			t.Fatalf("Expected %d notes for limit %d offset %d, got %d", tt.count, tt.limit, tt.offset, len(page.Notes))
+		}
+		if tt.count > 0 && page.Notes[0].ID != tt.firstID {
+			t.Errorf("Expected page to start at note %d, got %d", tt.firstID, page.Notes[0].ID)
+		}
+	}
+
+	if _, err := storage.GetNotesPageMeta(0, 0); err == nil {
+		t.Errorf("Expected error for a zero limit, got nil")
+	}
+	if _, err := storage.GetNotesPageMeta(2, -1); err == nil {
+		t.Errorf("Expected error for a negative offset, got nil")
+	}
+}
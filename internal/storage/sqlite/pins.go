@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// PinNote pins the note with the specified ID, pinning an already pinned note keeps its original pin time.
+// If the pin limit is set and reached, storage.ErrPinLimitReached is returned
+func (s *Storage) PinNote(noteID int) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// check the limit in the same transaction, so concurrent pins can't exceed it
+	err = s.checkPinLimit(tx, noteID)
+	if err != nil {
+		return err
+	}
+
+	err = setPinnedAt(tx, noteID, `COALESCE(pinned_at, CURRENT_TIMESTAMP)`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UnpinNote unpins the note with the specified ID
+func (s *Storage) UnpinNote(noteID int) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	return setPinnedAt(s.db, noteID, `NULL`)
+}
+
+// GetNotesForDashboard retrieves pinned notes in the order they were pinned,
+// followed by the rest of notes ordered from the most recently edited
+func (s *Storage) GetNotesForDashboard() ([]entities.Note, error) {
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + `
+		ORDER BY pinned_at IS NULL, pinned_at, last_edited_at DESC, note_id DESC`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
+
+// checkPinLimit returns storage.ErrPinLimitReached if the note can't be pinned without exceeding the pin limit
+func (s *Storage) checkPinLimit(db querier, noteID int) error {
+	if s.pinLimit <= 0 {
+		return nil
+	}
+
+	var pinned, alreadyPinned int
+	err := db.QueryRow(`SELECT COUNT(*), COALESCE(SUM(note_id = ?), 0) FROM `+s.notes()+` WHERE pinned_at IS NOT NULL`, noteID).
+		Scan(&pinned, &alreadyPinned)
+	if err != nil {
+		return err
+	}
+
+	// pinning an already pinned note doesn't take a new slot
+	if alreadyPinned == 0 && pinned >= s.pinLimit {
+		return storage.ErrPinLimitReached
+	}
+
+	return nil
+}
+
+// execer is implemented by both *prefixedDB and *prefixedTx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// setPinnedAt sets pinned_at of the note to the given SQL expression
+func setPinnedAt(db execer, noteID int, value string) error {
+	res, err := db.Exec(`UPDATE notes SET pinned_at = `+value+` WHERE note_id = ?`, noteID)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - return an error
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestGetNotesForDashboard(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	// insert notes directly, so timestamps are not bumped by the update trigger
+	_, err := storage.db.Exec(`INSERT INTO notes (note_id, title, content, last_edited_at, pinned_at) VALUES
+		(1, 'Old unpinned', 'c', '2024-01-01 10:00:00', NULL),
+		(2, 'Pinned second', 'c', '2024-01-05 10:00:00', '2024-02-02 10:00:00'),
+		(3, 'Recent unpinned', 'c', '2024-03-01 10:00:00', NULL),
+		(4, 'Pinned first', 'c', '2024-01-02 10:00:00', '2024-02-01 10:00:00')`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	notes, err := storage.GetNotesForDashboard()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int{4, 2, 3, 1}
+	if len(notes) != len(expected) {
+		t.Fatalf("Expected %d notes, got %d", len(expected), len(notes))
+	}
+	for i, id := range expected {
+		if notes[i].ID != id {
+			t.Errorf("Expected note %d at position %d, got %d", id, i, notes[i].ID)
+		}
+	}
+
+	if notes[0].PinnedAt == nil || notes[2].PinnedAt != nil {
+		t.Errorf("Expected pinned notes to have pin time and unpinned ones not to")
+	}
+}
+
+func TestPinNote(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Test Note", "This is a test note.")
+
+	if err := storage.PinNote(noteID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.PinnedAt == nil {
+		t.Fatal("Expected note to be pinned")
+	}
+
+	if err := storage.UnpinNote(noteID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ = storage.GetNoteByID(noteID)
+	if note.PinnedAt != nil {
+		t.Error("Expected note to be unpinned")
+	}
+
+	if err := storage.PinNote(noteID + 1); err == nil {
+		t.Error("Expected error pinning a missing note")
+	}
+}
+
+func TestPinLimit(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithPinLimit(2))
+	defer storage.Close()
+
+	first, _ := storage.NewNote("Note 1", "First note.")
+	second, _ := storage.NewNote("Note 2", "Second note.")
+	third, _ := storage.NewNote("Note 3", "Third note.")
+
+	for _, id := range []int{first, second} {
+		if err := storage.PinNote(id); err != nil {
+			t.Fatalf("Expected no error pinning note %d, got %v", id, err)
+		}
+	}
+
+	if err := storage.PinNote(third); !errors.Is(err, notesstorage.ErrPinLimitReached) {
+		t.Errorf("Expected ErrPinLimitReached, got %v", err)
+	}
+
+	// re-pinning an already pinned note doesn't count against the limit
+	if err := storage.PinNote(first); err != nil {
+		t.Errorf("Expected no error re-pinning a pinned note, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(third)
+	if note.PinnedAt != nil {
+		t.Error("Expected third note to stay unpinned")
+	}
+}
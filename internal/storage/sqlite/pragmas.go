@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	"go-notes/internal/storage"
+)
+
+// journalModes lists journal modes accepted by WithJournalMode
+var journalModes = []string{"delete", "truncate", "persist", "memory", "wal", "off"}
+
+// WithJournalMode sets the journal mode of every connection, e.g. "wal". The SQLite default is "delete"
+func WithJournalMode(mode string) Option {
+	return func(s *Storage) {
+		s.journalMode = strings.ToLower(mode)
+	}
+}
+
+// dataSourceName returns the connection string of the database file with connection settings of the storage
+func (s *Storage) dataSourceName(path string) (string, error) {
+	// foreign keys are enforced on every connection
+	dsn := path + "?_foreign_keys=on"
+
+	if s.journalMode != "" {
+		valid := false
+		for _, mode := range journalModes {
+			valid = valid || mode == s.journalMode
+		}
+		if !valid {
+			return "", fmt.Errorf("unknown journal mode %q, expected one of %s", s.journalMode, strings.Join(journalModes, ", "))
+		}
+
+		dsn += "&_journal_mode=" + s.journalMode
+	}
+
+	return dsn, nil
+}
+
+// GetPragmas retrieves effective settings and size of the database
+func (s *Storage) GetPragmas() (storage.Pragmas, error) {
+	var pragmas storage.Pragmas
+
+	err := s.db.QueryRow(`SELECT journal_mode, foreign_keys, page_size, page_count
+		FROM pragma_journal_mode(), pragma_foreign_keys(), pragma_page_size(), pragma_page_count()`).
+		Scan(&pragmas.JournalMode, &pragmas.ForeignKeys, &pragmas.PageSize, &pragmas.PageCount)
+
+	return pragmas, err
+}
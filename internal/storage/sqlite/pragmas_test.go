@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetPragmas(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, err := New(dbPath, WithJournalMode("WAL"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer storage.Close()
+
+	pragmas, err := storage.GetPragmas()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if pragmas.JournalMode != "wal" {
+		t.Errorf("Expected journal mode wal, got %q", pragmas.JournalMode)
+	}
+	if !pragmas.ForeignKeys {
+		t.Errorf("Expected foreign keys to be enforced")
+	}
+	if pragmas.PageSize <= 0 || pragmas.PageCount <= 0 || pragmas.Size() != pragmas.PageSize*pragmas.PageCount {
+		t.Errorf("Expected positive page size and count, got %+v", pragmas)
+	}
+}
+
+func TestGetPragmasDefaultJournalMode(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	pragmas, _ := storage.GetPragmas()
+	if pragmas.JournalMode != "delete" {
+		t.Errorf("Expected default journal mode delete, got %q", pragmas.JournalMode)
+	}
+}
+
+func TestWithJournalModeUnknown(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	if _, err := New(dbPath, WithJournalMode("fast")); err == nil {
+		t.Errorf("Expected error for an unknown journal mode, got nil")
+	}
+}
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+)
+
+// tablePrefixPattern matches table prefixes which are safe to put into SQL as part of identifiers
+var tablePrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,31}$`)
+
+// schemaNames matches names of tables, indexes and triggers of the schema, which get the table prefix
+var schemaNames = regexp.MustCompile(`\b(notes|note_tags|note_meta|note_revisions|audit_log|settings|sync_state|` +
+	`schema_version|notes_title_lower|notes_content_hash|note_revisions_note|update_last_edited_at|save_note_revision|` +
+	`audit_note_created|audit_note_changed|audit_note_deleted)\b`)
+
+// WithTablePrefix prefixes names of all tables, indexes and triggers, so several storages with different
+// prefixes keep isolated notes in a single database file. The prefix must start with a letter followed by
+// letters, digits or underscores, up to 32 characters in total, e.g. "work_" stores notes in work_notes
+func WithTablePrefix(prefix string) Option {
+	return func(s *Storage) {
+		s.tablePrefix = prefix
+	}
+}
+
+// validateTablePrefix checks that the prefix can be safely put into SQL, an empty prefix is valid
+func validateTablePrefix(prefix string) error {
+	if prefix != "" && !tablePrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid table prefix %q, expected a letter followed by letters, digits or underscores", prefix)
+	}
+
+	return nil
+}
+
+// prefixTables adds the prefix to names of the schema in the query
+func prefixTables(prefix, query string) string {
+	if prefix == "" {
+		return query
+	}
+
+	return schemaNames.ReplaceAllString(query, prefix+"$1")
+}
+
+// prefixedDB is a database connection adding the table prefix to names of the schema in every query,
+// so queries are written with plain names. Context variants of methods are not rewritten and must not be used
+type prefixedDB struct {
+	*sql.DB
+	prefix string
+}
+
+// Exec executes the query with prefixed names
+func (db *prefixedDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(prefixTables(db.prefix, query), args...)
+}
+
+// Query runs the query with prefixed names
+func (db *prefixedDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(prefixTables(db.prefix, query), args...)
+}
+
+// QueryRow runs the query with prefixed names
+func (db *prefixedDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(prefixTables(db.prefix, query), args...)
+}
+
+// Prepare prepares the query with prefixed names
+func (db *prefixedDB) Prepare(query string) (*sql.Stmt, error) {
+	return db.DB.Prepare(prefixTables(db.prefix, query))
+}
+
+// Begin starts a transaction adding the same prefix
+func (db *prefixedDB) Begin() (*prefixedTx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &prefixedTx{Tx: tx, prefix: db.prefix}, nil
+}
+
+// prefixedTx is a transaction adding the table prefix to names of the schema in every query, see prefixedDB
+type prefixedTx struct {
+	*sql.Tx
+	prefix string
+}
+
+// Exec executes the query with prefixed names
+func (tx *prefixedTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(prefixTables(tx.prefix, query), args...)
+}
+
+// Query runs the query with prefixed names
+func (tx *prefixedTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(prefixTables(tx.prefix, query), args...)
+}
+
+// QueryRow runs the query with prefixed names
+func (tx *prefixedTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(prefixTables(tx.prefix, query), args...)
+}
+
+// Prepare prepares the query with prefixed names
+func (tx *prefixedTx) Prepare(query string) (*sql.Stmt, error) {
+	return tx.Tx.Prepare(prefixTables(tx.prefix, query))
+}
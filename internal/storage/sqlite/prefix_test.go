@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTablePrefixIsolation(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	work, err := New(dbPath, WithTablePrefix("work_"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer work.Close()
+	home, _ := New(dbPath, WithTablePrefix("home_"))
+	defer home.Close()
+	plain, _ := New(dbPath)
+	defer plain.Close()
+
+	workID, _ := work.NewNote("Report", "Quarterly numbers")
+	_ = work.AddTag(workID, "office")
+	_ = work.SetSetting("list.columns", "id,title")
+	homeID, _ := home.NewNote("Groceries", "Milk")
+
+	// every storage numbers its notes separately and sees only its own ones
+	if workID != 1 || homeID != 1 {
+		t.Errorf("Expected both notes to get ID 1, got %d and %d", workID, homeID)
+	}
+	for name, s := range map[string]*Storage{"work": work, "home": home, "plain": plain} {
+		notes, err := s.GetAllNotes()
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", name, err)
+		}
+
+		var titles []string
+		for _, note := range notes {
+			titles = append(titles, note.Title)
+		}
+		expected := map[string][]string{"work": {"Report"}, "home": {"Groceries"}, "plain": nil}[name]
+		if !reflect.DeepEqual(titles, expected) {
+			t.Errorf("Expected notes %v in %s, got %v", expected, name, titles)
+		}
+	}
+
+	if tags, _ := home.GetNoteTags(homeID); len(tags) != 0 {
+		t.Errorf("Expected no tags of the home note, got %v", tags)
+	}
+	if _, ok, _ := home.GetSetting("list.columns"); ok {
+		t.Error("Expected settings of work to be invisible to home")
+	}
+
+	// tables of each prefix exist side by side in the file
+	var tables int
+	_ = plain.db.DB.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('notes', 'work_notes', 'home_notes')`).Scan(&tables)
+	if tables != 3 {
+		t.Errorf("Expected 3 notes tables, got %d", tables)
+	}
+
+	// reopening keeps the notes and doesn't apply migrations again
+	_ = work.Close()
+	work, err = New(dbPath, WithTablePrefix("work_"))
+	if err != nil {
+		t.Fatalf("Expected no error reopening, got %v", err)
+	}
+	defer work.Close()
+	if note, err := work.GetNoteByID(workID); err != nil || note.Title != "Report" {
+		t.Errorf("Expected the work note after reopening, got %+v (%v)", note, err)
+	}
+}
+
+func TestInvalidTablePrefix(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	for _, prefix := range []string{"1work", "work-", "work; DROP TABLE notes; --", "_work"} {
+		if s, err := New(dbPath, WithTablePrefix(prefix)); err == nil {
+			_ = s.Close()
+			t.Errorf("Expected an error for prefix %q", prefix)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import "go-notes/internal/storage"
+
+// Rebuild regenerates data derived from notes, content hashes, lengths of compressed content and indexes, in a single transaction,
+// so a database whose derived data got out of sync with the notes works correctly again.
+// Last edit times of notes are kept
+func (s *Storage) Rebuild() (storage.RebuildReport, error) {
+	var report storage.RebuildReport
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return report, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// trashed notes are rebuilt as well, as they can be restored
+	if err = tx.QueryRow(`SELECT COUNT(*) FROM notes`).Scan(&report.Notes); err != nil {
+		return report, err
+	}
+	if report.ContentHashes, err = rehashNotes(tx, ``, s.editTriggerStatement()); err != nil {
+		return report, err
+	}
+
+	// names are read back with the table prefix, which the report leaves out
+	rows, err := tx.Query(`SELECT substr(name, ?) FROM sqlite_master WHERE type = 'index' AND tbl_name = 'notes' AND sql IS NOT NULL ORDER BY name`,
+		len(s.tablePrefix)+1)
+	if err != nil {
+		return report, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return report, err
+		}
+		report.Indexes = append(report.Indexes, name)
+	}
+	if err = rows.Err(); err != nil {
+		return report, err
+	}
+	// the transaction can't run statements while rows are open
+	_ = rows.Close()
+
+	if _, err = tx.Exec(`REINDEX notes`); err != nil {
+		return report, err
+	}
+
+	return report, tx.Commit()
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestRebuild(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	lastEdited := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	first, _ := storage.CreateNote(entities.Note{Title: "First", Content: "Shared words", CreatedAt: lastEdited}, nil)
+	second, _ := storage.CreateNote(entities.Note{Title: "Second", Content: "Shared words", CreatedAt: lastEdited}, nil)
+	_, _ = storage.CreateNote(entities.Note{Title: "Third", Content: "Other words", CreatedAt: lastEdited}, nil)
+
+	// clearing and corrupting hashes hides the duplicates, the trigger is dropped to keep edit times
+	// and is expected to be restored by rebuilding
+	_, _ = storage.db.Exec(`DROP TRIGGER update_last_edited_at`)
+	_, _ = storage.db.Exec(`UPDATE notes SET content_hash = NULL WHERE note_id = ?`, first)
+	_, _ = storage.db.Exec(`UPDATE notes SET content_hash = 'corrupt' WHERE note_id = ?`, second)
+	if groups, _ := storage.FindDuplicatesByHash(); len(groups) != 0 {
+		t.Fatalf("Expected no duplicates with broken hashes, got %v", groups)
+	}
+
+	report, err := storage.Rebuild()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Notes != 3 || report.ContentHashes != 2 {
+		t.Errorf("Expected 2 of 3 notes rehashed, got %+v", report)
+	}
+	if expected := []string{"notes_content_hash", "notes_title_lower"}; !reflect.DeepEqual(report.Indexes, expected) {
+		t.Errorf("Expected indexes %v, got %v", expected, report.Indexes)
+	}
+
+	groups, _ := storage.FindDuplicatesByHash()
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Errorf("Expected the duplicates to be found again, got %v", groups)
+	}
+	if notes, _ := storage.SearchNotesByKeyword("shared"); len(notes) != 2 {
+		t.Errorf("Expected search to find 2 notes, got %d", len(notes))
+	}
+
+	// rebuilding keeps edit times and the trigger updating them
+	note, _ := storage.GetNoteByID(first)
+	if !note.LastEditedAt.Equal(lastEdited) {
+		t.Errorf("Expected last edit time %s, got %s", lastEdited, note.LastEditedAt)
+	}
+	_ = storage.SetNoteContent(first, "Changed")
+	if note, _ = storage.GetNoteByID(first); !note.LastEditedAt.After(lastEdited) {
+		t.Errorf("Expected a new edit time after rebuilding, got %s", note.LastEditedAt)
+	}
+
+	// a second rebuild has nothing to fix
+	if report, _ = storage.Rebuild(); report.ContentHashes != 0 {
+		t.Errorf("Expected no hashes to fix, got %d", report.ContentHashes)
+	}
+}
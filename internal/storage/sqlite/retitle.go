@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"fmt"
+)
+
+// RetitleNotes changes titles of notes whose title contains match, ignoring ASCII case like search does,
+// to the result of retitle in a single transaction. It returns the result of every changed title keyed by note ID,
+// nil for applied ones. A new title which is empty or too long fails only its note, other titles are still changed
+func (s *Storage) RetitleNotes(match string, retitle func(title string) string) (map[int]error, error) {
+	match = normalizeTitle(match)
+	err := validateSQLParam(match)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT note_id, title FROM ` + s.notes() + ` ORDER BY note_id`)
+	if err != nil {
+		return nil, err
+	}
+
+	// titles are collected before updating, as the transaction can't run statements while rows are open
+	titles := make(map[int]string)
+	var ids []int
+	for rows.Next() {
+		var (
+			id    int
+			title string
+		)
+		if err = rows.Scan(&id, &title); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		if containsFold(title, match) {
+			ids = append(ids, id)
+			titles[id] = title
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make(map[int]error)
+	for _, id := range ids {
+		title := normalizeTitle(retitle(titles[id]))
+		if title == titles[id] {
+			continue
+		}
+
+		if err = validateSQLParam(title); err != nil {
+			results[id] = fmt.Errorf("invalid title: %w", err)
+			continue
+		}
+
+		_, err = tx.Exec(`UPDATE notes SET title = ?, last_edited_at = `+currentTime+` WHERE note_id = ?`, title, id)
+		if err != nil {
+			return nil, err
+		}
+
+		results[id] = nil
+	}
+
+	return results, tx.Commit()
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRetitleNotesPrefix(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Meeting with Anna", "Agenda")
+	_, _ = storage.NewNote("Shopping", "Milk")
+	_, _ = storage.NewNote("weekly meeting", "Notes")
+
+	results, err := storage.RetitleNotes("Meeting", func(title string) string { return "[Archived] " + title })
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 2 || results[1] != nil || results[3] != nil {
+		t.Errorf("Expected notes 1 and 3 to be retitled, got %v", results)
+	}
+
+	for id, expected := range map[int]string{1: "[Archived] Meeting with Anna", 2: "Shopping", 3: "[Archived] weekly meeting"} {
+		note, _ := storage.GetNoteByID(id)
+		if note.Title != expected {
+			t.Errorf("Expected note %d to be titled %q, got %q", id, expected, note.Title)
+		}
+	}
+}
+
+func TestRetitleNotesTooLong(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Meeting: short", "Agenda")
+	_, _ = storage.NewNote("Meeting: long", "Agenda")
+
+	// replacing "long" exceeds the title length limit for the second note only
+	long := strings.Repeat("x", maxStringLength)
+	results, err := storage.RetitleNotes("Meeting", func(title string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(title, "long", long), "Meeting", "Call")
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if results[1] != nil || !errors.Is(results[2], invalidParamLength) {
+		t.Errorf("Expected only note 2 to fail with invalidParamLength, got %v", results)
+	}
+
+	first, _ := storage.GetNoteByID(1)
+	second, _ := storage.GetNoteByID(2)
+	if first.Title != "Call: short" || second.Title != "Meeting: long" {
+		t.Errorf("Expected only the first note to be retitled, got %q and %q", first.Title, second.Title)
+	}
+}
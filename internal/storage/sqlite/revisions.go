@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// GetRevisions retrieves earlier contents of the note, oldest first. A revision is kept by the
+// save_note_revision trigger whenever content of a note changes
+func (s *Storage) GetRevisions(noteID int) ([]entities.Revision, error) {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return noteRevisions(s.db, noteID)
+}
+
+// noteRevisions reads revisions of the note with db, which may be a transaction, decompressing their content
+func noteRevisions(db querier, noteID int) ([]entities.Revision, error) {
+	rows, err := db.Query(`SELECT COALESCE(content, ''), edited_at, uncompressed_length IS NOT NULL FROM note_revisions
+		WHERE note_id = ? ORDER BY edited_at, revision_id`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var revisions []entities.Revision
+	for rows.Next() {
+		var (
+			revision   entities.Revision
+			compressed bool
+		)
+		if err = rows.Scan(&revision.Content, timestamp{&revision.EditedAt}, &compressed); err != nil {
+			return nil, err
+		}
+		if revision.Content, err = decodeContent(revision.Content, compressed); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// insertRevisions stores revisions of the note with the transaction. Their content is stored as is,
+// as it's only read back by exports with history
+func insertRevisions(tx *prefixedTx, noteID int, revisions []entities.Revision) error {
+	for _, revision := range revisions {
+		_, err := tx.Exec(`INSERT INTO note_revisions (note_id, content, edited_at) VALUES (?, ?, ?)`,
+			noteID, revision.Content, dbTime(revision.EditedAt))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestRevisionsKeptOnEdit(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "notes.db"), WithCompression(true), WithTablePrefix("work_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	long := strings.Repeat("compressed ", 200)
+	id, _ := storage.NewNote("Plan", "first")
+	_ = storage.SetNoteContent(id, long)
+	_ = storage.SetNoteContent(id, "third")
+	// unchanged content and other changes of the note don't make revisions
+	_ = storage.SetNoteContent(id, "third")
+	_ = storage.PinNote(id)
+	other, _ := storage.NewNote("Other", "untouched")
+
+	revisions, err := storage.GetRevisions(id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Content != "first" || revisions[1].Content != long {
+		t.Fatalf("Expected revisions 'first' and the long content, got %+v", revisions)
+	}
+	if revisions[0].EditedAt.IsZero() || revisions[1].EditedAt.Before(revisions[0].EditedAt) {
+		t.Errorf("Expected revisions ordered by their edit times, got %+v", revisions)
+	}
+
+	if revisions, _ = storage.GetRevisions(other); len(revisions) != 0 {
+		t.Errorf("Expected no revisions of an unedited note, got %+v", revisions)
+	}
+
+	// scrubbed content doesn't survive as a revision
+	if _, err = storage.ScrubContent(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if revisions, _ = storage.GetRevisions(id); len(revisions) != 0 {
+		t.Errorf("Expected no revisions after scrubbing, got %+v", revisions)
+	}
+}
+
+func TestCreateNoteWithRevisions(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	edited := time.Date(2024, 1, 9, 12, 0, 0, 0, time.UTC)
+	revisions := []entities.Revision{
+		{Content: "draft", EditedAt: edited},
+		{Content: "second draft", EditedAt: edited.Add(time.Hour)},
+	}
+	id, err := storage.CreateNote(entities.Note{Title: "Restored", Content: "final", Revisions: revisions}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, err := storage.GetRevisions(id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, revisions) {
+		t.Errorf("Expected revisions %+v, got %+v", revisions, got)
+	}
+}
@@ -0,0 +1,36 @@
+package sqlite
+
+import "go-notes/internal/storage"
+
+// GetSchema retrieves statements creating tables, indexes and triggers of the storage and the migration version.
+// Objects of other table prefixes and internal SQLite tables are left out
+func (s *Storage) GetSchema() (storage.Schema, error) {
+	var schema storage.Schema
+
+	version, err := schemaVersion(s.db)
+	if err != nil {
+		return schema, err
+	}
+	schema.Version = version
+
+	// table names are prefixed like in any other query, so only objects of this storage are selected
+	rows, err := s.db.Query(`SELECT type, name, sql FROM sqlite_master
+		WHERE sql IS NOT NULL AND tbl_name IN ('notes', 'note_tags', 'note_meta', 'note_revisions', 'audit_log', 'settings',
+			'sync_state', 'schema_version')
+		ORDER BY CASE type WHEN 'table' THEN 0 WHEN 'index' THEN 1 ELSE 2 END, name`)
+	if err != nil {
+		return schema, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	for rows.Next() {
+		var object storage.SchemaObject
+		if err = rows.Scan(&object.Type, &object.Name, &object.SQL); err != nil {
+			return schema, err
+		}
+		schema.Objects = append(schema.Objects, object)
+	}
+
+	return schema, rows.Err()
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+	other, _ := New(dbPath, WithTablePrefix("other_"))
+	defer other.Close()
+
+	schema, err := storage.GetSchema()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if schema.Version != len(migrations) {
+		t.Errorf("Expected version %d, got %d", len(migrations), schema.Version)
+	}
+
+	found := map[string]string{}
+	for _, object := range schema.Objects {
+		found[object.Name] = object.SQL
+		if strings.HasPrefix(object.Name, "other_") {
+			t.Errorf("Expected objects of another prefix to be left out, got %s", object.Name)
+		}
+	}
+	if !strings.HasPrefix(found["notes"], "CREATE TABLE notes") {
+		t.Errorf("Expected the notes table definition, got %q", found["notes"])
+	}
+	if !strings.Contains(found["update_last_edited_at"], "CREATE TRIGGER") {
+		t.Errorf("Expected the update_last_edited_at trigger, got %q", found["update_last_edited_at"])
+	}
+	if _, ok := found["notes_title_lower"]; !ok {
+		t.Error("Expected the notes_title_lower index")
+	}
+}
@@ -0,0 +1,47 @@
+package sqlite
+
+// ScrubContent empties content of every note, including trashed ones, and removes all revisions. It returns
+// the number of notes which had content. Everything is changed in a single transaction, so either all notes
+// are scrubbed or none
+func (s *Storage) ScrubContent() (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// empty content is stored as is, so its length isn't kept and its hash is the hash of nothing
+	res, err := tx.Exec(`UPDATE notes SET content = '', uncompressed_length = NULL, content_hash = ?,
+		last_edited_at = `+currentTime+`
+		WHERE content IS NOT NULL AND content != ''`, contentHash(""))
+	if err != nil {
+		return 0, err
+	}
+
+	scrubbed, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// scrubbed content was kept as a revision, so revisions go last
+	if _, err = tx.Exec(`DELETE FROM note_revisions`); err != nil {
+		return 0, err
+	}
+
+	return int(scrubbed), tx.Commit()
+}
+
+// ScrubTitles replaces the title of every note, including trashed ones, with a placeholder naming its ID,
+// e.g. "Note 12", as titles can't be empty. It returns the number of notes whose title was replaced
+func (s *Storage) ScrubTitles() (int, error) {
+	res, err := s.db.Exec(`UPDATE notes SET title = 'Note ' || note_id, last_edited_at = ` + currentTime + `
+		WHERE title != 'Note ' || note_id`)
+	if err != nil {
+		return 0, err
+	}
+
+	scrubbed, err := res.RowsAffected()
+
+	return int(scrubbed), err
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScrubContent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Diary", "private thoughts")
+	_, _ = storage.NewNote("Log", strings.Repeat("compressed ", 200))
+	trashed, _ := storage.NewNote("Trashed", "private too")
+	_ = storage.TrashNote(trashed)
+
+	scrubbed, err := storage.ScrubContent()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if scrubbed != 3 {
+		t.Errorf("Expected 3 scrubbed notes, got %d", scrubbed)
+	}
+
+	var titles []string
+	rows, _ := storage.db.Query(`SELECT title, content, uncompressed_length IS NULL, content_hash FROM notes ORDER BY note_id`)
+	for rows.Next() {
+		var (
+			title, content, hash string
+			plain                bool
+		)
+		_ = rows.Scan(&title, &content, &plain, &hash)
+		if content != "" || !plain || hash != contentHash("") {
+			t.Errorf("Expected empty content of %q, got %q (stored as is: %v, hash %s)", title, content, plain, hash)
+		}
+
+		titles = append(titles, title)
+	}
+	_ = rows.Close()
+
+	if strings.Join(titles, ",") != "Diary,Log,Trashed" {
+		t.Errorf("Expected titles to remain, got %v", titles)
+	}
+
+	// notes without content are not counted again
+	if scrubbed, _ = storage.ScrubContent(); scrubbed != 0 {
+		t.Errorf("Expected nothing to scrub, got %d", scrubbed)
+	}
+}
+
+func TestScrubTitles(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	diary, _ := storage.NewNote("Diary", "kept content")
+	log, _ := storage.NewNote("Log", "kept too")
+
+	scrubbed, err := storage.ScrubTitles()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if scrubbed != 2 {
+		t.Errorf("Expected 2 scrubbed titles, got %d", scrubbed)
+	}
+
+	for id, content := range map[int]string{diary: "kept content", log: "kept too"} {
+		note, _ := storage.GetNoteByID(id)
+		if note.Title != fmt.Sprintf("Note %d", id) || note.Content != content {
+			t.Errorf("Expected placeholder title and content %q, got %+v", content, note)
+		}
+	}
+}
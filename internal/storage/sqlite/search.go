@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// SearchNotesByTitle searches for notes containing the specified keyword in titles only, comparing text
+// the same way SearchNotesByKeyword does. Matching titles are found by scanning the notes_title_lower index,
+// the same way searchQuery does, without reading content
+func (s *Storage) SearchNotesByTitle(keyword string) ([]entities.Note, error) {
+	err := validateSQLParam(keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	keyword = norm.NFC.String(keyword)
+	composedPattern, decomposedPattern := "%"+keyword+"%", "%"+norm.NFD.String(keyword)+"%"
+
+	// the subquery reads only the covering index, trashed notes are left out by the outer query
+	rows, err := s.db.Query(`SELECT `+noteColumns+` FROM `+s.notes()+` WHERE note_id IN
+		(SELECT note_id FROM notes INDEXED BY notes_title_lower WHERE lower(title) LIKE ?1 OR lower(title) LIKE ?2)
+		ORDER BY note_id`,
+		composedPattern, decomposedPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep only notes actually containing the keyword, as searchNotes does
+	var matching []entities.Note
+	for _, note := range found {
+		if containsFold(norm.NFC.String(note.Title), keyword) {
+			matching = append(matching, note)
+		}
+	}
+
+	return matching, nil
+}
+
+// SearchRecentNotes searches for notes created or edited at or after since in the same way as
+// SearchNotesByKeyword, SearchNotesIncludingTags or SearchNotesByTitle do, depending on the scope
+func (s *Storage) SearchRecentNotes(keyword string, scope storage.SearchScope, since time.Time) ([]entities.Note, error) {
+	recent := *s
+	recent.changedSince = since
+
+	switch scope {
+	case storage.ScopeText:
+		return recent.SearchNotesByKeyword(keyword)
+	case storage.ScopeTags:
+		return recent.SearchNotesIncludingTags(keyword)
+	case storage.ScopeTitle:
+		return recent.SearchNotesByTitle(keyword)
+	default:
+		return nil, fmt.Errorf("unknown search scope: %s", scope)
+	}
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+
+	"go-notes/internal/entities"
+)
+
+// previousSearchQuery is the search query before titles were matched through the notes_title_lower index,
+// kept to compare results and speed of both queries
+const previousSearchQuery = `SELECT ` + noteColumns + ` FROM notes
+	WHERE title LIKE ?1 OR content LIKE ?1 OR title LIKE ?2 OR content LIKE ?2 OR uncompressed_length IS NOT NULL`
+
+// searchWithQuery searches notes for the keyword with the query, filtering candidates the same way streamNotes does
+func searchWithQuery(s *Storage, query, keyword string) ([]entities.Note, error) {
+	keyword = norm.NFC.String(keyword)
+	rows, err := s.db.Query(query, "%"+keyword+"%", "%"+norm.NFD.String(keyword)+"%")
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []entities.Note
+	for _, note := range candidates {
+		if containsFold(norm.NFC.String(note.Title), keyword) || containsFold(norm.NFC.String(note.Content), keyword) {
+			matching = append(matching, note)
+		}
+	}
+
+	return matching, nil
+}
+
+// benchmarkSearch runs the search query for a title matching a single note of a database of 10k notes
+func benchmarkSearch(b *testing.B, query string) {
+	dbPath := filepath.Join(b.TempDir(), "bench.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	notes := make([]entities.Note, 10000)
+	for i := range notes {
+		notes[i] = entities.Note{Title: fmt.Sprintf("Note number %d", i), Content: strings.Repeat("lorem ipsum dolor sit amet ", 20)}
+	}
+	_, _ = storage.NewNotes(notes)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		found, err := searchWithQuery(storage, query, "Number 4242")
+		if err != nil || len(found) != 1 {
+			b.Fatalf("Expected a single note, got %d (%v)", len(found), err)
+		}
+	}
+}
+
+func BenchmarkSearchBefore(b *testing.B) {
+	benchmarkSearch(b, previousSearchQuery)
+}
+
+func BenchmarkSearchAfter(b *testing.B) {
+	benchmarkSearch(b, searchQuery(`notes`, false))
+}
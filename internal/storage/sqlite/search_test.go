@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestSearchNotesByTitle(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Café plans", "Coffee")
+	_, _ = storage.NewNote("Shopping", "Buy café beans")
+	_, _ = storage.NewNote("Morning café", "Prices")
+	trashed, _ := storage.NewNote("Old café", "Closed")
+	_ = storage.TrashNote(trashed)
+
+	// the keyword is decomposed, titles are stored composed
+	byTitle, err := storage.SearchNotesByTitle("café")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// results are the notes full search finds by their titles
+	all, _ := storage.SearchNotesByKeyword("café")
+	var expected []int
+	for _, note := range all {
+		if containsFold(note.Title, "café") {
+			expected = append(expected, note.ID)
+		}
+	}
+
+	if len(byTitle) != len(expected) {
+		t.Fatalf("Expected notes %v, got %+v", expected, byTitle)
+	}
+	for i, note := range byTitle {
+		if note.ID != expected[i] {
+			t.Errorf("Expected notes %v, got %+v", expected, byTitle)
+		}
+	}
+	if len(all) != 3 || len(byTitle) != 2 {
+		t.Errorf("Expected 3 notes by keyword and 2 by title, got %d and %d", len(all), len(byTitle))
+	}
+}
+
+func TestSearchRecentNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	old, _ := storage.CreateNote(entities.Note{Title: "Old milk", Content: "Expired", CreatedAt: monthAgo}, []string{"dairy"})
+	recent, _ := storage.NewNote("New milk", "Fresh")
+	// an old note edited recently is recent too
+	edited, _ := storage.CreateNote(entities.Note{Title: "Butter", Content: "Salted", CreatedAt: monthAgo}, []string{"dairy"})
+	_ = storage.SetNoteContent(edited, "Unsalted milk")
+
+	weekAgo := time.Now().AddDate(0, 0, -7)
+	tests := []struct {
+		scope    notesstorage.SearchScope
+		keyword  string
+		expected []int
+	}{
+		{scope: notesstorage.ScopeText, keyword: "milk", expected: []int{recent, edited}},
+		{scope: notesstorage.ScopeTitle, keyword: "milk", expected: []int{recent}},
+		{scope: notesstorage.ScopeTags, keyword: "dairy", expected: []int{edited}},
+	}
+	for _, tt := range tests {
+		notes, err := storage.SearchRecentNotes(tt.keyword, tt.scope, weekAgo)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var ids []int
+		for _, note := range notes {
+			ids = append(ids, note.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.expected) {
+			t.Errorf("Expected notes %v in scope %s, got %v", tt.expected, tt.scope, ids)
+		}
+	}
+
+	// a wide enough window includes the old note again
+	notes, _ := storage.SearchRecentNotes("milk", notesstorage.ScopeTitle, monthAgo.AddDate(0, 0, -1))
+	if len(notes) != 2 || notes[0].ID != old {
+		t.Errorf("Expected the old note within a wide window, got %+v", notes)
+	}
+}
+
+func TestSearchNotesStream(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("Milk", "Whole")
+	_, _ = storage.NewNote("Bread", "Rye")
+	_, _ = storage.NewNote("More milk", "Skimmed")
+
+	// every match is passed to the callback
+	var ids []int
+	err := storage.SearchNotesStream("milk", func(note entities.Note) error {
+		ids = append(ids, note.ID)
+		return nil
+	})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("Expected 2 matches, got %v (%v)", ids, err)
+	}
+
+	// an error of the callback stops the search and is returned as is
+	errStop := errors.New("stop")
+	calls := 0
+	err = storage.SearchNotesStream("milk", func(note entities.Note) error {
+		calls++
+		if note.ID != first {
+			t.Errorf("Expected note %d first, got %d", first, note.ID)
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected the callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the search to stop after the first match, got %d calls", calls)
+	}
+}
+
+func TestSearchQueryResultsUnchanged(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithCompression(true))
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Café plans", "Coffee")
+	_, _ = storage.NewNote("SHOPPING list", "Buy café beans")
+	_, _ = storage.NewNote("Morning", "prices of CAFÉ au lait")
+	_, _ = storage.NewNote("100% done", "50_50 split")
+	_, _ = storage.NewNote("Long", strings.Repeat("compressed café ", 100))
+	_, _ = storage.NewNote("Unrelated", "Nothing here")
+
+	for _, keyword := range []string{"café", "CAFE", "café", "shopping", "%", "_", "compressed", "missing"} {
+		before, err := searchWithQuery(storage, previousSearchQuery, keyword)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		after, err := storage.SearchNotesByKeyword(keyword)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !reflect.DeepEqual(after, before) {
+			t.Errorf("Expected the same notes for %q, got %+v before and %+v after", keyword, before, after)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// MarkSeen stores the time notes were last viewed at, notes created or edited later are unseen.
+// The time is kept with milliseconds like creation and edit times, so notes saved later within the same second are unseen
+func (s *Storage) MarkSeen(at time.Time) error {
+	return s.SetSetting(settingLastViewed, at.UTC().Format(preciseTimeLayout))
+}
+
+// GetUnseenNotes retrieves notes created or edited after the time stored by MarkSeen ordered from the least recently changed.
+// All notes are unseen until MarkSeen is called for the first time
+func (s *Storage) GetUnseenNotes() ([]entities.Note, error) {
+	lastViewed, _, err := getSetting(s.db, settingLastViewed)
+	if err != nil {
+		return nil, err
+	}
+
+	// times are stored in UTC in CURRENT_TIMESTAMP format, with or without milliseconds, so they compare as strings;
+	// an empty marker precedes all
+	rows, err := s.db.Query(`SELECT `+noteColumns+` FROM `+s.notes()+`
+		WHERE created_at > ?1 OR last_edited_at > ?1 ORDER BY max(created_at, last_edited_at), note_id`, lastViewed)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+func TestGetUnseenNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	hourAgo := time.Now().Add(-time.Hour)
+	_, _ = storage.CreateNote(entities.Note{Title: "Old", Content: "Seen before", CreatedAt: hourAgo}, nil)
+
+	// without a marker every note is unseen
+	notes, err := storage.GetUnseenNotes()
+	if err != nil || len(notes) != 1 {
+		t.Fatalf("Expected the old note to be unseen, got %+v (%v)", notes, err)
+	}
+
+	if err = storage.MarkSeen(time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	id, _ := storage.NewNote("New", "Created after the marker")
+
+	notes, _ = storage.GetUnseenNotes()
+	if len(notes) != 1 || notes[0].ID != id {
+		t.Errorf("Expected only the new note to be unseen, got %+v", notes)
+	}
+
+	// everything is seen after marking again
+	_ = storage.MarkSeen(time.Now().Add(time.Second))
+	if notes, _ = storage.GetUnseenNotes(); len(notes) != 0 {
+		t.Errorf("Expected no unseen notes, got %+v", notes)
+	}
+}
+
+func TestGetUnseenNotesSameSecond(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	edited, _ := storage.NewNote("Edited", "Before the marker")
+
+	// notes saved right after marking, within the same second, are unseen
+	_ = storage.MarkSeen(time.Now())
+	time.Sleep(5 * time.Millisecond)
+	created, _ := storage.NewNote("Created", "After the marker")
+	_ = storage.SetNoteContent(edited, "After the marker")
+
+	notes, err := storage.GetUnseenNotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 2 || notes[0].ID != created || notes[1].ID != edited {
+		t.Errorf("Expected notes saved after the marker to be unseen, got %+v", notes)
+	}
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// keys of settings stored in the settings table
+const (
+	// settingCompress enables compression of newly written content when set to "true"
+	settingCompress = "compress"
+
+	// settingLastViewed holds the time notes were last marked as seen at, see MarkSeen
+	settingLastViewed = "last_viewed_at"
+
+	// settingLastBackup holds the time of the last successful backup, see Backup
+	settingLastBackup = "last_backup_at"
+)
+
+// GetSetting retrieves the value of a setting, ok is false if the setting is not set
+func (s *Storage) GetSetting(key string) (value string, ok bool, err error) {
+	err = validateSQLParam(key)
+	if err != nil {
+		return "", false, err
+	}
+
+	return getSetting(s.db, key)
+}
+
+// SetSetting stores the value of a setting, replacing the previous one
+func (s *Storage) SetSetting(key, value string) error {
+	err := validateSQLParam(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO settings (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, value)
+
+	return err
+}
+
+// getSetting retrieves the value of a setting, ok is false if the setting is not set
+func getSetting(db queryExecer, key string) (value string, ok bool, err error) {
+	err = db.QueryRow(`SELECT value FROM settings WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
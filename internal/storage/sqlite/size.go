@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// GetLongestNotes retrieves up to n notes with the longest content in bytes, longest first
+func (s *Storage) GetLongestNotes(n int) ([]entities.Note, error) {
+	return s.getNotesByContentLength(n, "DESC")
+}
+
+// GetShortestNotes retrieves up to n notes with the shortest content in bytes, shortest first.
+// Notes without content are treated as having zero length
+func (s *Storage) GetShortestNotes(n int) ([]entities.Note, error) {
+	return s.getNotesByContentLength(n, "ASC")
+}
+
+// getNotesByContentLength retrieves up to n notes ordered by content length in the given direction
+func (s *Storage) getNotesByContentLength(n int, direction string) ([]entities.Note, error) {
+	err := validateSQLParam(n)
+	if err != nil {
+		return nil, err
+	}
+
+	// lengths are counted in bytes: compressed content has its length stored, other content is cast to a blob;
+	// NULL content has NULL length, so it is coalesced to zero; ties are broken by ID for stable output
+	query := `SELECT ` + noteColumns + ` FROM ` + s.notes() + `
+		ORDER BY COALESCE(uncompressed_length, LENGTH(CAST(content AS BLOB)), 0) ` + direction + `, note_id
+		LIMIT ?`
+
+	rows, err := s.db.Query(query, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
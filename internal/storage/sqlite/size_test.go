@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetNotesByContentLength(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	medium, _ := storage.NewNote("Medium", "twelve chars")
+	long, _ := storage.NewNote("Long", "this content is clearly the longest one")
+	short, _ := storage.NewNote("Short", "abc")
+
+	// notes with empty and missing content can't be created through NewNote
+	res, _ := storage.db.Exec(`INSERT INTO notes (title, content) VALUES ('Null', NULL)`)
+	nullID, _ := res.LastInsertId()
+
+	longest, err := storage.GetLongestNotes(2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(longest) != 2 || longest[0].ID != long || longest[1].ID != medium {
+		t.Errorf("Expected longest notes [%d %d], got %v", long, medium, longest)
+	}
+
+	shortest, err := storage.GetShortestNotes(3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(shortest) != 3 || shortest[0].ID != int(nullID) || shortest[1].ID != short || shortest[2].ID != medium {
+		t.Errorf("Expected shortest notes [%d %d %d], got %v", nullID, short, medium, shortest)
+	}
+
+	if shortest[0].Content != "" {
+		t.Errorf("Expected missing content to be read as empty, got %q", shortest[0].Content)
+	}
+}
+
+func TestGetNotesByContentLengthInBytes(t *testing.T) {
+	storage, err := New(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	// eight characters taking sixteen bytes are longer than twelve ASCII characters
+	ascii, _ := storage.NewNote("ASCII", "twelve chars")
+	accents, _ := storage.NewNote("Accents", "éééééééé")
+
+	longest, err := storage.GetLongestNotes(2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(longest) != 2 || longest[0].ID != accents || longest[1].ID != ascii {
+		t.Errorf("Expected longest notes [%d %d], got %v", accents, ascii, longest)
+	}
+}
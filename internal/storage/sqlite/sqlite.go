@@ -3,33 +3,198 @@ package sqlite
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"math"
+	"os"
+	"strings"
+	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
+	"golang.org/x/text/unicode/norm"
 
 	"go-notes/internal/entities"
+	"go-notes/internal/storage"
 )
 
 type (
 	Storage struct {
-		// db holds the database connection.
-		db *sql.DB
+		// db holds the database connection, adding the table prefix to queries.
+		db *prefixedDB
+
+		// maxContentLength is the maximum allowed length of note content in bytes.
+		maxContentLength int
+
+		// pinLimit is the maximum number of pinned notes, 0 means unlimited.
+		pinLimit int
+
+		// compress enables gzip compression of newly written content.
+		compress bool
+
+		// journalMode is the journal mode of every connection, empty for the SQLite default.
+		journalMode string
+
+		// includeTrashed makes read methods return notes in the trash too, see WithTrashed.
+		includeTrashed bool
+
+		// includeExpired makes read methods return notes which have expired too, see SetIncludeExpired.
+		includeExpired bool
+
+		// changedSince limits read methods to notes created or edited at or after it, zero time doesn't limit them.
+		changedSince time.Time
+
+		// noEditTrigger replaces the trigger bumping last edit time on every update by setting it
+		// explicitly on edits of title and content only.
+		noEditTrigger bool
+
+		// stripControlChars removes control characters from content instead of rejecting it, see WithStripControlChars.
+		stripControlChars bool
+
+		// tablePrefix is prepended to names of all tables, see WithTablePrefix.
+		tablePrefix string
+
+		// fileLock makes New take the lock of the database, waiting for it up to lockTimeout, see WithFileLock.
+		fileLock    bool
+		lockTimeout time.Duration
+
+		// lock is the file holding the lock of the database, nil if it's not locked.
+		lock *os.File
+
+		// path is the path of the database file, profiles are kept next to it.
+		path string
 	}
+
+	// Option configures a Storage created by New.
+	Option func(*Storage)
 )
 
+// maxStringLength is the maximum allowed length of string params in bytes,
+// it is also the default maximum length of note content
+const maxStringLength = 256000
+
+// errors of rejected params, they match storage.ErrInvalidParam
 var (
-	invalidNum         = errors.New("invalid number")
-	invalidParamLength = errors.New("invalid param length")
+	invalidNum         = fmt.Errorf("%w: invalid number", storage.ErrInvalidParam)
+	invalidParamLength = fmt.Errorf("%w: invalid param length", storage.ErrInvalidParam)
 )
 
+// WithMaxContentLength sets the maximum allowed length of note content in bytes
+func WithMaxContentLength(n int) Option {
+	return func(s *Storage) {
+		s.maxContentLength = n
+	}
+}
+
+// WithPinLimit sets the maximum number of pinned notes, 0 means unlimited
+func WithPinLimit(n int) Option {
+	return func(s *Storage) {
+		s.pinLimit = n
+	}
+}
+
+// WithEditTrigger enables or disables the trigger updating last edit time of a note on every update.
+// Without it, last edit time changes only when title or content is edited, not e.g. when a note is pinned
+func WithEditTrigger(enabled bool) Option {
+	return func(s *Storage) {
+		s.noEditTrigger = !enabled
+	}
+}
+
+// WithStripControlChars removes control characters other than tabs and line breaks, e.g. NUL bytes or terminal
+// escapes, from content written to the storage. By default such content is rejected with storage.ErrInvalidContent
+func WithStripControlChars(enabled bool) Option {
+	return func(s *Storage) {
+		s.stripControlChars = enabled
+	}
+}
+
+// lastEditedTrigger creates a trigger updating last edit time of a note on every update
+const lastEditedTrigger = `
+	CREATE TRIGGER IF NOT EXISTS update_last_edited_at
+	AFTER UPDATE ON notes
+	FOR EACH ROW
+	BEGIN
+		UPDATE notes
+		SET last_edited_at = ` + currentTime + `
+		WHERE note_id = OLD.note_id;
+	END;
+`
+
+// dropOutdatedEditTrigger drops the edit trigger if it was created by an earlier version,
+// which stored edit times without milliseconds, so it's created again with currentTime
+func dropOutdatedEditTrigger(db *prefixedDB) error {
+	var definition string
+	err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = 'update_last_edited_at'`).
+		Scan(&definition)
+	if errors.Is(err, sql.ErrNoRows) || strings.Contains(definition, currentTime) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`DROP TRIGGER update_last_edited_at`)
+
+	return err
+}
+
+// editTriggerStatement returns the statement setting up the edit trigger: creating it, or dropping it if it's disabled
+func (s *Storage) editTriggerStatement() string {
+	if s.noEditTrigger {
+		return `DROP TRIGGER IF EXISTS update_last_edited_at`
+	}
+
+	return lastEditedTrigger
+}
+
 // New creates a new Storage instance and establishes a connection to the SQLite database
-func New(storagePath string) (*Storage, error) {
+func New(storagePath string, opts ...Option) (*Storage, error) {
+	// options configuring the connection and the schema are needed before they are set up
+	conf := &Storage{}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	dsn, err := conf.dataSourceName(storagePath)
+	if err != nil {
+		return nil, err
+	}
+	// the prefix becomes part of identifiers in SQL, so it's validated before any query
+	if err = validateTablePrefix(conf.tablePrefix); err != nil {
+		return nil, err
+	}
+
+	// the lock is taken before anything is written and released again if opening fails
+	var lock *os.File
+	if conf.fileLock {
+		if lock, err = acquireLock(storagePath, conf.lockTimeout); err != nil {
+			return nil, err
+		}
+	}
+	opened := false
+	defer func() {
+		if lock != nil && !opened {
+			_ = lock.Close()
+		}
+	}()
+
 	// opening connection to sqlite db
-	db, err := sql.Open("sqlite3", storagePath)
+	conn, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		// return error if connection fails
 		return nil, err
 	}
+	db := &prefixedDB{DB: conn, prefix: conf.tablePrefix}
+
+	// detect a damaged file before touching the schema, so it fails with a clear error instead of deep in a query
+	err = checkIntegrity(conn)
+	if errors.Is(err, storage.ErrCorrupt) {
+		_ = db.Close()
+		return nil, fmt.Errorf("%s: %w; restore it from a backup or salvage notes with "+
+			"`sqlite3 %s .recover | sqlite3 recovered.db`", storagePath, err, storagePath)
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
 
 	// prepare statement to create a table
 	createTable, err := db.Prepare(`
@@ -54,17 +219,14 @@ func New(storagePath string) (*Storage, error) {
 		return nil, err
 	}
 
-	// preparing statement to create a trigger for updating last edit of note
-	onUpdateTrigger, err := db.Prepare(`
-		CREATE TRIGGER IF NOT EXISTS update_last_edited_at
-		AFTER UPDATE ON notes
-		FOR EACH ROW
-		BEGIN
-    		UPDATE notes
-    		SET last_edited_at = CURRENT_TIMESTAMP
-    		WHERE note_id = OLD.note_id;
-		END;
-`)
+	// a trigger storing edit times without milliseconds is replaced by the one below
+	if err = dropOutdatedEditTrigger(db); err != nil {
+		return nil, err
+	}
+
+	// preparing statement to create a trigger for updating last edit of note, or to drop it if it's disabled
+	triggerStatement := conf.editTriggerStatement()
+	onUpdateTrigger, err := db.Prepare(triggerStatement)
 	if err != nil {
 		// return error if preparing fails
 		return nil, err
@@ -79,25 +241,110 @@ func New(storagePath string) (*Storage, error) {
 		return nil, err
 	}
 
-	// returning new storage with established db connect
-	return &Storage{db: db}, nil
+	// applying schema migrations on top of notes table
+	err = migrate(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// hashing content of notes stored before content_hash was added
+	err = backfillContentHashes(db, triggerStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	// reading compression setting stored by SetCompression
+	compress, _, err := getSetting(db, settingCompress)
+	if err != nil {
+		return nil, err
+	}
+
+	// creating storage with established db connect and applying options over defaults
+	s := &Storage{db: db, maxContentLength: maxStringLength, compress: compress == "true", lock: lock, path: storagePath}
+	for _, opt := range opts {
+		opt(s)
+	}
+	opened = true
+
+	// returning new storage
+	return s, nil
+}
+
+// checkIntegrity runs a quick integrity check of the database, an empty or new file passes it.
+// Damage is reported as storage.ErrCorrupt, other failures (e.g. a locked database) are returned as is
+func checkIntegrity(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA quick_check`)
+	if err != nil {
+		return corruptionError(err)
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	// a healthy database reports a single "ok" row, otherwise each row describes a problem
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err = rows.Scan(&result); err != nil {
+			return corruptionError(err)
+		}
+
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return corruptionError(err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", storage.ErrCorrupt, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// corruptionError wraps errors sqlite reports for damaged files or files which are not databases with storage.ErrCorrupt
+func corruptionError(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB) {
+		return fmt.Errorf("%w: %v", storage.ErrCorrupt, err)
+	}
+
+	return err
 }
 
-// Close closes the database connection associated with the Storage instance
+// Close closes the database connection associated with the Storage instance and releases its lock, if any
 func (s *Storage) Close() error {
 	err := s.db.Close()
 
+	// releasing the lock after the connection is closed, so the next process finds the database settled
+	if s.lock != nil {
+		err = errors.Join(err, s.lock.Close())
+	}
+
 	return err
 }
 
-// NewNote creates a new note with the given title and content and returns its ID
+// NewNote creates a new note with the given title and content and returns its ID.
+// The title is stored normalized to NFC, so composed and decomposed forms of characters match each other
 func (s *Storage) NewNote(noteTitle, content string) (int, error) {
-	err := validateSQLParam(noteTitle, content)
+	noteTitle = normalizeTitle(noteTitle)
+	err := validateSQLParam(noteTitle)
+	if err != nil {
+		return 0, err
+	}
+	content, err = s.validateContent(content)
+	if err != nil {
+		return 0, err
+	}
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(content)
 	if err != nil {
 		return 0, err
 	}
 	// preparing statement for creating new note with title and content
-	newNote, err := s.db.Prepare("INSERT INTO notes (title, content) VALUES (?, ?)")
+	newNote, err := s.db.Prepare(`INSERT INTO notes (title, content, uncompressed_length, content_hash, created_at, last_edited_at)
+		VALUES (?, ?, ?, ?, ` + currentTime + `, ` + currentTime + `)`)
 	if err != nil {
 		// return error if preparing fails
 		return 0, err
@@ -106,7 +353,7 @@ func (s *Storage) NewNote(noteTitle, content string) (int, error) {
 	defer newNote.Close()
 
 	// creating new note execution with title and content
-	res, err := newNote.Exec(noteTitle, content)
+	res, err := newNote.Exec(noteTitle, storedContent, uncompressedLength, contentHash(content))
 	if err != nil {
 		// return err if execution fails
 		return 0, err
@@ -156,20 +403,29 @@ func (s *Storage) DeleteNote(id int) (int, error) {
 
 // SetNoteContent updates the content of a note with the specified ID
 func (s *Storage) SetNoteContent(noteID int, content string) error {
-	err := validateSQLParam(noteID, content)
+	return s.setNoteContent(s.db, noteID, content)
+}
+
+// setNoteContent updates content of the note with db, which may be a transaction
+func (s *Storage) setNoteContent(db queryExecer, noteID int, content string) error {
+	err := validateSQLParam(noteID)
 	if err != nil {
 		return err
 	}
-	// preparing statement for setting note content by id
-	setNoteContent, err := s.db.Prepare("UPDATE notes SET content = ? WHERE note_id = ?")
+	content, err = s.validateContent(content)
+	if err != nil {
+		return err
+	}
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(content)
 	if err != nil {
 		return err
 	}
-	// ensure statement are closed when done processing
-	defer setNoteContent.Close()
 
-	// execute setting note content
-	res, err := setNoteContent.Exec(content, noteID)
+	// execute setting note content, unchanged content is left as is, so its last edit time is kept
+	hash := contentHash(content)
+	res, err := db.Exec(`UPDATE notes SET content = ?, uncompressed_length = ?, content_hash = ?, last_edited_at = `+currentTime+`
+		WHERE note_id = ? AND content_hash IS NOT ?`, storedContent, uncompressedLength, hash, noteID, hash)
 	if err != nil {
 		return err
 	}
@@ -180,9 +436,55 @@ func (s *Storage) SetNoteContent(noteID int, content string) error {
 		return err
 	}
 
-	// if no rows were affected - return an error
+	// if no rows were affected - the note is either missing or its content is unchanged
 	if rowsAffected == 0 {
-		return sql.ErrNoRows
+		var id int
+		return db.QueryRow(`SELECT note_id FROM notes WHERE note_id = ?`, noteID).Scan(&id)
+	}
+
+	return nil
+}
+
+// SetNoteContentIfUnchanged sets content of the note only if it wasn't edited since expectedLastEdited,
+// the last edit time of the note as seen by the caller. Otherwise it returns storage.ErrConflict.
+// Edit times are compared with milliseconds, so edits within the same second are told apart
+func (s *Storage) SetNoteContentIfUnchanged(noteID int, expectedLastEdited time.Time, content string) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+	content, err = s.validateContent(content)
+	if err != nil {
+		return err
+	}
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(content)
+	if err != nil {
+		return err
+	}
+
+	// the check and the update are a single statement, so no other write can happen in between;
+	// strftime() makes timestamps stored in other formats, e.g. without milliseconds, compare by value
+	res, err := s.db.Exec(`UPDATE notes SET content = ?, uncompressed_length = ?, content_hash = ?, last_edited_at = `+currentTime+`
+		WHERE note_id = ? AND strftime('%Y-%m-%d %H:%M:%f', last_edited_at) = ?`,
+		storedContent, uncompressedLength, contentHash(content), noteID, expectedLastEdited.UTC().Format(preciseTimeLayout))
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - the note is either missing or was edited in the meantime
+	if rowsAffected == 0 {
+		if err = s.noteExists(noteID); err != nil {
+			return err
+		}
+
+		return storage.ErrConflict
 	}
 
 	return nil
@@ -194,41 +496,131 @@ func (s *Storage) SearchNotesByKeyword(keyword string) ([]entities.Note, error)
 	if err != nil {
 		return nil, err
 	}
-	// SQL query to search for notes containing the keyword in titles or content
-	query := "SELECT * FROM notes WHERE title LIKE ? OR content LIKE ?"
+	// search notes and return them with any error that occurred
+	return searchNotes(s.db, s.notes(), keyword, false)
+}
 
-	// create a wildcard pattern for keyword (e.g., "%keyword%") to match partial strings
-	keywordPattern := "%" + keyword + "%"
+// SearchNotesStream searches for notes containing the keyword in titles or content like SearchNotesByKeyword does,
+// but calls fn with every matching note as soon as it's read instead of collecting them.
+// Searching stops at the first error returned by fn, which is returned as is
+func (s *Storage) SearchNotesStream(keyword string, fn func(note entities.Note) error) error {
+	err := validateSQLParam(keyword)
+	if err != nil {
+		return err
+	}
 
-	// execute the query with the keyword pattern twice (for title and content) and retrieve the result rows
-	rows, err := s.db.Query(query, keywordPattern, keywordPattern)
+	return streamNotes(s.db, s.notes(), keyword, false, fn)
+}
+
+// searchNotes searches for notes containing the keyword in titles or content using db or a transaction.
+// Notes are selected from notes, the source returned by Storage.notes. Text is compared in NFC,
+// so composed and decomposed forms of characters match each other.
+// With includeTags notes having a tag containing the keyword match as well
+func searchNotes(db querier, notes, keyword string, includeTags bool) ([]entities.Note, error) {
+	var matching []entities.Note
+	err := streamNotes(db, notes, keyword, includeTags, func(note entities.Note) error {
+		matching = append(matching, note)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// ensure rows are closed when done processing
-	defer rows.Close()
+	// return the list of matching notes
+	return matching, nil
+}
 
-	// create a slice to store matching notes
-	var notes []entities.Note
+// streamNotes searches for notes as searchNotes does and calls fn with every matching note while reading rows,
+// it stops at the first error returned by fn
+func streamNotes(db querier, notes, keyword string, includeTags bool, fn func(note entities.Note) error) error {
+	// create wildcard patterns for keyword (e.g., "%keyword%") to match partial strings
+	keyword = norm.NFC.String(keyword)
+	composedPattern, decomposedPattern := "%"+keyword+"%", "%"+norm.NFD.String(keyword)+"%"
+
+	// find notes matching by tag first, so they are kept regardless of their text
+	var tagged map[int]bool
+	if includeTags {
+		var err error
+		tagged, err = notesTaggedLike(db, composedPattern)
+		if err != nil {
+			return err
+		}
+	}
 
-	// iterate through result rows
-	for rows.Next() {
-		// declare a variable to store a single note
-		var note entities.Note
+	// execute the query with both patterns and retrieve the result rows
+	rows, err := db.Query(searchQuery(notes, includeTags), composedPattern, decomposedPattern)
+	if err != nil {
+		return err
+	}
+	// ensure rows are closed when done processing, including when fn stops the search
+	defer rows.Close()
 
-		// scan the values from the row into 'note' struct
-		err = rows.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.LastEditedAt)
+	// pass only notes actually containing the keyword, each note is selected once even if it matches several ways
+	for rows.Next() {
+		note, err := scanNote(rows)
 		if err != nil {
-			return []entities.Note{}, err
+			return err
 		}
 
-		// append the retrieved note to 'notes' slice
-		notes = append(notes, note)
+		if tagged[note.ID] || containsFold(norm.NFC.String(note.Title), keyword) ||
+			containsFold(norm.NFC.String(note.Content), keyword) {
+			if err = fn(note); err != nil {
+				return err
+			}
+		}
 	}
 
-	// return the list of matching notes and any error that occurred
-	return notes, nil
+	return rows.Err()
+}
+
+// searchQuery returns the query selecting candidates of notes containing the keyword in titles or content
+// in either normalization form, given as LIKE patterns ?1 and ?2, from notes, the source returned by Storage.notes.
+// Titles are matched by scanning the notes_title_lower index of lowercased titles, which is much smaller
+// than the table holding content, and LIKE ignores ASCII case the same way lower() does.
+// The decomposed pattern is tried only if it differs, compressed content can't be matched in SQL,
+// so such notes are filtered after decompression
+func searchQuery(notes string, includeTags bool) string {
+	query := `SELECT ` + noteColumns + ` FROM ` + notes + `
+		WHERE note_id IN (SELECT note_id FROM notes INDEXED BY notes_title_lower
+			WHERE lower(title) LIKE ?1 OR (?2 != ?1 AND lower(title) LIKE ?2))
+		OR content LIKE ?1 OR (?2 != ?1 AND content LIKE ?2) OR uncompressed_length IS NOT NULL`
+	if includeTags {
+		query += ` OR note_id IN (SELECT note_id FROM note_tags WHERE tag LIKE ?1)`
+	}
+
+	return query
+}
+
+// notesTaggedLike returns IDs of notes having a tag matching the LIKE pattern
+func notesTaggedLike(db querier, pattern string) (map[int]bool, error) {
+	rows, err := db.Query(`SELECT DISTINCT note_id FROM note_tags WHERE tag LIKE ?`, pattern)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	tagged := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		tagged[id] = true
+	}
+
+	return tagged, rows.Err()
+}
+
+// normalizeTitle converts a title to NFC, the form titles are stored in
+func normalizeTitle(title string) string {
+	return norm.NFC.String(title)
+}
+
+// containsFold reports whether s contains substr ignoring ASCII case, the same way LIKE does
+func containsFold(s, substr string) bool {
+	return strings.Contains(storage.FoldCase(s), storage.FoldCase(substr))
 }
 
 // GetNoteByID retrieves a note by its ID and returns it as an entities.Note
@@ -238,56 +630,27 @@ func (s *Storage) GetNoteByID(noteID int) (entities.Note, error) {
 		return entities.Note{}, err
 	}
 	// SQL query to select a note by its ID
-	getNoteQuery := `SELECT * FROM notes WHERE note_id = ?`
-
-	// declare a variable to store the retrieved note
-	var note entities.Note
-
-	// execute the query and scan the result into the 'note' struct
-	err = s.db.QueryRow(getNoteQuery, noteID).Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.LastEditedAt)
+	getNoteQuery := `SELECT ` + noteColumns + ` FROM ` + s.notes() + ` WHERE note_id = ?`
 
-	// return the retrieved note and any error that occurred
-	return note, err
+	// execute the query, scan the result and return it with any error that occurred
+	return scanNote(s.db.QueryRow(getNoteQuery, noteID))
 }
 
 // GetAllNotes retrieves all notes and returns them as a slice of entities.Note
 func (s *Storage) GetAllNotes() ([]entities.Note, error) {
 	// execute an SQL query to retrieve all notes from table
-	rows, err := s.db.Query(`SELECT * FROM notes`)
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes())
 	if err != nil {
 		return nil, err
 	}
-	// ensure rows are closed when done processing
-	defer rows.Close()
-
-	// create a slice to store the retrieved notes
-	var notes []entities.Note
 
-	// iterate through the result rows
-	for rows.Next() {
-		// create a variable to store a single note
-		var note entities.Note
-
-		// scan the values from the row into the 'note' struct
-		err = rows.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.LastEditedAt)
-		if err != nil {
-			return nil, err
-		}
-
-		// append the retrieved note to the 'notes' slice
-		notes = append(notes, note)
-	}
-
-	// return slice of notes
-	return notes, nil
+	// a single damaged note doesn't hide all others
+	return scanNotesSkipping(rows)
 }
 
 // validateSQLParam validates parameters based on their type and value
 // it checks if integers are within a valid range and if strings have a valid length
 func validateSQLParam(params ...interface{}) error {
-	// define a constant for the maximum allowed string length
-	const maxStringLength = 256000
-
 	// iterate over each parameter in variadic 'params' slice
 	for _, param := range params {
 		// use a type switch to check type of the parameter
@@ -312,3 +675,165 @@ func validateSQLParam(params ...interface{}) error {
 	// if all parameters pass validation, return nil (no error)
 	return nil
 }
+
+// validateContent checks that note content is not empty, fits the configured maximum length and has no control
+// characters except tabs and line breaks, and returns the content to store. With WithStripControlChars
+// such characters are removed instead of rejecting the content with storage.ErrInvalidContent
+func (s *Storage) validateContent(content string) (string, error) {
+	// control characters are stripped first, so content left empty is rejected
+	if s.stripControlChars {
+		content = storage.StripControlChars(content)
+	} else if err := storage.CheckControlChars(content); err != nil {
+		return "", err
+	}
+
+	if len(content) < 1 {
+		return "", invalidParamLength
+	}
+
+	if len(content) > s.maxContentLength {
+		return "", &storage.ContentTooLongError{Length: len(content), Max: s.maxContentLength}
+	}
+
+	return content, nil
+}
+
+// notes returns the source read queries select notes from: notes which are neither in the trash nor expired,
+// unless the storage was returned by WithTrashed or told to SetIncludeExpired, selected under the name of the notes table.
+// Every read query must use it, so trashed and expired notes don't leak
+func (s *Storage) notes() string {
+	var conditions []string
+	if !s.includeTrashed {
+		conditions = append(conditions, `deleted_at IS NULL`)
+	}
+	// timestamps are stored in UTC in CURRENT_TIMESTAMP format, so they compare as strings
+	if !s.includeExpired {
+		conditions = append(conditions, `(expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)`)
+	}
+	if !s.changedSince.IsZero() {
+		conditions = append(conditions, `max(created_at, last_edited_at) >= '`+s.changedSince.UTC().Format(timeLayout)+`'`)
+	}
+
+	if len(conditions) == 0 {
+		return `notes`
+	}
+
+	return `(SELECT * FROM notes WHERE ` + strings.Join(conditions, ` AND `) + `) AS notes`
+}
+
+// WithTrashed returns a storage sharing the connection whose read methods return notes in the trash too
+func (s *Storage) WithTrashed() *Storage {
+	trashed := *s
+	trashed.includeTrashed = true
+
+	return &trashed
+}
+
+// noteColumns lists columns scanned by scanNote, missing content is read as an empty string
+const noteColumns = `note_id, title, COALESCE(content, ''), created_at, last_edited_at, pinned_at, due_at,
+	expires_at, deleted_at, uncompressed_length IS NOT NULL`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// querier is implemented by both *prefixedDB and *prefixedTx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanNote reads a single note selected with noteColumns, decompressing its content if needed
+func scanNote(row rowScanner) (entities.Note, error) {
+	var (
+		note       entities.Note
+		compressed bool
+	)
+
+	// timestamps are scanned leniently, so a single broken row doesn't fail listing all notes
+	err := row.Scan(&note.ID, &note.Title, &note.Content, timestamp{&note.CreatedAt}, timestamp{&note.LastEditedAt},
+		optionalTimestamp{&note.PinnedAt}, optionalTimestamp{&note.DueAt},
+		optionalTimestamp{&note.ExpiresAt}, optionalTimestamp{&note.DeletedAt}, &compressed)
+	if err != nil {
+		return note, err
+	}
+
+	note.Content, err = decodeContent(note.Content, compressed)
+
+	return note, err
+}
+
+// scanNotes reads all notes selected with noteColumns from rows and closes them
+func scanNotes(rows *sql.Rows) ([]entities.Note, error) {
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var notes []entities.Note
+	for rows.Next() {
+		note, err := scanNote(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, rows.Err()
+}
+
+// scanNotesSkipping reads all notes selected with noteColumns from rows and closes them like scanNotes does,
+// but notes which can't be read are skipped. They are reported by an error wrapping storage.ErrSkippedNotes
+// and the reason of each skipped note, returned together with the notes which were read
+func scanNotesSkipping(rows *sql.Rows) ([]entities.Note, error) {
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var (
+		notes   []entities.Note
+		skipped []error
+	)
+	for row := 1; rows.Next(); row++ {
+		note, err := scanNote(rows)
+		if err != nil {
+			// ID is scanned first, so it's known unless the row is damaged beyond that
+			if note.ID != 0 {
+				skipped = append(skipped, fmt.Errorf("note %d: %w", note.ID, err))
+			} else {
+				skipped = append(skipped, fmt.Errorf("row %d: %w", row, err))
+			}
+			continue
+		}
+
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(skipped) > 0 {
+		return notes, fmt.Errorf("%w: %w", storage.ErrSkippedNotes, errors.Join(skipped...))
+	}
+
+	return notes, nil
+}
+
+// timeLayout matches the format of CURRENT_TIMESTAMP, so stored timestamps compare correctly as strings
+const timeLayout = "2006-01-02 15:04:05"
+
+// preciseTimeLayout matches the format of currentTime
+const preciseTimeLayout = "2006-01-02 15:04:05.000"
+
+// currentTime is the current time in CURRENT_TIMESTAMP format with milliseconds, creation and edit times of notes
+// are stored with it, so changes within the same second are told apart. A time without milliseconds sorts before
+// the same time with them, so both formats still compare as strings
+const currentTime = `strftime('%Y-%m-%d %H:%M:%f', 'now')`
+
+// dbTime formats time the same way CURRENT_TIMESTAMP does, zero time is stored as NULL
+func dbTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.UTC().Format(timeLayout)
+}
@@ -1,8 +1,16 @@
 package sqlite
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	notesstorage "go-notes/internal/storage"
 )
 
 func TestNewStorage(t *testing.T) {
@@ -102,6 +110,56 @@ func TestSetNoteContent(t *testing.T) {
 	}
 }
 
+func TestSetNoteContentIfUnchanged(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Groceries", "Milk and bread.")
+	seen, _ := storage.GetNoteByID(noteID)
+
+	err := storage.SetNoteContentIfUnchanged(noteID, seen.LastEditedAt, "Milk, bread and eggs.")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.Content != "Milk, bread and eggs." {
+		t.Errorf("Expected updated content, got %q", note.Content)
+	}
+
+	err = storage.SetNoteContentIfUnchanged(noteID+1, seen.LastEditedAt, "Missing note.")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing note, got %v", err)
+	}
+}
+
+func TestSetNoteContentIfUnchangedConflict(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// without the trigger the edit time can be moved to the past, so the concurrent edit gets a different one
+	storage, _ := New(dbPath, WithEditTrigger(false))
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Groceries", "Milk and bread.")
+	_, _ = storage.db.Exec(`UPDATE notes SET last_edited_at = ? WHERE note_id = ?`, oldEditTime, noteID)
+	seen, _ := storage.GetNoteByID(noteID)
+
+	// another client edits the note after it was read
+	_ = storage.SetNoteContent(noteID, "Eggs.")
+
+	err := storage.SetNoteContentIfUnchanged(noteID, seen.LastEditedAt, "Milk, bread and eggs.")
+	if !errors.Is(err, notesstorage.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.Content != "Eggs." {
+		t.Errorf("Expected the concurrent edit to be kept, got %q", note.Content)
+	}
+}
+
 func TestSearchNotesByKeyword(t *testing.T) {
 	dbPath := "test.db"
 	defer func() {
@@ -160,3 +218,92 @@ func TestGetAllNotes(t *testing.T) {
 		t.Errorf("Expected 2 notes, got %d", len(notes))
 	}
 }
+
+func TestGetAllNotesSkipsUnreadable(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	s, _ := New(dbPath)
+	defer s.Close()
+
+	first, _ := s.NewNote("First", "Readable")
+	damaged, _ := s.NewNote("Damaged", "Not compressed")
+	last, _ := s.NewNote("Last", "Readable")
+
+	// marking plain content as compressed makes the note fail to decode
+	_, _ = s.db.Exec(`UPDATE notes SET uncompressed_length = 10 WHERE note_id = ?`, damaged)
+
+	notes, err := s.GetAllNotes()
+	if !errors.Is(err, notesstorage.ErrSkippedNotes) {
+		t.Fatalf("Expected ErrSkippedNotes, got %v", err)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("note %d", damaged)) {
+		t.Errorf("Expected error to name note %d, got %v", damaged, err)
+	}
+	if len(notes) != 2 || notes[0].ID != first || notes[1].ID != last {
+		t.Errorf("Expected readable notes %d and %d, got %+v", first, last, notes)
+	}
+}
+
+func TestMaxContentLength(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithMaxContentLength(10))
+	defer storage.Close()
+
+	// content of exactly the maximum length is accepted
+	noteID, err := storage.NewNote("Test Note", "0123456789")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	// one byte over the limit is rejected with a descriptive error
+	_, err = storage.NewNote("Test Note", "0123456789a")
+	if !errors.Is(err, notesstorage.ErrContentTooLong) {
+		t.Fatalf("Expected ErrContentTooLong, got %v", err)
+	}
+
+	var tooLong *notesstorage.ContentTooLongError
+	if !errors.As(err, &tooLong) || tooLong.Length != 11 || tooLong.Max != 10 {
+		t.Errorf("Expected actual length 11 and maximum 10, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "11") || !strings.Contains(err.Error(), "10") {
+		t.Errorf("Expected error message to mention both lengths, got %q", err.Error())
+	}
+
+	// updates are limited the same way
+	err = storage.SetNoteContent(noteID, "0123456789a")
+	if !errors.Is(err, notesstorage.ErrContentTooLong) {
+		t.Errorf("Expected ErrContentTooLong on update, got %v", err)
+	}
+}
+
+func TestSetNoteContentIfUnchangedSameSecond(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Groceries", "Milk and bread.")
+	seen, _ := storage.GetNoteByID(noteID)
+
+	// two edits within the same second, the second one based on the note as it was before the first
+	time.Sleep(5 * time.Millisecond)
+	if err := storage.SetNoteContentIfUnchanged(noteID, seen.LastEditedAt, "Eggs."); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	err := storage.SetNoteContentIfUnchanged(noteID, seen.LastEditedAt, "Milk, bread and eggs.")
+	if !errors.Is(err, notesstorage.ErrConflict) {
+		t.Errorf("Expected ErrConflict, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(noteID)
+	if note.Content != "Eggs." {
+		t.Errorf("Expected the first edit to be kept, got %q", note.Content)
+	}
+
+	// the edit time returned by the storage matches the stored one
+	if err = storage.SetNoteContentIfUnchanged(noteID, note.LastEditedAt, "Milk, bread and eggs."); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
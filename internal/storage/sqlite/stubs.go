@@ -0,0 +1,16 @@
+package sqlite
+
+import (
+	"go-notes/internal/entities"
+)
+
+// GetEmptyNotes retrieves notes whose content is missing or empty ordered by ID
+func (s *Storage) GetEmptyNotes() ([]entities.Note, error) {
+	// compressed content is never empty, so it's safe to check content in SQL
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + ` WHERE content IS NULL OR content = '' ORDER BY note_id`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetEmptyNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.NewNote("Filled", "Some content.")
+
+	// NewNote rejects empty content, so stubs are inserted directly
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content) VALUES (2, 'Null stub', NULL)`)
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content) VALUES (3, 'Empty stub', '')`)
+	_, _ = storage.NewNote("Also filled", "More content.")
+
+	notes, err := storage.GetEmptyNotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notes) != 2 || notes[0].ID != 2 || notes[1].ID != 3 {
+		t.Fatalf("Expected stubs 2 and 3, got %+v", notes)
+	}
+
+	if notes[0].Content != "" {
+		t.Errorf("Expected missing content to be read as empty, got %q", notes[0].Content)
+	}
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"errors"
+)
+
+// errSwapEmptyContent is returned when swapping would leave a note with an empty title
+var errSwapEmptyContent = errors.New("can't swap title and content: content is empty, but a title is required")
+
+// SwapTitleContent exchanges the title and content of the note with the specified ID in a single transaction.
+// Notes with empty content are rejected, as the content would become an empty title
+func (s *Storage) SwapTitleContent(noteID int) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	note, err := scanNote(tx.QueryRow(`SELECT `+noteColumns+` FROM `+s.notes()+` WHERE note_id = ?`, noteID))
+	if err != nil {
+		return err
+	}
+
+	// the content becomes the title, so it has to satisfy the same rules as any other title
+	if note.Content == "" {
+		return errSwapEmptyContent
+	}
+	title := normalizeTitle(note.Content)
+	err = validateSQLParam(title)
+	if err != nil {
+		return err
+	}
+	note.Title, err = s.validateContent(note.Title)
+	if err != nil {
+		return err
+	}
+
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(note.Title)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE notes SET title = ?, content = ?, uncompressed_length = ?, content_hash = ?,
+		last_edited_at = `+currentTime+` WHERE note_id = ?`,
+		title, storedContent, uncompressedLength, contentHash(note.Title), noteID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
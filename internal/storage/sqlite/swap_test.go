@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSwapTitleContent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Milk and bread.", "Groceries")
+
+	if err := storage.SwapTitleContent(id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(id)
+	if note.Title != "Groceries" || note.Content != "Milk and bread." {
+		t.Errorf("Expected swapped title and content, got %q and %q", note.Title, note.Content)
+	}
+
+	if err := storage.SwapTitleContent(id + 1); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing note, got %v", err)
+	}
+}
+
+func TestSwapTitleContentEmptyContent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	// notes without content can only be created directly
+	res, _ := storage.db.Exec(`INSERT INTO notes (title, content) VALUES ('Stub', '')`)
+	id, _ := res.LastInsertId()
+
+	if err := storage.SwapTitleContent(int(id)); !errors.Is(err, errSwapEmptyContent) {
+		t.Errorf("Expected errSwapEmptyContent, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(int(id))
+	if note.Title != "Stub" || note.Content != "" {
+		t.Errorf("Expected note to stay unchanged, got %q and %q", note.Title, note.Content)
+	}
+}
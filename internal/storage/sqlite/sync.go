@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// GetNotesSinceID retrieves notes with ID greater than the specified one ordered by ID.
+// Passing 0 retrieves all notes
+func (s *Storage) GetNotesSinceID(id int) ([]entities.Note, error) {
+	// 0 is a valid starting point here, so only negative IDs are rejected
+	if id < 0 {
+		return nil, invalidNum
+	}
+
+	rows, err := s.db.Query(`SELECT `+noteColumns+` FROM `+s.notes()+` WHERE note_id > ? ORDER BY note_id`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
+
+// ImportNote inserts a note keeping its ID and timestamps, e.g. one received from another storage.
+// It returns false if an identical note with the same ID already exists and storage.ErrConflict if the existing one differs
+func (s *Storage) ImportNote(note entities.Note) (bool, error) {
+	note.Title = normalizeTitle(note.Title)
+	err := validateSQLParam(note.ID, note.Title)
+	if err != nil {
+		return false, err
+	}
+	note.Content, err = s.validateContent(note.Content)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// compare with a note already stored under the same ID
+	existing, err := scanNote(tx.QueryRow(`SELECT `+noteColumns+` FROM notes WHERE note_id = ?`, note.ID))
+	switch {
+	case err == nil:
+		if existing.Title != note.Title || existing.Content != note.Content {
+			return false, storage.ErrConflict
+		}
+
+		return false, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return false, err
+	}
+
+	// compress content if enabled
+	storedContent, uncompressedLength, err := s.encodeContent(note.Content)
+	if err != nil {
+		return false, err
+	}
+
+	// keep original timestamps, falling back to the current time for missing ones
+	_, err = tx.Exec(`INSERT INTO notes (note_id, title, content, uncompressed_length, content_hash, created_at, last_edited_at)
+		VALUES (?, ?, ?, ?, ?, COALESCE(?, `+currentTime+`), COALESCE(?, `+currentTime+`))`,
+		note.ID, note.Title, storedContent, uncompressedLength, contentHash(note.Content),
+		dbTime(note.CreatedAt), dbTime(note.LastEditedAt))
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// GetSyncState retrieves IDs of the last note pushed to and pulled from the remote
+func (s *Storage) GetSyncState(remote string) (pushedID, pulledID int, err error) {
+	err = validateSQLParam(remote)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s.db.QueryRow(`SELECT last_pushed_id, last_pulled_id FROM sync_state WHERE remote = ?`, remote).
+		Scan(&pushedID, &pulledID)
+
+	// a remote which was never synced starts from the beginning
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, nil
+	}
+
+	return pushedID, pulledID, err
+}
+
+// SetSyncState stores IDs of the last note pushed to and pulled from the remote
+func (s *Storage) SetSyncState(remote string, pushedID, pulledID int) error {
+	err := validateSQLParam(remote)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO sync_state (remote, last_pushed_id, last_pulled_id) VALUES (?, ?, ?)
+		ON CONFLICT (remote) DO UPDATE SET last_pushed_id = excluded.last_pushed_id, last_pulled_id = excluded.last_pulled_id`,
+		remote, pushedID, pulledID)
+
+	return err
+}
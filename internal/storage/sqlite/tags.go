@@ -0,0 +1,267 @@
+package sqlite
+
+import (
+	"database/sql"
+	"strings"
+
+	"go-notes/internal/entities"
+)
+
+// AddTag attaches a tag to the note with the specified ID, attaching an already present tag is a no-op
+func (s *Storage) AddTag(noteID int, tag string) error {
+	tag = normalizeTag(tag)
+	err := validateSQLParam(noteID, tag)
+	if err != nil {
+		return err
+	}
+
+	// make sure the note exists, so missing notes are reported the same way as in other methods
+	err = s.noteExists(noteID)
+	if err != nil {
+		return err
+	}
+
+	// insert tag, ignoring duplicates
+	_, err = s.db.Exec(`INSERT OR IGNORE INTO note_tags (note_id, tag) VALUES (?, ?)`, noteID, tag)
+
+	return err
+}
+
+// RemoveTag detaches a tag from the note with the specified ID
+func (s *Storage) RemoveTag(noteID int, tag string) error {
+	tag = normalizeTag(tag)
+	err := validateSQLParam(noteID, tag)
+	if err != nil {
+		return err
+	}
+
+	// execute deleting of tag
+	res, err := s.db.Exec(`DELETE FROM note_tags WHERE note_id = ? AND tag = ?`, noteID, tag)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - note doesn't have this tag
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetNoteTags retrieves tags of the note with the specified ID sorted by name
+func (s *Storage) GetNoteTags(noteID int) ([]string, error) {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return noteTags(s.db, noteID)
+}
+
+// noteTags retrieves tags of the note sorted by name
+func noteTags(db querier, noteID int) ([]string, error) {
+	rows, err := db.Query(`SELECT tag FROM note_tags WHERE note_id = ? ORDER BY tag`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetAllTags retrieves every tag with the number of notes having it, from the most used tag
+func (s *Storage) GetAllTags() ([]entities.TagCount, error) {
+	rows, err := s.db.Query(`SELECT tag, COUNT(*) FROM note_tags
+		WHERE note_id IN (SELECT note_id FROM ` + s.notes() + `) GROUP BY tag ORDER BY COUNT(*) DESC, tag`)
+	if err != nil {
+		return nil, err
+	}
+	// ensure rows are closed when done processing
+	defer rows.Close()
+
+	var tags []entities.TagCount
+	for rows.Next() {
+		var tag entities.TagCount
+		if err = rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// SearchNotesIncludingTags searches for notes containing the keyword in titles or content, or having a tag containing it.
+// Notes matching in several ways are returned once
+func (s *Storage) SearchNotesIncludingTags(keyword string) ([]entities.Note, error) {
+	err := validateSQLParam(keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchNotes(s.db, s.notes(), keyword, true)
+}
+
+// TagNotesByKeyword attaches a tag to every note matching the keyword in a single transaction.
+// It returns number of notes which got the tag, notes already having it are not counted
+func (s *Storage) TagNotesByKeyword(keyword, tag string) (int, error) {
+	return s.retagNotesByKeyword(keyword, tag, `INSERT OR IGNORE INTO note_tags (note_id, tag) VALUES (?, ?)`)
+}
+
+// UntagNotesByKeyword detaches a tag from every note matching the keyword in a single transaction.
+// It returns number of notes the tag was removed from
+func (s *Storage) UntagNotesByKeyword(keyword, tag string) (int, error) {
+	return s.retagNotesByKeyword(keyword, tag, `DELETE FROM note_tags WHERE note_id = ? AND tag = ?`)
+}
+
+// retagNotesByKeyword executes the statement with (note_id, tag) arguments for each note matching the keyword
+func (s *Storage) retagNotesByKeyword(keyword, tag, statement string) (int, error) {
+	tag = normalizeTag(tag)
+	err := validateSQLParam(keyword, tag)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// find notes matching the keyword the same way SearchNotesByKeyword does
+	notes, err := searchNotes(tx, s.notes(), keyword, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// prepare statement once for all matching notes
+	stmt, err := tx.Prepare(statement)
+	if err != nil {
+		return 0, err
+	}
+	// ensure statement are closed when done processing
+	defer stmt.Close()
+
+	// apply statement to every note, counting only actually changed rows
+	var affected int
+	for _, note := range notes {
+		res, err := stmt.Exec(note.ID, tag)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += int(n)
+	}
+
+	return affected, tx.Commit()
+}
+
+// noteExists returns sql.ErrNoRows if there is no note with the specified ID
+func (s *Storage) noteExists(noteID int) error {
+	var id int
+
+	return s.db.QueryRow(`SELECT note_id FROM notes WHERE note_id = ?`, noteID).Scan(&id)
+}
+
+// normalizeTag trims spaces around a tag and lowercases it, so "Work" and "work " are the same tag
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// RenameTag renames a tag on all notes in a single transaction.
+// Notes already having the new tag keep a single copy of it, so renaming into an existing tag merges them
+func (s *Storage) RenameTag(oldTag, newTag string) error {
+	oldTag, newTag = normalizeTag(oldTag), normalizeTag(newTag)
+	err := validateSQLParam(oldTag, newTag)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// make sure some note has the old tag
+	var count int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM note_tags WHERE tag = ?`, oldTag).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return sql.ErrNoRows
+	}
+
+	// renaming a tag to itself changes nothing
+	if oldTag == newTag {
+		return nil
+	}
+
+	// copy the tag under the new name, ignoring notes which already have it, then remove the old name
+	_, err = tx.Exec(`INSERT OR IGNORE INTO note_tags (note_id, tag) SELECT note_id, ? FROM note_tags WHERE tag = ?`,
+		newTag, oldTag)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM note_tags WHERE tag = ?`, oldTag)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteTag removes a tag from all notes
+func (s *Storage) DeleteTag(tag string) error {
+	tag = normalizeTag(tag)
+	err := validateSQLParam(tag)
+	if err != nil {
+		return err
+	}
+
+	// a single statement is atomic, so no explicit transaction is needed
+	res, err := s.db.Exec(`DELETE FROM note_tags WHERE tag = ?`, tag)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - no note has this tag
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
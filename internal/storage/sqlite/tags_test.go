@@ -0,0 +1,213 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestTagNotesByKeyword(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	matching1, _ := storage.NewNote("Project plan", "Tasks for the project.")
+	matching2, _ := storage.NewNote("Meeting", "Discussed the project deadline.")
+	other, _ := storage.NewNote("Groceries", "Milk and bread.")
+
+	// one matching note is already tagged and must not be counted again
+	_ = storage.AddTag(matching2, "Work")
+
+	affected, err := storage.TagNotesByKeyword("project", "work")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if affected != 1 {
+		t.Errorf("Expected 1 newly tagged note, got %d", affected)
+	}
+
+	for _, id := range []int{matching1, matching2} {
+		tags, err := storage.GetNoteTags(id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tags) != 1 || tags[0] != "work" {
+			t.Errorf("Expected note %d to be tagged 'work', got %v", id, tags)
+		}
+	}
+
+	tags, _ := storage.GetNoteTags(other)
+	if len(tags) != 0 {
+		t.Errorf("Expected non-matching note to have no tags, got %v", tags)
+	}
+
+	// removing the tag by the same search affects both matching notes
+	affected, err = storage.UntagNotesByKeyword("project", "work")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if affected != 2 {
+		t.Errorf("Expected 2 untagged notes, got %d", affected)
+	}
+}
+
+func TestDeleteNoteRemovesTags(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	noteID, _ := storage.NewNote("Test Note", "This is a test note.")
+	_ = storage.AddTag(noteID, "test")
+
+	_, _ = storage.DeleteNote(noteID)
+
+	var count int
+	_ = storage.db.QueryRow(`SELECT COUNT(*) FROM note_tags`).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected tags of deleted note to be removed, got %d rows", count)
+	}
+}
+
+func TestRenameTagMerge(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	both, _ := storage.NewNote("Both", "Has both tags.")
+	oldOnly, _ := storage.NewNote("Old", "Has the old tag.")
+	newOnly, _ := storage.NewNote("New", "Has the new tag.")
+
+	_ = storage.AddTag(both, "todo")
+	_ = storage.AddTag(both, "tasks")
+	_ = storage.AddTag(oldOnly, "todo")
+	_ = storage.AddTag(newOnly, "tasks")
+
+	err := storage.RenameTag("TODO", "tasks")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, id := range []int{both, oldOnly, newOnly} {
+		tags, _ := storage.GetNoteTags(id)
+		if len(tags) != 1 || tags[0] != "tasks" {
+			t.Errorf("Expected note %d to have only 'tasks', got %v", id, tags)
+		}
+	}
+
+	// the old tag is gone
+	if err = storage.RenameTag("todo", "other"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing tag, got %v", err)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("First", "Content.")
+	second, _ := storage.NewNote("Second", "Content.")
+
+	_ = storage.AddTag(first, "old")
+	_ = storage.AddTag(first, "keep")
+	_ = storage.AddTag(second, "old")
+
+	err := storage.DeleteTag("Old")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tags, _ := storage.GetNoteTags(first)
+	if len(tags) != 1 || tags[0] != "keep" {
+		t.Errorf("Expected only 'keep' to stay, got %v", tags)
+	}
+	tags, _ = storage.GetNoteTags(second)
+	if len(tags) != 0 {
+		t.Errorf("Expected no tags, got %v", tags)
+	}
+
+	if err = storage.DeleteTag("old"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for a missing tag, got %v", err)
+	}
+}
+
+func TestGetAllTags(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	first, _ := storage.NewNote("First", "Content.")
+	second, _ := storage.NewNote("Second", "Content.")
+	third, _ := storage.NewNote("Third", "Content.")
+
+	for _, id := range []int{first, second, third} {
+		_ = storage.AddTag(id, "work")
+	}
+	_ = storage.AddTag(first, "home")
+	_ = storage.AddTag(second, "home")
+	_ = storage.AddTag(third, "archive")
+	_ = storage.AddTag(first, "ideas")
+
+	tags, err := storage.GetAllTags()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// ties are ordered by name
+	want := []struct {
+		tag   string
+		count int
+	}{{"work", 3}, {"home", 2}, {"archive", 1}, {"ideas", 1}}
+
+	if len(tags) != len(want) {
+		t.Fatalf("Expected %d tags, got %v", len(want), tags)
+	}
+	for i, w := range want {
+		if tags[i].Tag != w.tag || tags[i].Count != w.count {
+			t.Errorf("Expected %s with %d notes at position %d, got %+v", w.tag, w.count, i, tags[i])
+		}
+	}
+}
+
+func TestSearchNotesIncludingTags(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	byText, _ := storage.NewNote("Work plan", "Finish the report.")
+	byTag, _ := storage.NewNote("Standup", "Discuss the release.")
+	byBoth, _ := storage.NewNote("Homework", "Math exercises.")
+	_, _ = storage.NewNote("Groceries", "Milk and bread.")
+	_ = storage.AddTag(byTag, "work")
+	_ = storage.AddTag(byBoth, "work")
+	_ = storage.AddTag(byBoth, "school-work")
+
+	notes, err := storage.SearchNotesIncludingTags("work")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// every note is found once, including the one matching only by tag
+	var ids []int
+	for _, note := range notes {
+		ids = append(ids, note.ID)
+	}
+	if len(ids) != 3 || ids[0] != byText || ids[1] != byTag || ids[2] != byBoth {
+		t.Errorf("Expected notes %v, got %v", []int{byText, byTag, byBoth}, ids)
+	}
+
+	// tags are not searched by default
+	notes, _ = storage.SearchNotesByKeyword("work")
+	if len(notes) != 2 {
+		t.Errorf("Expected 2 notes matching by text, got %+v", notes)
+	}
+}
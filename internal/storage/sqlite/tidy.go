@@ -0,0 +1,44 @@
+package sqlite
+
+// TidyNotes replaces content of every note with the result of tidy in a single transaction
+// and returns IDs of changed notes. With dryRun nothing is written, only the IDs which would change are returned.
+// Notes which tidy would leave empty are kept as they are, as notes can't have empty content
+func (s *Storage) TidyNotes(tidy func(content string) string, dryRun bool) ([]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// notes are read completely before updating, as the transaction can't run statements while rows are open
+	rows, err := tx.Query(`SELECT ` + noteColumns + ` FROM ` + s.notes() + ` ORDER BY note_id`)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := scanNotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []int
+	for _, note := range notes {
+		content := tidy(note.Content)
+		if content == note.Content || content == "" {
+			continue
+		}
+
+		if !dryRun {
+			if err = s.setNoteContent(tx, note.ID, content); err != nil {
+				return nil, err
+			}
+		}
+		changed = append(changed, note.ID)
+	}
+
+	if dryRun {
+		return changed, nil
+	}
+
+	return changed, tx.Commit()
+}
@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-notes/internal/storage"
+)
+
+func TestTidyNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	s, _ := New(dbPath)
+	defer s.Close()
+
+	ragged, _ := s.NewNote("Ragged", "One  \n\n\n\nTwo")
+	_, _ = s.NewNote("Tidy", "Already\n\ntidy")
+	blank, _ := s.NewNote("Blank", " \n ")
+
+	// dry run reports the note without changing it
+	changed, err := s.TidyNotes(storage.TidyWhitespace, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(changed, []int{ragged}) {
+		t.Errorf("Expected note %d to change, got %v", ragged, changed)
+	}
+	if note, _ := s.GetNoteByID(ragged); note.Content != "One  \n\n\n\nTwo" {
+		t.Errorf("Expected dry run to keep content, got %q", note.Content)
+	}
+
+	changed, err = s.TidyNotes(storage.TidyWhitespace, false)
+	if err != nil || !reflect.DeepEqual(changed, []int{ragged}) {
+		t.Fatalf("Expected note %d to change, got %v (%v)", ragged, changed, err)
+	}
+	if note, _ := s.GetNoteByID(ragged); note.Content != "One\n\nTwo" {
+		t.Errorf("Expected tidied content, got %q", note.Content)
+	}
+
+	// content which would become empty is kept
+	if note, _ := s.GetNoteByID(blank); note.Content != " \n " {
+		t.Errorf("Expected blank note to be kept, got %q", note.Content)
+	}
+}
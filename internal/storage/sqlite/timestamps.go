@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"time"
+)
+
+// timestamp scans a timestamp column into t, NULL and values the driver couldn't parse are read as zero time
+type timestamp struct {
+	t *time.Time
+}
+
+// Scan implements sql.Scanner
+func (ts timestamp) Scan(src interface{}) error {
+	// the driver returns timestamps it can parse as time.Time and anything else as is
+	if v, ok := src.(time.Time); ok {
+		*ts.t = v
+	} else {
+		*ts.t = time.Time{}
+	}
+
+	return nil
+}
+
+// optionalTimestamp scans a nullable timestamp column into t, NULL and malformed values are read as nil
+type optionalTimestamp struct {
+	t **time.Time
+}
+
+// Scan implements sql.Scanner
+func (ts optionalTimestamp) Scan(src interface{}) error {
+	if v, ok := src.(time.Time); ok {
+		*ts.t = &v
+	} else {
+		*ts.t = nil
+	}
+
+	return nil
+}
+
+// FixTimestamps backfills missing or malformed creation and edit times and returns number of fixed notes.
+// A broken timestamp is replaced by the other one if it's valid, otherwise by the current time
+func (s *Storage) FixTimestamps() (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// the trigger would overwrite backfilled edit times, so it's dropped for this transaction only
+	if _, err = tx.Exec(`DROP TRIGGER IF EXISTS update_last_edited_at`); err != nil {
+		return 0, err
+	}
+
+	// datetime() returns NULL for both NULL and unparsable values
+	res, err := tx.Exec(`UPDATE notes SET
+		created_at = COALESCE(datetime(created_at), datetime(last_edited_at), ` + currentTime + `),
+		last_edited_at = COALESCE(datetime(last_edited_at), datetime(created_at), ` + currentTime + `)
+		WHERE datetime(created_at) IS NULL OR datetime(last_edited_at) IS NULL`)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// the trigger is restored only if it's enabled
+	if !s.noEditTrigger {
+		if _, err = tx.Exec(lastEditedTrigger); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(fixed), tx.Commit()
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanNullTimestamps(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content, created_at, last_edited_at)
+		VALUES (1, 'Null', 'Imported without times.', NULL, NULL)`)
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content, created_at, last_edited_at)
+		VALUES (2, 'Malformed', 'Imported with broken times.', 'yesterday', '2024-01-05 10:00:00')`)
+
+	notes, err := storage.GetAllNotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	if !notes[0].CreatedAt.IsZero() || !notes[0].LastEditedAt.IsZero() {
+		t.Errorf("Expected zero times for NULL timestamps, got %+v", notes[0])
+	}
+	if !notes[1].CreatedAt.IsZero() {
+		t.Errorf("Expected zero time for a malformed timestamp, got %s", notes[1].CreatedAt)
+	}
+}
+
+func TestFixTimestamps(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content, created_at, last_edited_at)
+		VALUES (1, 'Null', 'Imported without times.', NULL, NULL)`)
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content, created_at, last_edited_at)
+		VALUES (2, 'Malformed', 'Imported with broken times.', 'yesterday', '2024-01-05 10:00:00')`)
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content, created_at, last_edited_at)
+		VALUES (3, 'Valid', 'Fine already.', '2024-01-01 08:00:00', '2024-01-02 09:00:00')`)
+
+	fixed, err := storage.FixTimestamps()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if fixed != 2 {
+		t.Errorf("Expected 2 fixed notes, got %d", fixed)
+	}
+
+	notes, _ := storage.GetAllNotes()
+	if notes[0].CreatedAt.IsZero() || notes[0].LastEditedAt.IsZero() {
+		t.Errorf("Expected NULL timestamps to be backfilled, got %+v", notes[0])
+	}
+
+	// the valid edit time is kept and used for the broken creation time
+	editedAt := time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)
+	if !notes[1].CreatedAt.Equal(editedAt) || !notes[1].LastEditedAt.Equal(editedAt) {
+		t.Errorf("Expected both timestamps %s, got %+v", editedAt, notes[1])
+	}
+
+	// valid notes are left untouched
+	if !notes[2].LastEditedAt.Equal(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected valid edit time to be kept, got %s", notes[2].LastEditedAt)
+	}
+
+	// the trigger is restored
+	_ = storage.SetNoteContent(3, "Edited.")
+	note, _ := storage.GetNoteByID(3)
+	if !note.LastEditedAt.After(time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected edit time to be updated by the trigger, got %s", note.LastEditedAt)
+	}
+}
@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// TrashNote moves the note with the specified ID to the trash, hiding it from read methods until it is restored
+func (s *Storage) TrashNote(noteID int) error {
+	return s.setDeletedAt(noteID, `CURRENT_TIMESTAMP`, `deleted_at IS NULL`)
+}
+
+// RestoreNote moves the note with the specified ID out of the trash
+func (s *Storage) RestoreNote(noteID int) error {
+	return s.setDeletedAt(noteID, `NULL`, `deleted_at IS NOT NULL`)
+}
+
+// TrashMatchingNotes moves every note matching the filter to the trash in a single transaction
+// and returns IDs of trashed notes. With dryRun nothing is written, only the IDs which would be trashed are returned
+func (s *Storage) TrashMatchingNotes(filter storage.NoteFilter, dryRun bool) ([]int, error) {
+	// only set conditions are validated, as unset ones are empty
+	tag := normalizeTag(filter.Tag)
+	for _, param := range []string{filter.Keyword, tag} {
+		if param == "" {
+			continue
+		}
+		if err := validateSQLParam(param); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback is a no-op after a successful commit
+	defer tx.Rollback()
+
+	// an empty keyword matches every note
+	notes, err := searchNotes(tx, s.notes(), filter.Keyword, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := map[int]bool{}
+	if tag != "" {
+		rows, err := tx.Query(`SELECT note_id FROM note_tags WHERE tag = ?`, tag)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id int
+			if err = rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, err
+			}
+			tagged[id] = true
+		}
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		// the transaction can't run statements while rows are open
+		_ = rows.Close()
+	}
+
+	var trashed []int
+	for _, note := range notes {
+		if tag != "" && !tagged[note.ID] {
+			continue
+		}
+		if !filter.CreatedBefore.IsZero() && !createdBefore(note, filter.CreatedBefore) {
+			continue
+		}
+
+		if !dryRun {
+			if _, err = tx.Exec(`UPDATE notes SET deleted_at = CURRENT_TIMESTAMP WHERE note_id = ? AND deleted_at IS NULL`, note.ID); err != nil {
+				return nil, err
+			}
+		}
+		trashed = append(trashed, note.ID)
+	}
+
+	if dryRun {
+		return trashed, nil
+	}
+
+	return trashed, tx.Commit()
+}
+
+// createdBefore reports whether the note was created before the time. Notes whose creation time is missing
+// or unreadable are scanned with zero time, they are never taken as old
+func createdBefore(note entities.Note, before time.Time) bool {
+	return !note.CreatedAt.IsZero() && note.CreatedAt.Before(before)
+}
+
+// GetTrashedNotes retrieves notes in the trash ordered from the most recently trashed
+func (s *Storage) GetTrashedNotes() ([]entities.Note, error) {
+	rows, err := s.db.Query(`SELECT ` + noteColumns + ` FROM notes
+		WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, note_id DESC`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
+
+// setDeletedAt sets deleted_at of the note matching the condition to the given SQL expression,
+// sql.ErrNoRows is returned if there is no such note
+func (s *Storage) setDeletedAt(noteID int, value, condition string) error {
+	err := validateSQLParam(noteID)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.Exec(`UPDATE notes SET deleted_at = `+value+` WHERE note_id = ? AND `+condition, noteID)
+	if err != nil {
+		return err
+	}
+
+	// check number of rows affected
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// if no rows were affected - return an error
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+	notesstorage "go-notes/internal/storage"
+)
+
+func TestTrashNote(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Groceries", "Milk")
+
+	if err := storage.TrashNote(id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := storage.TrashNote(id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows trashing a trashed note, got %v", err)
+	}
+
+	trashed, _ := storage.GetTrashedNotes()
+	if len(trashed) != 1 || trashed[0].ID != id || trashed[0].DeletedAt == nil {
+		t.Errorf("Expected note %d in the trash, got %v", id, trashed)
+	}
+
+	if err := storage.RestoreNote(id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := storage.RestoreNote(id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows restoring a note not in the trash, got %v", err)
+	}
+
+	note, err := storage.GetNoteByID(id)
+	if err != nil || note.DeletedAt != nil {
+		t.Errorf("Expected restored note, got %v, %v", note, err)
+	}
+}
+
+// TestReadMethodsExcludeTrashed checks every read method hides a trashed note unless WithTrashed is used
+func TestReadMethodsExcludeTrashed(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	// the trashed note matches every read method, so only the trash can hide it,
+	// NewNote rejects empty content, so the note is inserted directly
+	id := 1
+	_, _ = storage.db.Exec(`INSERT INTO notes (note_id, title, content) VALUES (1, 'Trashed', '')`)
+	_ = storage.AddTag(id, "bin")
+	_ = storage.SetMeta(id, "kind", "old")
+	_ = storage.PinNote(id)
+	_ = storage.SetDueDate(id, time.Now().Add(-time.Hour))
+	_ = storage.TrashNote(id)
+
+	reads := map[string]func(s *Storage) ([]entities.Note, error){
+		"GetAllNotes":          (*Storage).GetAllNotes,
+		"GetNotesForDashboard": (*Storage).GetNotesForDashboard,
+		"GetEmptyNotes":        (*Storage).GetEmptyNotes,
+		"GetOrphanNotes": func(s *Storage) ([]entities.Note, error) {
+			// orphans have no tags
+			defer s.AddTag(id, "bin")
+			_ = s.RemoveTag(id, "bin")
+			return s.GetOrphanNotes()
+		},
+		"SearchNotesByKeyword": func(s *Storage) ([]entities.Note, error) {
+			return s.SearchNotesByKeyword("Trash")
+		},
+		"SearchNotesIncludingTags": func(s *Storage) ([]entities.Note, error) {
+			return s.SearchNotesIncludingTags("bin")
+		},
+		"GetNotesByMeta": func(s *Storage) ([]entities.Note, error) {
+			return s.GetNotesByMeta("kind", "old")
+		},
+		"GetDueNotes": func(s *Storage) ([]entities.Note, error) {
+			return s.GetDueNotes(time.Now())
+		},
+		"GetLongestNotes": func(s *Storage) ([]entities.Note, error) {
+			return s.GetLongestNotes(10)
+		},
+		"GetShortestNotes": func(s *Storage) ([]entities.Note, error) {
+			return s.GetShortestNotes(10)
+		},
+		"GetNotesSinceID": func(s *Storage) ([]entities.Note, error) {
+			return s.GetNotesSinceID(0)
+		},
+		"GetNotesByWeekday": func(s *Storage) ([]entities.Note, error) {
+			return s.GetNotesByWeekday(time.Now().Weekday())
+		},
+		"GetNotesPageMeta": func(s *Storage) ([]entities.Note, error) {
+			page, err := s.GetNotesPageMeta(10, 0)
+			return page.Notes, err
+		},
+		"NoteCursor": func(s *Storage) ([]entities.Note, error) {
+			notes, _, err := s.NewNoteCursor(10).Next()
+			return notes, err
+		},
+		"GetNoteByID": func(s *Storage) ([]entities.Note, error) {
+			note, err := s.GetNoteByID(id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil
+			}
+			return []entities.Note{note}, err
+		},
+	}
+
+	for name, read := range reads {
+		notes, err := read(storage)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+		if len(notes) != 0 {
+			t.Errorf("%s: expected trashed note to be excluded, got %v", name, notes)
+		}
+
+		notes, err = read(storage.WithTrashed())
+		if err != nil {
+			t.Errorf("%s: expected no error with trashed notes, got %v", name, err)
+		}
+		if len(notes) != 1 || notes[0].ID != id {
+			t.Errorf("%s: expected trashed note to be included with WithTrashed, got %v", name, notes)
+		}
+	}
+
+	if tags, _ := storage.GetAllTags(); len(tags) != 0 {
+		t.Errorf("Expected tags of trashed notes to be excluded, got %v", tags)
+	}
+	if tags, _ := storage.WithTrashed().GetAllTags(); len(tags) != 1 {
+		t.Errorf("Expected tags of trashed notes with WithTrashed, got %v", tags)
+	}
+}
+
+func TestTrashMatchingNotes(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	cutoff := time.Now().AddDate(0, 0, -30).UTC()
+	oldNote, _ := storage.CreateNote(entities.Note{Title: "Old", Content: "Stale plan", CreatedAt: cutoff.AddDate(0, 0, -1)}, nil)
+	oldTagged, _ := storage.CreateNote(entities.Note{Title: "Old tagged", Content: "Stale list", CreatedAt: cutoff.AddDate(0, 0, -5)}, nil)
+	newNote, _ := storage.NewNote("New", "Fresh plan")
+	_ = storage.AddTag(oldTagged, "archive")
+	_ = storage.AddTag(newNote, "archive")
+	// age is measured from creation, so a recent edit doesn't keep the old note
+	_ = storage.SetNoteContent(oldNote, "Stale plan, edited today")
+	// notes without a known creation time are never old
+	undated, _ := storage.NewNote("Undated", "No creation time")
+	unreadable, _ := storage.NewNote("Unreadable", "Broken creation time")
+	_, _ = storage.db.Exec(`UPDATE notes SET created_at = NULL WHERE note_id = ?`, undated)
+	_, _ = storage.db.Exec(`UPDATE notes SET created_at = 'yesterday' WHERE note_id = ?`, unreadable)
+
+	// a dry run reports the notes without trashing them
+	ids, err := storage.TrashMatchingNotes(notesstorage.NoteFilter{CreatedBefore: cutoff}, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int{oldNote, oldTagged}) {
+		t.Errorf("Expected notes %d and %d to match, got %v", oldNote, oldTagged, ids)
+	}
+	if trashed, _ := storage.GetTrashedNotes(); len(trashed) != 0 {
+		t.Errorf("Expected an empty trash after a dry run, got %v", trashed)
+	}
+
+	// conditions of the filter are combined
+	ids, _ = storage.TrashMatchingNotes(notesstorage.NoteFilter{Tag: "archive", Keyword: "plan"}, true)
+	if !reflect.DeepEqual(ids, []int{newNote}) {
+		t.Errorf("Expected only note %d to match, got %v", newNote, ids)
+	}
+
+	if ids, _ = storage.TrashMatchingNotes(notesstorage.NoteFilter{CreatedBefore: cutoff}, false); len(ids) != 2 {
+		t.Errorf("Expected 2 trashed notes, got %v", ids)
+	}
+	notes, _ := storage.GetAllNotes()
+	if len(notes) != 3 {
+		t.Errorf("Expected the new and undated notes to stay active, got %v", notes)
+	}
+	if trashed, _ := storage.GetTrashedNotes(); len(trashed) != 2 {
+		t.Errorf("Expected 2 notes in the trash, got %v", trashed)
+	}
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// oldEditTime is a last edit time long in the past, so any bump of it is visible within a single second
+const oldEditTime = "2020-01-01 00:00:00"
+
+func TestWithoutEditTrigger(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath, WithEditTrigger(false))
+	defer storage.Close()
+
+	id, _ := storage.NewNote("Groceries", "Milk and bread.")
+	_, _ = storage.db.Exec(`UPDATE notes SET last_edited_at = ? WHERE note_id = ?`, oldEditTime, id)
+
+	// metadata-only updates keep the edit time
+	if err := storage.PinNote(id); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := storage.GetNoteByID(id)
+	if note.PinnedAt == nil {
+		t.Errorf("Expected note to be pinned")
+	}
+	if got := note.LastEditedAt.Format(timeLayout); got != oldEditTime {
+		t.Errorf("Expected last edit time %s after pinning, got %s", oldEditTime, got)
+	}
+
+	// editing content bumps it
+	if err := storage.SetNoteContent(id, "Milk, bread and eggs."); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ = storage.GetNoteByID(id)
+	if got := note.LastEditedAt.Format(timeLayout); got == oldEditTime {
+		t.Errorf("Expected last edit time to change after editing content")
+	}
+}
+
+func TestEditTriggerRestored(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// the trigger is stored in the database, so opening it with the default options creates it again
+	storage, _ := New(dbPath, WithEditTrigger(false))
+	if triggerExists(t, storage) {
+		t.Errorf("Expected the trigger to be dropped")
+	}
+	_ = storage.Close()
+
+	storage, _ = New(dbPath)
+	defer storage.Close()
+
+	if !triggerExists(t, storage) {
+		t.Errorf("Expected the trigger to be created again")
+	}
+}
+
+// triggerExists reports whether the trigger updating last edit time exists
+func triggerExists(t *testing.T, storage *Storage) bool {
+	t.Helper()
+
+	var count int
+	err := storage.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'update_last_edited_at'`).
+		Scan(&count)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	return count > 0
+}
+
+func TestOutdatedEditTriggerReplaced(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// earlier versions created the trigger storing edit times without milliseconds
+	storage, _ := New(dbPath)
+	_, _ = storage.db.Exec(`DROP TRIGGER update_last_edited_at`)
+	_, _ = storage.db.Exec(strings.Replace(lastEditedTrigger, currentTime, `CURRENT_TIMESTAMP`, 1))
+	_ = storage.Close()
+
+	storage, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer storage.Close()
+
+	var definition string
+	_ = storage.db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = 'update_last_edited_at'`).
+		Scan(&definition)
+	if !strings.Contains(definition, currentTime) {
+		t.Errorf("Expected the trigger to store edit times with milliseconds, got %q", definition)
+	}
+}
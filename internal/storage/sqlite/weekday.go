@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"time"
+
+	"go-notes/internal/entities"
+)
+
+// GetNotesByWeekday retrieves notes created on the specified day of the week in local time, ordered by ID
+func (s *Storage) GetNotesByWeekday(day time.Weekday) ([]entities.Note, error) {
+	// strftime numbers days from Sunday as 0, the same way time.Weekday does
+	rows, err := s.db.Query(`SELECT `+noteColumns+` FROM `+s.notes()+`
+		WHERE CAST(strftime('%w', created_at, 'localtime') AS INTEGER) = ? ORDER BY note_id`, int(day))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotes(rows)
+}
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetNotesByWeekday(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	storage, _ := New(dbPath)
+	defer storage.Close()
+
+	// notes are created at noon UTC, so they fall on the same day in most time zones
+	dates := map[string]string{
+		"Monday standup":  "2024-01-01 12:00:00",
+		"Tuesday review":  "2024-01-02 12:00:00",
+		"Another Monday":  "2024-01-08 12:00:00",
+		"Sunday planning": "2024-01-07 12:00:00",
+	}
+	for title, created := range dates {
+		_, _ = storage.db.Exec(`INSERT INTO notes (title, content, created_at) VALUES (?, 'Content', ?)`, title, created)
+	}
+
+	notes, err := storage.GetNotesByWeekday(time.Monday)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes created on Monday, got %+v", notes)
+	}
+	for _, note := range notes {
+		if note.CreatedAt.Local().Weekday() != time.Monday {
+			t.Errorf("Expected only notes created on Monday, got %q created on %s", note.Title, note.CreatedAt.Weekday())
+		}
+	}
+
+	notes, _ = storage.GetNotesByWeekday(time.Sunday)
+	if len(notes) != 1 || notes[0].Title != "Sunday planning" {
+		t.Errorf("Expected the note created on Sunday, got %+v", notes)
+	}
+
+	notes, _ = storage.GetNotesByWeekday(time.Friday)
+	if len(notes) != 0 {
+		t.Errorf("Expected no notes created on Friday, got %+v", notes)
+	}
+}
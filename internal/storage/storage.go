@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errors shared by storage implementations, so callers can tell them apart without knowing the backend
+var (
+	// ErrConflict is returned when a write conflicts with the current state of a note
+	ErrConflict = errors.New("conflict")
+
+	// ErrPinLimitReached is returned when pinning a note would exceed the maximum number of pinned notes
+	ErrPinLimitReached = errors.New("pin limit reached")
+
+	// ErrCorrupt is returned when the database is damaged or is not a database at all
+	ErrCorrupt = errors.New("database is corrupt")
+
+	// ErrIDTaken is returned when a note can't get an ID because another note already has it
+	ErrIDTaken = errors.New("note ID is already taken")
+
+	// ErrSkippedNotes is returned together with the notes which were read when some stored notes couldn't be read
+	ErrSkippedNotes = errors.New("some notes couldn't be read")
+
+	// ErrInUse is returned when the database is locked by another process for longer than the caller waits
+	ErrInUse = errors.New("database in use")
+
+	// ErrInvalidContent is returned when content contains characters which aren't allowed, e.g. a NUL byte
+	ErrInvalidContent = errors.New("invalid content")
+
+	// ErrInvalidParam is matched by errors of params a storage rejects, e.g. an empty title or a negative ID
+	ErrInvalidParam = errors.New("invalid param")
+)
+
+// ErrContentTooLong is matched by ContentTooLongError using errors.Is
+var ErrContentTooLong = errors.New("content too long")
+
+// ContentTooLongError is returned when note content exceeds the configured maximum length
+type ContentTooLongError struct {
+	// Length is the length of the rejected content in bytes
+	Length int
+
+	// Max is the maximum allowed length in bytes
+	Max int
+}
+
+// Error describes both the actual and the allowed length
+func (e *ContentTooLongError) Error() string {
+	return fmt.Sprintf("content too long: %d bytes, maximum allowed is %d", e.Length, e.Max)
+}
+
+// Is makes errors.Is(err, ErrContentTooLong) match any ContentTooLongError
+func (e *ContentTooLongError) Is(target error) bool {
+	return target == ErrContentTooLong
+}
@@ -0,0 +1,201 @@
+package storagetest
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// Storage is the contract checked by RunStorageTests, it matches the storage interface used by the CLI
+type Storage interface {
+	NewNote(noteTitle, content string) (int, error)
+	DeleteNote(id int) (int, error)
+	SetNoteContent(noteID int, content string) error
+	SearchNotesByKeyword(keyword string) ([]entities.Note, error)
+	GetNoteByID(noteID int) (entities.Note, error)
+	GetAllNotes() ([]entities.Note, error)
+}
+
+// RunStorageTests runs the conformance suite, newStorage must return a new empty storage on every call
+func RunStorageTests(t *testing.T, newStorage func() Storage) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s Storage)
+	}{
+		{"Create", testCreate},
+		{"Get", testGet},
+		{"Update", testUpdate},
+		{"UnchangedUpdate", testUnchangedUpdate},
+		{"Delete", testDelete},
+		{"Search", testSearch},
+		{"List", testList},
+		{"NotFound", testNotFound},
+		{"Validation", testValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStorage())
+		})
+	}
+}
+
+func testCreate(t *testing.T, s Storage) {
+	first, err := s.NewNote("First", "One.")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := s.NewNote("Second", "Two.")
+
+	if first != 1 || second != 2 {
+		t.Errorf("Expected sequential IDs 1 and 2, got %d and %d", first, second)
+	}
+}
+
+func testGet(t *testing.T, s Storage) {
+	id, _ := s.NewNote("Title", "Content.")
+
+	note, err := s.GetNoteByID(id)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if note.ID != id || note.Title != "Title" || note.Content != "Content." {
+		t.Errorf("Expected the created note, got %+v", note)
+	}
+	if note.CreatedAt.IsZero() || note.LastEditedAt.IsZero() {
+		t.Errorf("Expected timestamps to be set, got %+v", note)
+	}
+}
+
+func testUpdate(t *testing.T, s Storage) {
+	id, _ := s.NewNote("Title", "Before.")
+
+	if err := s.SetNoteContent(id, "After."); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := s.GetNoteByID(id)
+	if note.Content != "After." || note.Title != "Title" {
+		t.Errorf("Expected updated content with the same title, got %+v", note)
+	}
+	if note.LastEditedAt.Before(note.CreatedAt) {
+		t.Errorf("Expected edit time not before creation time, got %+v", note)
+	}
+}
+
+func testUnchangedUpdate(t *testing.T, s Storage) {
+	id, _ := s.NewNote("Title", "Content.")
+	before, _ := s.GetNoteByID(id)
+
+	// setting the same content isn't an edit, so the edit time is kept even once it would differ
+	time.Sleep(20 * time.Millisecond)
+	if err := s.SetNoteContent(id, "Content."); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	note, _ := s.GetNoteByID(id)
+	if !note.LastEditedAt.Equal(before.LastEditedAt) {
+		t.Errorf("Expected edit time %s to be kept, got %s", before.LastEditedAt, note.LastEditedAt)
+	}
+}
+
+func testDelete(t *testing.T, s Storage) {
+	id, _ := s.NewNote("Title", "Content.")
+	other, _ := s.NewNote("Other", "Content.")
+
+	deleted, err := s.DeleteNote(id)
+	if err != nil || deleted != id {
+		t.Fatalf("Expected note %d to be deleted, got %d, %v", id, deleted, err)
+	}
+
+	if _, err = s.GetNoteByID(id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows for the deleted note, got %v", err)
+	}
+	if _, err = s.GetNoteByID(other); err != nil {
+		t.Errorf("Expected other note to stay, got %v", err)
+	}
+}
+
+func testSearch(t *testing.T, s Storage) {
+	groceries, _ := s.NewNote("Groceries", "Milk and BREAD.")
+	_, _ = s.NewNote("Meeting", "Project deadline.")
+	bakery, _ := s.NewNote("Bread shop", "Fresh pastries.")
+
+	// search matches titles and content ignoring ASCII case, results are ordered by ID
+	notes, err := s.SearchNotesByKeyword("bread")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 2 || notes[0].ID != groceries || notes[1].ID != bakery {
+		t.Errorf("Expected notes %d and %d, got %+v", groceries, bakery, notes)
+	}
+
+	notes, err = s.SearchNotesByKeyword("nothing like this")
+	if err != nil || len(notes) != 0 {
+		t.Errorf("Expected no notes and no error, got %+v, %v", notes, err)
+	}
+}
+
+func testList(t *testing.T, s Storage) {
+	notes, err := s.GetAllNotes()
+	if err != nil || len(notes) != 0 {
+		t.Errorf("Expected no notes in an empty storage, got %+v, %v", notes, err)
+	}
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		_, _ = s.NewNote(title, "Content.")
+	}
+
+	notes, err = s.GetAllNotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(notes) != 3 || notes[0].Title != "First" || notes[2].Title != "Third" {
+		t.Errorf("Expected 3 notes in creation order, got %+v", notes)
+	}
+}
+
+func testNotFound(t *testing.T, s Storage) {
+	if _, err := s.GetNoteByID(100); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows getting a missing note, got %v", err)
+	}
+	if _, err := s.DeleteNote(100); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows deleting a missing note, got %v", err)
+	}
+	if err := s.SetNoteContent(100, "Content."); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows updating a missing note, got %v", err)
+	}
+}
+
+func testValidation(t *testing.T, s Storage) {
+	if _, err := s.NewNote("", "Content."); err == nil {
+		t.Errorf("Expected error for an empty title, got nil")
+	}
+	if _, err := s.NewNote("Title", ""); err == nil {
+		t.Errorf("Expected error for empty content, got nil")
+	}
+	if _, err := s.NewNote("Title", strings.Repeat("a", 256001)); !errors.Is(err, storage.ErrContentTooLong) {
+		t.Errorf("Expected ErrContentTooLong for too long content, got %v", err)
+	}
+	if _, err := s.GetNoteByID(0); err == nil {
+		t.Errorf("Expected error for ID 0, got nil")
+	}
+	if _, err := s.DeleteNote(-1); err == nil {
+		t.Errorf("Expected error for a negative ID, got nil")
+	}
+	if _, err := s.SearchNotesByKeyword(""); err == nil {
+		t.Errorf("Expected error for an empty keyword, got nil")
+	}
+
+	// rejected notes are not stored
+	notes, _ := s.GetAllNotes()
+	if len(notes) != 0 {
+		t.Errorf("Expected no notes, got %+v", notes)
+	}
+}
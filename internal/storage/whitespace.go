@@ -0,0 +1,43 @@
+package storage
+
+import "strings"
+
+// TidyWhitespace normalizes whitespace of note content: trailing spaces and tabs of every line are stripped,
+// runs of blank lines are collapsed into a single one and blank lines at the start and the end are removed.
+// Line endings are kept, so CRLF content stays CRLF
+func TidyWhitespace(content string) string {
+	lines := strings.Split(content, "\n")
+
+	tidied := make([]string, 0, len(lines))
+	blank := true // blank lines at the start are dropped as if following another blank line
+	for _, line := range lines {
+		body, cr := strings.CutSuffix(line, "\r")
+		body = strings.TrimRight(body, " \t")
+
+		if body == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+
+		if cr {
+			body += "\r"
+		}
+		tidied = append(tidied, body)
+	}
+
+	// a single blank line may be left at the end
+	if blank && len(tidied) > 0 {
+		tidied = tidied[:len(tidied)-1]
+	}
+
+	// the last line isn't followed by a line ending, so its carriage return is trailing whitespace too
+	if len(tidied) > 0 {
+		tidied[len(tidied)-1] = strings.TrimSuffix(tidied[len(tidied)-1], "\r")
+	}
+
+	return strings.Join(tidied, "\n")
+}
@@ -0,0 +1,27 @@
+package storage
+
+import "testing"
+
+func TestTidyWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "tidy", content: "Title\n\nBody", expected: "Title\n\nBody"},
+		{name: "trailing spaces", content: "Line \t\nNext  ", expected: "Line\nNext"},
+		{name: "blank runs", content: "One\n\n\n  \n\nTwo", expected: "One\n\nTwo"},
+		{name: "blank edges", content: "\n \nBody\n\n\n", expected: "Body"},
+		{name: "crlf", content: "One \r\n\r\n\r\nTwo\r\n", expected: "One\r\n\r\nTwo"},
+		{name: "leading spaces kept", content: "  indented", expected: "  indented"},
+		{name: "only whitespace", content: " \n\t\n", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TidyWhitespace(tt.content); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
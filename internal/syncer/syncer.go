@@ -0,0 +1,184 @@
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go-notes/internal/entities"
+	"go-notes/internal/storage"
+)
+
+// Local is the local notes storage synchronized with a remote server
+type Local interface {
+	// GetNotesSinceID retrieves notes with ID greater than the specified one ordered by ID
+	GetNotesSinceID(id int) ([]entities.Note, error)
+
+	// ImportNote inserts a note keeping its ID, returns false if an identical note already exists
+	ImportNote(note entities.Note) (bool, error)
+
+	// GetSyncState retrieves IDs of the last note pushed to and pulled from the remote
+	GetSyncState(remote string) (pushedID, pulledID int, err error)
+
+	// SetSyncState stores IDs of the last note pushed to and pulled from the remote
+	SetSyncState(remote string, pushedID, pulledID int) error
+}
+
+// Result describes the outcome of a push or a pull
+type Result struct {
+	// Transferred is the number of notes created on the receiving side
+	Transferred int
+
+	// Conflicts holds IDs of notes which exist on both sides with different title or content
+	Conflicts []int
+}
+
+// Client synchronizes a local storage with a remote REST server
+type Client struct {
+	local Local
+	url   string
+	http  *http.Client
+}
+
+// New creates a sync client for the server at url, http.DefaultClient is used if httpClient is nil
+func New(local Local, url string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{local: local, url: strings.TrimRight(url, "/"), http: httpClient}
+}
+
+// Push sends local notes created since the last push to the remote.
+// Conflicting notes are reported and never overwritten, the next push retries from the first conflict
+func (c *Client) Push() (Result, error) {
+	pushedID, pulledID, err := c.local.GetSyncState(c.url)
+	if err != nil {
+		return Result{}, fmt.Errorf("reading sync state: %w", err)
+	}
+
+	notes, err := c.local.GetNotesSinceID(pushedID)
+	if err != nil {
+		return Result{}, fmt.Errorf("reading local notes: %w", err)
+	}
+
+	result, lastID, err := transfer(notes, pushedID, c.pushNote)
+
+	// remember progress even if transfer stopped halfway
+	if stateErr := c.local.SetSyncState(c.url, lastID, pulledID); stateErr != nil && err == nil {
+		err = fmt.Errorf("saving sync state: %w", stateErr)
+	}
+
+	return result, err
+}
+
+// Pull fetches remote notes created since the last pull into the local storage.
+// Conflicting notes are reported and never overwritten, the next pull retries from the first conflict
+func (c *Client) Pull() (Result, error) {
+	pushedID, pulledID, err := c.local.GetSyncState(c.url)
+	if err != nil {
+		return Result{}, fmt.Errorf("reading sync state: %w", err)
+	}
+
+	notes, err := c.fetchNotesSinceID(pulledID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, lastID, err := transfer(notes, pulledID, c.local.ImportNote)
+
+	// remember progress even if transfer stopped halfway
+	if stateErr := c.local.SetSyncState(c.url, pushedID, lastID); stateErr != nil && err == nil {
+		err = fmt.Errorf("saving sync state: %w", stateErr)
+	}
+
+	return result, err
+}
+
+// transfer imports notes ordered by ID one by one, collecting conflicts.
+// It returns the ID up to which all notes were transferred without conflicts
+func transfer(notes []entities.Note, fromID int, importNote func(entities.Note) (bool, error)) (Result, int, error) {
+	var result Result
+	lastID := fromID
+
+	for _, note := range notes {
+		created, err := importNote(note)
+		switch {
+		case errors.Is(err, storage.ErrConflict):
+			result.Conflicts = append(result.Conflicts, note.ID)
+			continue
+		case err != nil:
+			return result, lastID, fmt.Errorf("transferring note %d: %w", note.ID, err)
+		}
+
+		if created {
+			result.Transferred++
+		}
+
+		// progress only moves forward while there are no unresolved conflicts
+		if len(result.Conflicts) == 0 {
+			lastID = note.ID
+		}
+	}
+
+	return result, lastID, nil
+}
+
+// fetchNotesSinceID retrieves remote notes with ID greater than the specified one
+func (c *Client) fetchNotesSinceID(id int) ([]entities.Note, error) {
+	resp, err := c.http.Get(fmt.Sprintf("%s/notes?since_id=%d", c.url, id))
+	if err != nil {
+		return nil, fmt.Errorf("fetching remote notes: %w", err)
+	}
+	// ensure body is closed when done reading
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching remote notes: %w", responseError(resp))
+	}
+
+	var notes []entities.Note
+	if err = json.NewDecoder(resp.Body).Decode(&notes); err != nil {
+		return nil, fmt.Errorf("decoding remote notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// pushNote imports a single note into the remote keeping its ID
+func (c *Client) pushNote(note entities.Note) (bool, error) {
+	body, err := json.Marshal(note)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.http.Post(c.url+"/notes", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	// ensure body is closed when done reading
+	defer resp.Body.Close()
+
+	// remote responds 201 for created notes, 200 for already present identical ones and 409 for conflicts
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	case http.StatusConflict:
+		return false, storage.ErrConflict
+	default:
+		return false, responseError(resp)
+	}
+}
+
+// responseError builds an error from an unexpected response
+func responseError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("remote responded %s: %s", resp.Status, strings.TrimSpace(string(message)))
+}
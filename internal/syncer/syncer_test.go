@@ -0,0 +1,152 @@
+package syncer
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"go-notes/internal/server"
+	"go-notes/internal/storage/sqlite"
+)
+
+// newStorages creates local and remote storages and an HTTP server serving the remote one
+func newStorages(t *testing.T) (local, remote *sqlite.Storage, url string) {
+	dir := t.TempDir()
+	localPath, remotePath := filepath.Join(dir, "local.db"), filepath.Join(dir, "remote.db")
+
+	local, err := sqlite.New(localPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = local.Close() })
+
+	remote, err = sqlite.New(remotePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = remote.Close() })
+
+	ts := httptest.NewServer(server.New(remote))
+	t.Cleanup(ts.Close)
+
+	return local, remote, ts.URL
+}
+
+func TestPush(t *testing.T) {
+	local, remote, url := newStorages(t)
+
+	_, _ = local.NewNote("Note 1", "First note.")
+	_, _ = local.NewNote("Note 2", "Second note.")
+
+	client := New(local, url, nil)
+	result, err := client.Push()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Transferred != 2 || len(result.Conflicts) != 0 {
+		t.Errorf("Expected 2 transferred notes without conflicts, got %+v", result)
+	}
+
+	notes, _ := remote.GetAllNotes()
+	if len(notes) != 2 || notes[1].Title != "Note 2" {
+		t.Errorf("Expected remote to have both notes, got %v", notes)
+	}
+
+	// only notes created since the last push are sent
+	_, _ = local.NewNote("Note 3", "Third note.")
+	result, err = client.Push()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Transferred != 1 {
+		t.Errorf("Expected 1 transferred note, got %+v", result)
+	}
+
+	pushedID, _, _ := local.GetSyncState(url)
+	if pushedID != 3 {
+		t.Errorf("Expected last pushed ID 3, got %d", pushedID)
+	}
+}
+
+func TestPull(t *testing.T) {
+	local, remote, url := newStorages(t)
+
+	_, _ = remote.NewNote("Remote note", "Created on another machine.")
+
+	result, err := New(local, url, nil).Pull()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Transferred != 1 {
+		t.Errorf("Expected 1 transferred note, got %+v", result)
+	}
+
+	note, err := local.GetNoteByID(1)
+	if err != nil {
+		t.Fatalf("Expected pulled note, got %v", err)
+	}
+
+	if note.Content != "Created on another machine." {
+		t.Errorf("Expected pulled content, got %s", note.Content)
+	}
+}
+
+func TestPullReportsConflicts(t *testing.T) {
+	local, remote, url := newStorages(t)
+
+	// both sides have a note with ID 1, but with different content
+	_, _ = local.NewNote("Note", "Edited locally.")
+	_, _ = remote.NewNote("Note", "Edited remotely.")
+	_, _ = remote.NewNote("Other note", "No conflict here.")
+
+	client := New(local, url, nil)
+	result, err := client.Pull()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != 1 {
+		t.Errorf("Expected conflict for note 1, got %+v", result)
+	}
+
+	if result.Transferred != 1 {
+		t.Errorf("Expected non-conflicting note to be transferred, got %+v", result)
+	}
+
+	// local version is kept
+	note, _ := local.GetNoteByID(1)
+	if note.Content != "Edited locally." {
+		t.Errorf("Expected local content to be kept, got %s", note.Content)
+	}
+
+	// conflict is reported again until resolved
+	result, _ = client.Pull()
+	if len(result.Conflicts) != 1 {
+		t.Errorf("Expected conflict to be reported again, got %+v", result)
+	}
+}
+
+func TestPushReportsConflicts(t *testing.T) {
+	local, remote, url := newStorages(t)
+
+	_, _ = local.NewNote("Note", "Edited locally.")
+	_, _ = remote.NewNote("Note", "Edited remotely.")
+
+	result, err := New(local, url, nil).Push()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Conflicts) != 1 || result.Transferred != 0 {
+		t.Errorf("Expected one conflict and no transferred notes, got %+v", result)
+	}
+
+	// remote version is not overwritten
+	note, _ := remote.GetNoteByID(1)
+	if note.Content != "Edited remotely." {
+		t.Errorf("Expected remote content to be kept, got %s", note.Content)
+	}
+}